@@ -1,5 +1,11 @@
 package solana
 
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/btcsuite/btcutil/base58"
+)
+
 // AccountInfoEncodedData is information describing an account
 // with data field encoded according to a prescribed Encoding
 type AccountInfoEncodedData struct {
@@ -48,3 +54,22 @@ func (e AccountInfoEncodedData) GetData() string {
 	}
 	return e.Data[0]
 }
+
+// DecodeData decodes the Data field according to its Encoding.
+// Base58Encoding and Base64Encoding are supported.
+func (e AccountInfoEncodedData) DecodeData() ([]byte, error) {
+	switch e.GetEncoding() {
+	case Base64Encoding:
+		data, err := base64.StdEncoding.DecodeString(e.GetData())
+		if err != nil {
+			return nil, fmt.Errorf("error base64 decoding account data: %w", err)
+		}
+		return data, nil
+
+	case Base58Encoding:
+		return base58.Decode(e.GetData()), nil
+
+	default:
+		return nil, fmt.Errorf("'%s': %w", e.GetEncoding(), ErrUnsupportedEncoding)
+	}
+}
@@ -0,0 +1,49 @@
+package addressLookupTableProgram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/BRBussy/solgo"
+)
+
+type CloseLookupTableParams struct {
+	// LookupTablePubkey is the deactivated lookup table to close
+	// Req: [writer]
+	LookupTablePubkey solana.PublicKey
+
+	// AuthorityPubkey is the authority of the lookup table
+	// Req: [signer]
+	AuthorityPubkey solana.PublicKey
+
+	// RecipientPubkey is the account to which the lamports of the lookup table are transferred
+	// Req: [writer]
+	RecipientPubkey solana.PublicKey
+}
+
+// CloseLookupTable creates a Solana address lookup table program Instruction
+// to close a deactivated lookup table and reclaim its lamports.
+func CloseLookupTable(params CloseLookupTableParams) ([]solana.Instruction, error) {
+	// encode instruction data
+	buf := new(bytes.Buffer)
+	if err := binary.Write(
+		buf,
+		binary.LittleEndian,
+		CloseLookupTableInstruction,
+	); err != nil {
+		return nil, fmt.Errorf("error encoding close lookup table data: %w", err)
+	}
+
+	// construct and return instruction
+	return []solana.Instruction{
+		{
+			InstructionAccountMeta: []solana.InstructionAccountMeta{
+				{PubKey: params.LookupTablePubkey, IsSigner: false, IsWritable: true},
+				{PubKey: params.AuthorityPubkey, IsSigner: true, IsWritable: false},
+				{PubKey: params.RecipientPubkey, IsSigner: false, IsWritable: true},
+			},
+			ProgramIDPubKey: ID,
+			Data:            buf.Bytes(),
+		},
+	}, nil
+}
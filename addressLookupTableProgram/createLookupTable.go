@@ -0,0 +1,69 @@
+package addressLookupTableProgram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/BRBussy/solgo"
+	"github.com/BRBussy/solgo/systemProgram"
+)
+
+type CreateLookupTableParams struct {
+	// AuthorityPubkey is the authority of the new lookup table
+	// Req: [signer]
+	AuthorityPubkey solana.PublicKey
+
+	// PayerPubkey is the account that will pay for the lookup table account
+	// Req: [writer, signer]
+	PayerPubkey solana.PublicKey
+
+	// RecentSlot is a recent slot used to derive the lookup table address.
+	// It must be present in the SlotHashes sysvar when the instruction is processed.
+	// Use LookupTableAddress to derive the address of the created lookup table.
+	RecentSlot uint64
+}
+
+type createLookupTableInstructionData struct {
+	Instruction Instruction
+	RecentSlot  uint64
+	BumpSeed    uint8
+}
+
+// CreateLookupTable creates a Solana address lookup table program Instruction
+// to create a lookup table at the address derived from the AuthorityPubkey
+// and RecentSlot by LookupTableAddress.
+func CreateLookupTable(params CreateLookupTableParams) ([]solana.Instruction, error) {
+	// derive lookup table address
+	lookupTablePubkey, bumpSeed, err := LookupTableAddress(params.AuthorityPubkey, params.RecentSlot)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving lookup table address: %w", err)
+	}
+
+	// encode instruction data
+	buf := new(bytes.Buffer)
+	if err := binary.Write(
+		buf,
+		binary.LittleEndian,
+		createLookupTableInstructionData{
+			Instruction: CreateLookupTableInstruction,
+			RecentSlot:  params.RecentSlot,
+			BumpSeed:    bumpSeed,
+		},
+	); err != nil {
+		return nil, fmt.Errorf("error encoding create lookup table data: %w", err)
+	}
+
+	// construct and return instruction
+	return []solana.Instruction{
+		{
+			InstructionAccountMeta: []solana.InstructionAccountMeta{
+				{PubKey: lookupTablePubkey, IsSigner: false, IsWritable: true},
+				{PubKey: params.AuthorityPubkey, IsSigner: true, IsWritable: false},
+				{PubKey: params.PayerPubkey, IsSigner: true, IsWritable: true},
+				{PubKey: systemProgram.ID, IsSigner: false, IsWritable: false},
+			},
+			ProgramIDPubKey: ID,
+			Data:            buf.Bytes(),
+		},
+	}, nil
+}
@@ -0,0 +1,46 @@
+package addressLookupTableProgram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/BRBussy/solgo"
+)
+
+type DeactivateLookupTableParams struct {
+	// LookupTablePubkey is the lookup table to deactivate
+	// Req: [writer]
+	LookupTablePubkey solana.PublicKey
+
+	// AuthorityPubkey is the authority of the lookup table
+	// Req: [signer]
+	AuthorityPubkey solana.PublicKey
+}
+
+// DeactivateLookupTable creates a Solana address lookup table program Instruction
+// to deactivate a lookup table.
+// Once deactivated a lookup table can no longer be extended or used by
+// transactions, and can be closed once the deactivation cooldown has elapsed.
+func DeactivateLookupTable(params DeactivateLookupTableParams) ([]solana.Instruction, error) {
+	// encode instruction data
+	buf := new(bytes.Buffer)
+	if err := binary.Write(
+		buf,
+		binary.LittleEndian,
+		DeactivateLookupTableInstruction,
+	); err != nil {
+		return nil, fmt.Errorf("error encoding deactivate lookup table data: %w", err)
+	}
+
+	// construct and return instruction
+	return []solana.Instruction{
+		{
+			InstructionAccountMeta: []solana.InstructionAccountMeta{
+				{PubKey: params.LookupTablePubkey, IsSigner: false, IsWritable: true},
+				{PubKey: params.AuthorityPubkey, IsSigner: true, IsWritable: false},
+			},
+			ProgramIDPubKey: ID,
+			Data:            buf.Bytes(),
+		},
+	}, nil
+}
@@ -0,0 +1,10 @@
+package addressLookupTableProgram
+
+import "errors"
+
+var (
+	ErrInvalidStateData         = errors.New("invalid lookup table state data")
+	ErrUninitializedLookupTable = errors.New("uninitialized lookup table")
+	ErrLookupTableNotFound      = errors.New("lookup table not found")
+	ErrUnexpectedOwner          = errors.New("unexpected owner")
+)
@@ -0,0 +1,76 @@
+package addressLookupTableProgram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/BRBussy/solgo"
+	"github.com/BRBussy/solgo/systemProgram"
+)
+
+type ExtendLookupTableParams struct {
+	// LookupTablePubkey is the lookup table to extend
+	// Req: [writer]
+	LookupTablePubkey solana.PublicKey
+
+	// AuthorityPubkey is the authority of the lookup table
+	// Req: [signer]
+	AuthorityPubkey solana.PublicKey
+
+	// PayerPubkey is the account that will pay for the additional rent
+	// required by the extended lookup table.
+	// Not required if the lookup table already holds enough lamports.
+	// Req: [writer, signer]
+	PayerPubkey *solana.PublicKey
+
+	// NewAddresses are the addresses to append to the lookup table
+	NewAddresses []solana.PublicKey
+}
+
+type extendLookupTableInstructionDataHeader struct {
+	Instruction       Instruction
+	NewAddressesCount uint64
+}
+
+// ExtendLookupTable creates a Solana address lookup table program Instruction
+// to append addresses to a lookup table.
+func ExtendLookupTable(params ExtendLookupTableParams) ([]solana.Instruction, error) {
+	// encode instruction data
+	buf := new(bytes.Buffer)
+	if err := binary.Write(
+		buf,
+		binary.LittleEndian,
+		extendLookupTableInstructionDataHeader{
+			Instruction:       ExtendLookupTableInstruction,
+			NewAddressesCount: uint64(len(params.NewAddresses)),
+		},
+	); err != nil {
+		return nil, fmt.Errorf("error encoding extend lookup table data: %w", err)
+	}
+	for _, address := range params.NewAddresses {
+		b := address.ToBytes()
+		buf.Write(b[:])
+	}
+
+	// prepare accounts
+	accountMetas := []solana.InstructionAccountMeta{
+		{PubKey: params.LookupTablePubkey, IsSigner: false, IsWritable: true},
+		{PubKey: params.AuthorityPubkey, IsSigner: true, IsWritable: false},
+	}
+	if params.PayerPubkey != nil {
+		accountMetas = append(
+			accountMetas,
+			solana.InstructionAccountMeta{PubKey: *params.PayerPubkey, IsSigner: true, IsWritable: true},
+			solana.InstructionAccountMeta{PubKey: systemProgram.ID, IsSigner: false, IsWritable: false},
+		)
+	}
+
+	// construct and return instruction
+	return []solana.Instruction{
+		{
+			InstructionAccountMeta: accountMetas,
+			ProgramIDPubKey:        ID,
+			Data:                   buf.Bytes(),
+		},
+	}, nil
+}
@@ -0,0 +1,45 @@
+package addressLookupTableProgram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/BRBussy/solgo"
+)
+
+type FreezeLookupTableParams struct {
+	// LookupTablePubkey is the lookup table to freeze
+	// Req: [writer]
+	LookupTablePubkey solana.PublicKey
+
+	// AuthorityPubkey is the authority of the lookup table
+	// Req: [signer]
+	AuthorityPubkey solana.PublicKey
+}
+
+// FreezeLookupTable creates a Solana address lookup table program Instruction
+// to freeze a lookup table, preventing further changes to it.
+// A frozen lookup table can no longer be extended, deactivated or closed.
+func FreezeLookupTable(params FreezeLookupTableParams) ([]solana.Instruction, error) {
+	// encode instruction data
+	buf := new(bytes.Buffer)
+	if err := binary.Write(
+		buf,
+		binary.LittleEndian,
+		FreezeLookupTableInstruction,
+	); err != nil {
+		return nil, fmt.Errorf("error encoding freeze lookup table data: %w", err)
+	}
+
+	// construct and return instruction
+	return []solana.Instruction{
+		{
+			InstructionAccountMeta: []solana.InstructionAccountMeta{
+				{PubKey: params.LookupTablePubkey, IsSigner: false, IsWritable: true},
+				{PubKey: params.AuthorityPubkey, IsSigner: true, IsWritable: false},
+			},
+			ProgramIDPubKey: ID,
+			Data:            buf.Bytes(),
+		},
+	}, nil
+}
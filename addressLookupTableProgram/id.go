@@ -0,0 +1,21 @@
+// Package addressLookupTableProgram provides a set of functions for constructing Solana
+// address lookup table program instructions and decoding lookup table account state.
+// See instruction definitions here:
+// https://github.com/solana-labs/solana/blob/v1.16.0/programs/address-lookup-table/src/instruction.rs
+package addressLookupTableProgram
+
+import (
+	"encoding/binary"
+	solana "github.com/BRBussy/solgo"
+)
+
+// ID is the Solana address lookup table program ID
+var ID = solana.NewPublicKeyFromBase58String("AddressLookupTab1e1111111111111111111111111")
+
+// LookupTableAddress derives the address and bump seed of the lookup table
+// created by the given authority with the given recent slot.
+func LookupTableAddress(authority solana.PublicKey, recentSlot uint64) (solana.PublicKey, uint8, error) {
+	recentSlotBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(recentSlotBytes, recentSlot)
+	return solana.FindProgramAddress([][]byte{authority.PublicKey, recentSlotBytes}, ID)
+}
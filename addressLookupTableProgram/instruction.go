@@ -0,0 +1,13 @@
+package addressLookupTableProgram
+
+// Instruction is a Solana address lookup table program Instruction.
+// See rust defs here: https://github.com/solana-labs/solana/blob/v1.16.0/programs/address-lookup-table/src/instruction.rs
+type Instruction uint32
+
+const (
+	CreateLookupTableInstruction Instruction = iota
+	FreezeLookupTableInstruction
+	ExtendLookupTableInstruction
+	DeactivateLookupTableInstruction
+	CloseLookupTableInstruction
+)
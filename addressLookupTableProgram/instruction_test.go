@@ -0,0 +1,156 @@
+package addressLookupTableProgram
+
+import (
+	"github.com/BRBussy/solgo"
+	"github.com/BRBussy/solgo/systemProgram"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestExtendLookupTable(t *testing.T) {
+	lookupTable := solana.MustNewRandomKeypair().PublicKey
+	authority := solana.MustNewRandomKeypair().PublicKey
+	payer := solana.MustNewRandomKeypair().PublicKey
+	address1 := solana.MustNewRandomKeypair().PublicKey
+	address2 := solana.MustNewRandomKeypair().PublicKey
+	address1Bytes := address1.ToBytes()
+	address2Bytes := address2.ToBytes()
+
+	data := append(
+		[]byte{
+			2, 0, 0, 0, // extend lookup table instruction, u32
+			2, 0, 0, 0, 0, 0, 0, 0, // number of new addresses, u64
+		},
+		append(address1Bytes[:], address2Bytes[:]...)...,
+	)
+
+	tests := []struct {
+		name         string
+		payer        *solana.PublicKey
+		wantAccounts []solana.InstructionAccountMeta
+	}{
+		{
+			name: "without payer",
+			wantAccounts: []solana.InstructionAccountMeta{
+				{PubKey: lookupTable, IsWritable: true},
+				{PubKey: authority, IsSigner: true},
+			},
+		},
+		{
+			name:  "with payer",
+			payer: &payer,
+			wantAccounts: []solana.InstructionAccountMeta{
+				{PubKey: lookupTable, IsWritable: true},
+				{PubKey: authority, IsSigner: true},
+				{PubKey: payer, IsSigner: true, IsWritable: true},
+				{PubKey: systemProgram.ID},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instructions, err := ExtendLookupTable(ExtendLookupTableParams{
+				LookupTablePubkey: lookupTable,
+				AuthorityPubkey:   authority,
+				PayerPubkey:       tt.payer,
+				NewAddresses:      []solana.PublicKey{address1, address2},
+			})
+			require.Nil(t, err)
+			require.Equal(
+				t,
+				[]solana.Instruction{
+					{
+						InstructionAccountMeta: tt.wantAccounts,
+						ProgramIDPubKey:        ID,
+						Data:                   data,
+					},
+				},
+				instructions,
+			)
+		})
+	}
+}
+
+func TestDeactivateLookupTable(t *testing.T) {
+	lookupTable := solana.MustNewRandomKeypair().PublicKey
+	authority := solana.MustNewRandomKeypair().PublicKey
+
+	instructions, err := DeactivateLookupTable(DeactivateLookupTableParams{
+		LookupTablePubkey: lookupTable,
+		AuthorityPubkey:   authority,
+	})
+	require.Nil(t, err)
+	require.Equal(
+		t,
+		[]solana.Instruction{
+			{
+				InstructionAccountMeta: []solana.InstructionAccountMeta{
+					{PubKey: lookupTable, IsWritable: true},
+					{PubKey: authority, IsSigner: true},
+				},
+				ProgramIDPubKey: ID,
+				Data: []byte{
+					3, 0, 0, 0, // deactivate lookup table instruction, u32
+				},
+			},
+		},
+		instructions,
+	)
+}
+
+func TestFreezeLookupTable(t *testing.T) {
+	lookupTable := solana.MustNewRandomKeypair().PublicKey
+	authority := solana.MustNewRandomKeypair().PublicKey
+
+	instructions, err := FreezeLookupTable(FreezeLookupTableParams{
+		LookupTablePubkey: lookupTable,
+		AuthorityPubkey:   authority,
+	})
+	require.Nil(t, err)
+	require.Equal(
+		t,
+		[]solana.Instruction{
+			{
+				InstructionAccountMeta: []solana.InstructionAccountMeta{
+					{PubKey: lookupTable, IsWritable: true},
+					{PubKey: authority, IsSigner: true},
+				},
+				ProgramIDPubKey: ID,
+				Data: []byte{
+					1, 0, 0, 0, // freeze lookup table instruction, u32
+				},
+			},
+		},
+		instructions,
+	)
+}
+
+func TestCloseLookupTable(t *testing.T) {
+	lookupTable := solana.MustNewRandomKeypair().PublicKey
+	authority := solana.MustNewRandomKeypair().PublicKey
+	recipient := solana.MustNewRandomKeypair().PublicKey
+
+	instructions, err := CloseLookupTable(CloseLookupTableParams{
+		LookupTablePubkey: lookupTable,
+		AuthorityPubkey:   authority,
+		RecipientPubkey:   recipient,
+	})
+	require.Nil(t, err)
+	require.Equal(
+		t,
+		[]solana.Instruction{
+			{
+				InstructionAccountMeta: []solana.InstructionAccountMeta{
+					{PubKey: lookupTable, IsWritable: true},
+					{PubKey: authority, IsSigner: true},
+					{PubKey: recipient, IsWritable: true},
+				},
+				ProgramIDPubKey: ID,
+				Data: []byte{
+					4, 0, 0, 0, // close lookup table instruction, u32
+				},
+			},
+		},
+		instructions,
+	)
+}
@@ -0,0 +1,150 @@
+package addressLookupTableProgram
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"github.com/BRBussy/solgo"
+	"math"
+)
+
+// StateType is the type of an account owned by the address lookup table program
+// See rust defs here: https://github.com/solana-labs/solana/blob/v1.16.0/programs/address-lookup-table/src/state.rs
+type StateType uint32
+
+const (
+	UninitializedStateType StateType = iota
+	LookupTableStateType
+)
+
+const (
+	// LookupTableMetaSize is the size of the metadata preceding
+	// the addresses in a lookup table account
+	LookupTableMetaSize = 56
+
+	// LookupTableMaxAddresses is the maximum number of addresses that a lookup table can hold
+	LookupTableMaxAddresses = 256
+)
+
+// LookupTable is the decoded state of a lookup table account
+type LookupTable struct {
+	// DeactivationSlot is the slot at which the lookup table was deactivated.
+	// Set to math.MaxUint64 while the lookup table is active.
+	DeactivationSlot uint64
+
+	// LastExtendedSlot is the slot at which the lookup table was last extended
+	LastExtendedSlot uint64
+
+	// LastExtendedSlotStartIndex is the index of the first address
+	// added when the lookup table was last extended
+	LastExtendedSlotStartIndex uint8
+
+	// Authority is the authority of the lookup table.
+	// nil if the lookup table has been frozen.
+	Authority *solana.PublicKey
+
+	// Addresses are the addresses held in the lookup table
+	Addresses []solana.PublicKey
+}
+
+// IsActive returns true if the lookup table has not been deactivated
+func (l LookupTable) IsActive() bool {
+	return l.DeactivationSlot == math.MaxUint64
+}
+
+// IsFrozen returns true if the lookup table has been frozen
+func (l LookupTable) IsFrozen() bool {
+	return l.Authority == nil
+}
+
+// DecodeLookupTable decodes the given lookup table account data into a LookupTable
+func DecodeLookupTable(data []byte) (*LookupTable, error) {
+	if len(data) < LookupTableMetaSize {
+		return nil, fmt.Errorf("data shorter than lookup table metadata: %w", ErrInvalidStateData)
+	}
+	r := bytes.NewReader(data[:LookupTableMetaSize])
+
+	// read state type
+	var stateType StateType
+	if err := binary.Read(r, binary.LittleEndian, &stateType); err != nil {
+		return nil, fmt.Errorf("error reading state type: %w", err)
+	}
+	switch stateType {
+	case LookupTableStateType:
+	case UninitializedStateType:
+		return nil, ErrUninitializedLookupTable
+	default:
+		return nil, fmt.Errorf("state type %d: %w", stateType, ErrInvalidStateData)
+	}
+
+	// read metadata
+	var meta struct {
+		DeactivationSlot           uint64
+		LastExtendedSlot           uint64
+		LastExtendedSlotStartIndex uint8
+		AuthoritySet               uint8
+		Authority                  [32]byte
+	}
+	if err := binary.Read(r, binary.LittleEndian, &meta); err != nil {
+		return nil, fmt.Errorf("error reading lookup table metadata: %w", err)
+	}
+	lookupTable := LookupTable{
+		DeactivationSlot:           meta.DeactivationSlot,
+		LastExtendedSlot:           meta.LastExtendedSlot,
+		LastExtendedSlotStartIndex: meta.LastExtendedSlotStartIndex,
+	}
+	if meta.AuthoritySet == 1 {
+		authority := solana.NewPublicKeyFromBytes(meta.Authority)
+		lookupTable.Authority = &authority
+	}
+
+	// read addresses
+	addressData := data[LookupTableMetaSize:]
+	if len(addressData)%32 != 0 {
+		return nil, fmt.Errorf("address data of %d bytes: %w", len(addressData), ErrInvalidStateData)
+	}
+	lookupTable.Addresses = make([]solana.PublicKey, 0, len(addressData)/32)
+	for i := 0; i < len(addressData); i += 32 {
+		var address [32]byte
+		copy(address[:], addressData[i:i+32])
+		lookupTable.Addresses = append(lookupTable.Addresses, solana.NewPublicKeyFromBytes(address))
+	}
+
+	return &lookupTable, nil
+}
+
+// GetLookupTable gets and decodes the lookup table at the given address using the given
+// solana.Connection. ErrLookupTableNotFound is returned if the account does not exist.
+func GetLookupTable(
+	ctx context.Context,
+	connection solana.Connection,
+	lookupTablePubkey solana.PublicKey,
+	commitmentLevel solana.CommitmentLevel,
+) (*LookupTable, error) {
+	getAccountInfoResponse, err := connection.GetAccountInfo(
+		ctx,
+		solana.GetAccountInfoRequest{
+			PublicKey:       lookupTablePubkey,
+			CommitmentLevel: commitmentLevel,
+			Encoding:        solana.Base64Encoding,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error getting lookup table account info: %w", err)
+	}
+	accountInfo, ok := getAccountInfoResponse.AccountInfo.(solana.AccountInfoEncodedData)
+	if !ok || accountInfo.GetOwner() == "" {
+		return nil, ErrLookupTableNotFound
+	}
+	if accountInfo.GetOwner() != ID.ToBase58() {
+		return nil, fmt.Errorf("account owned by %s: %w", accountInfo.GetOwner(), ErrUnexpectedOwner)
+	}
+
+	data, err := accountInfo.DecodeData()
+	if err != nil {
+		return nil, fmt.Errorf("error decoding lookup table account data: %w", err)
+	}
+
+	return DecodeLookupTable(data)
+}
@@ -0,0 +1,114 @@
+package addressLookupTableProgram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/BRBussy/solgo"
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+)
+
+func TestDecodeLookupTable(t *testing.T) {
+	authority := solana.MustNewRandomKeypair().PublicKey
+	address1 := solana.MustNewRandomKeypair().PublicKey
+	address2 := solana.MustNewRandomKeypair().PublicKey
+
+	// encodeLookupTable builds serialised lookup table data
+	encodeLookupTable := func(stateType StateType, deactivationSlot uint64, authority *solana.PublicKey, addresses ...solana.PublicKey) []byte {
+		buf := new(bytes.Buffer)
+		require.Nil(t, binary.Write(buf, binary.LittleEndian, stateType))
+		require.Nil(t, binary.Write(buf, binary.LittleEndian, deactivationSlot))
+		require.Nil(t, binary.Write(buf, binary.LittleEndian, uint64(200)))
+		buf.WriteByte(1)
+		if authority != nil {
+			buf.WriteByte(1)
+			buf.Write(authority.PublicKey)
+		} else {
+			buf.Write(make([]byte, 33))
+		}
+		buf.Write(make([]byte, 2))
+		for _, address := range addresses {
+			buf.Write(address.PublicKey)
+		}
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		name       string
+		data       []byte
+		want       *LookupTable
+		wantActive bool
+		wantFrozen bool
+		wantErr    error
+	}{
+		{
+			name: "active lookup table",
+			data: encodeLookupTable(LookupTableStateType, math.MaxUint64, &authority, address1, address2),
+			want: &LookupTable{
+				DeactivationSlot:           math.MaxUint64,
+				LastExtendedSlot:           200,
+				LastExtendedSlotStartIndex: 1,
+				Authority:                  &authority,
+				Addresses:                  []solana.PublicKey{address1, address2},
+			},
+			wantActive: true,
+		},
+		{
+			name: "frozen and deactivated lookup table",
+			data: encodeLookupTable(LookupTableStateType, 300, nil),
+			want: &LookupTable{
+				DeactivationSlot:           300,
+				LastExtendedSlot:           200,
+				LastExtendedSlotStartIndex: 1,
+				Addresses:                  []solana.PublicKey{},
+			},
+			wantFrozen: true,
+		},
+		{
+			name:    "uninitialized",
+			data:    encodeLookupTable(UninitializedStateType, 0, nil),
+			wantErr: ErrUninitializedLookupTable,
+		},
+		{
+			name:    "too short",
+			data:    []byte{1, 0, 0, 0},
+			wantErr: ErrInvalidStateData,
+		},
+		{
+			name:    "partial address",
+			data:    append(encodeLookupTable(LookupTableStateType, 0, nil), 1, 2, 3),
+			wantErr: ErrInvalidStateData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeLookupTable(tt.data)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, got)
+			if got != nil {
+				require.Equal(t, tt.wantActive, got.IsActive())
+				require.Equal(t, tt.wantFrozen, got.IsFrozen())
+			}
+		})
+	}
+}
+
+func TestCreateLookupTable(t *testing.T) {
+	authority := solana.MustNewRandomKeypair().PublicKey
+	payer := solana.MustNewRandomKeypair().PublicKey
+
+	instructions, err := CreateLookupTable(CreateLookupTableParams{
+		AuthorityPubkey: authority,
+		PayerPubkey:     payer,
+		RecentSlot:      0x0102,
+	})
+	require.Nil(t, err)
+	require.Len(t, instructions, 1)
+
+	// lookup table address is derived from the authority and recent slot
+	address, bumpSeed, err := LookupTableAddress(authority, 0x0102)
+	require.Nil(t, err)
+	require.Equal(t, address, instructions[0].InstructionAccountMeta[0].PubKey)
+	require.Equal(t, []byte{0, 0, 0, 0, 0x02, 0x01, 0, 0, 0, 0, 0, 0, bumpSeed}, instructions[0].Data)
+}
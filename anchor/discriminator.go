@@ -0,0 +1,79 @@
+// Package anchor provides support for interacting with programs built with the Anchor framework.
+// See the framework here: https://www.anchor-lang.com
+package anchor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/BRBussy/solgo/borsh"
+)
+
+// DiscriminatorSize is the size of an Anchor discriminator
+const DiscriminatorSize = 8
+
+// Discriminator is the 8 byte prefix of Anchor instruction data, account data and event data
+// that identifies the instruction, account or event type.
+type Discriminator [DiscriminatorSize]byte
+
+// NewDiscriminator returns the Discriminator for the given namespace and name, which is
+// the first 8 bytes of the sha256 hash of "<namespace>:<name>".
+func NewDiscriminator(namespace, name string) Discriminator {
+	hash := sha256.Sum256([]byte(namespace + ":" + name))
+	var discriminator Discriminator
+	copy(discriminator[:], hash[:DiscriminatorSize])
+	return discriminator
+}
+
+// NewInstructionDiscriminator returns the Discriminator of the instruction with the given
+// snake case name, e.g. "initialize_counter".
+func NewInstructionDiscriminator(name string) Discriminator {
+	return NewDiscriminator("global", name)
+}
+
+// NewAccountDiscriminator returns the Discriminator of the account with the given name, e.g. "Counter"
+func NewAccountDiscriminator(name string) Discriminator {
+	return NewDiscriminator("account", name)
+}
+
+// NewEventDiscriminator returns the Discriminator of the event with the given name, e.g. "CounterIncremented"
+func NewEventDiscriminator(name string) Discriminator {
+	return NewDiscriminator("event", name)
+}
+
+// ToBytes returns the Discriminator as a byte slice
+func (d Discriminator) ToBytes() []byte {
+	return append([]byte{}, d[:]...)
+}
+
+// String returns the hex encoding of the Discriminator
+func (d Discriminator) String() string {
+	return hex.EncodeToString(d[:])
+}
+
+// DecodeAccount checks that the given account data is prefixed with the given Discriminator
+// and decodes the remaining data into the value pointed to by v.
+func DecodeAccount(data []byte, discriminator Discriminator, v interface{}) error {
+	return decodeWithDiscriminator(data, discriminator, v)
+}
+
+// DecodeEvent checks that the given event data is prefixed with the given Discriminator
+// and decodes the remaining data into the value pointed to by v.
+func DecodeEvent(data []byte, discriminator Discriminator, v interface{}) error {
+	return decodeWithDiscriminator(data, discriminator, v)
+}
+
+func decodeWithDiscriminator(data []byte, discriminator Discriminator, v interface{}) error {
+	if len(data) < DiscriminatorSize {
+		return fmt.Errorf("data of %d bytes: %w", len(data), ErrDataTooShort)
+	}
+	var got Discriminator
+	copy(got[:], data)
+	if got != discriminator {
+		return fmt.Errorf("expected %s, got %s: %w", discriminator, got, ErrUnexpectedDiscriminator)
+	}
+	if err := borsh.Unmarshal(data[DiscriminatorSize:], v); err != nil {
+		return fmt.Errorf("error decoding data: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package anchor
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNewDiscriminator(t *testing.T) {
+	tests := []struct {
+		name          string
+		discriminator Discriminator
+		want          string
+	}{
+		{
+			name:          "instruction",
+			discriminator: NewInstructionDiscriminator("initialize"),
+			want:          "afaf6d1f0d989bed",
+		},
+		{
+			name:          "account",
+			discriminator: NewAccountDiscriminator("NewAccount"),
+			want:          "b05f04765bb17de8",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.discriminator.String())
+		})
+	}
+}
+
+func TestDecodeAccount(t *testing.T) {
+	discriminator := NewAccountDiscriminator("Counter")
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    uint64
+		wantErr error
+	}{
+		{
+			name: "matching discriminator",
+			data: append(discriminator.ToBytes(), 5, 0, 0, 0, 0, 0, 0, 0),
+			want: 5,
+		},
+		{
+			name:    "unexpected discriminator",
+			data:    append(NewAccountDiscriminator("Other").ToBytes(), 5, 0, 0, 0, 0, 0, 0, 0),
+			wantErr: ErrUnexpectedDiscriminator,
+		},
+		{
+			name:    "data too short",
+			data:    discriminator.ToBytes()[:4],
+			wantErr: ErrDataTooShort,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got uint64
+			err := DecodeAccount(tt.data, discriminator, &got)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
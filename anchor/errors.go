@@ -0,0 +1,11 @@
+package anchor
+
+import "errors"
+
+var (
+	ErrDataTooShort            = errors.New("data too short")
+	ErrUnexpectedDiscriminator = errors.New("unexpected discriminator")
+	ErrInvalidEventType        = errors.New("invalid event type")
+	ErrEventAlreadyRegistered  = errors.New("event already registered")
+	ErrUnknownEvent            = errors.New("unknown event")
+)
@@ -0,0 +1,123 @@
+package anchor
+
+import (
+	"errors"
+	"fmt"
+	"github.com/BRBussy/solgo"
+	"github.com/BRBussy/solgo/programLogs"
+	"reflect"
+	"sync"
+)
+
+// Event is a decoded Anchor event
+type Event struct {
+	// Name is the name with which the event type was registered
+	Name string
+
+	// ProgramID is the program that emitted the event
+	ProgramID solana.PublicKey
+
+	// Data is a pointer to the decoded event, of the registered type
+	Data interface{}
+}
+
+// EventRegistry decodes Anchor events into registered Go types.
+// It is safe for concurrent use.
+type EventRegistry struct {
+	mutex  sync.RWMutex
+	events map[Discriminator]registeredEvent
+}
+
+type registeredEvent struct {
+	name      string
+	eventType reflect.Type
+}
+
+// NewEventRegistry returns a new empty EventRegistry
+func NewEventRegistry() *EventRegistry {
+	return &EventRegistry{events: make(map[Discriminator]registeredEvent)}
+}
+
+// Register registers the type of the given event value, e.g. CounterIncremented{}, to be
+// decoded from events with the Discriminator of the given event name.
+func (r *EventRegistry) Register(name string, event interface{}) error {
+	return r.RegisterWithDiscriminator(NewEventDiscriminator(name), name, event)
+}
+
+// RegisterWithDiscriminator registers the type of the given event value
+// to be decoded from events with the given Discriminator.
+func (r *EventRegistry) RegisterWithDiscriminator(discriminator Discriminator, name string, event interface{}) error {
+	eventType := reflect.TypeOf(event)
+	if eventType == nil {
+		return fmt.Errorf("nil event: %w", ErrInvalidEventType)
+	}
+	if eventType.Kind() == reflect.Ptr {
+		eventType = eventType.Elem()
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if registered, found := r.events[discriminator]; found {
+		return fmt.Errorf("%s conflicts with %s: %w", name, registered.name, ErrEventAlreadyRegistered)
+	}
+	r.events[discriminator] = registeredEvent{name: name, eventType: eventType}
+	return nil
+}
+
+// Decode decodes the given event data into a new value of the type registered for its Discriminator.
+// ErrUnknownEvent is returned if no type is registered for the Discriminator.
+func (r *EventRegistry) Decode(data []byte) (*Event, error) {
+	if len(data) < DiscriminatorSize {
+		return nil, fmt.Errorf("event data of %d bytes: %w", len(data), ErrDataTooShort)
+	}
+	var discriminator Discriminator
+	copy(discriminator[:], data)
+
+	r.mutex.RLock()
+	registered, found := r.events[discriminator]
+	r.mutex.RUnlock()
+	if !found {
+		return nil, fmt.Errorf("discriminator %s: %w", discriminator, ErrUnknownEvent)
+	}
+
+	value := reflect.New(registered.eventType)
+	if err := DecodeEvent(data, discriminator, value.Interface()); err != nil {
+		return nil, fmt.Errorf("error decoding %s event: %w", registered.name, err)
+	}
+	return &Event{Name: registered.name, Data: value.Interface()}, nil
+}
+
+// ParseEvents parses the given transaction log messages and decodes the events emitted by the
+// program with the given ID, in log order. Events emitted by any program are decoded if programID is
+// the zero PublicKey. Data with a Discriminator that has not been registered is skipped.
+func (r *EventRegistry) ParseEvents(programID solana.PublicKey, logMessages []string) ([]Event, error) {
+	// parse logs
+	logs, err := programLogs.Parse(logMessages)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing logs: %w", err)
+	}
+
+	// decode events from data emitted by the program
+	var events []Event
+	for _, data := range logs.Data {
+		if len(programID.PublicKey) != 0 && !programID.Equal(data.ProgramID.PublicKey) {
+			continue
+		}
+
+		// events are emitted as a single data field
+		if len(data.Fields) != 1 {
+			continue
+		}
+		event, err := r.Decode(data.Fields[0])
+		if err != nil {
+			if errors.Is(err, ErrUnknownEvent) || errors.Is(err, ErrDataTooShort) {
+				continue
+			}
+			return nil, err
+		}
+		event.ProgramID = data.ProgramID
+		events = append(events, *event)
+	}
+
+	return events, nil
+}
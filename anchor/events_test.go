@@ -0,0 +1,80 @@
+package anchor
+
+import (
+	"encoding/base64"
+	"github.com/BRBussy/solgo"
+	"github.com/BRBussy/solgo/borsh"
+	"github.com/BRBussy/solgo/programLogs"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type testEvent struct {
+	Count uint64
+	Label string
+}
+
+func TestEventRegistryParseEvents(t *testing.T) {
+	program := solana.MustNewRandomKeypair().PublicKey
+	otherProgram := solana.MustNewRandomKeypair().PublicKey
+
+	registry := NewEventRegistry()
+	require.Nil(t, registry.Register("TestEvent", testEvent{}))
+	require.ErrorIs(t, registry.Register("TestEvent", testEvent{}), ErrEventAlreadyRegistered)
+
+	// eventLog returns the log message emitting the given event
+	eventLog := func(name string, event interface{}) string {
+		data, err := borsh.Marshal(event)
+		require.Nil(t, err)
+		return "Program data: " + base64.StdEncoding.EncodeToString(append(NewEventDiscriminator(name).ToBytes(), data...))
+	}
+	logMessages := []string{
+		"Program " + program.ToBase58() + " invoke [1]",
+		eventLog("TestEvent", testEvent{Count: 1, Label: "a"}),
+		eventLog("UnknownEvent", testEvent{}),
+		"Program " + otherProgram.ToBase58() + " invoke [2]",
+		eventLog("TestEvent", testEvent{Count: 2, Label: "b"}),
+		"Program " + otherProgram.ToBase58() + " success",
+		eventLog("TestEvent", testEvent{Count: 3, Label: "c"}),
+		"Program " + program.ToBase58() + " success",
+	}
+
+	tests := []struct {
+		name      string
+		programID solana.PublicKey
+		want      []Event
+	}{
+		{
+			name:      "events of program",
+			programID: program,
+			want: []Event{
+				{Name: "TestEvent", ProgramID: program, Data: &testEvent{Count: 1, Label: "a"}},
+				{Name: "TestEvent", ProgramID: program, Data: &testEvent{Count: 3, Label: "c"}},
+			},
+		},
+		{
+			name: "events of any program in log order",
+			want: []Event{
+				{Name: "TestEvent", ProgramID: program, Data: &testEvent{Count: 1, Label: "a"}},
+				{Name: "TestEvent", ProgramID: otherProgram, Data: &testEvent{Count: 2, Label: "b"}},
+				{Name: "TestEvent", ProgramID: program, Data: &testEvent{Count: 3, Label: "c"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := registry.ParseEvents(tt.programID, logMessages)
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	// malformed logs are rejected
+	_, err := registry.ParseEvents(program, []string{eventLog("TestEvent", testEvent{})})
+	require.ErrorIs(t, err, programLogs.ErrUnexpectedLog)
+	_, err = registry.ParseEvents(program, []string{
+		"Program " + program.ToBase58() + " invoke [1]",
+		"Program data: !!!",
+	})
+	require.ErrorIs(t, err, programLogs.ErrInvalidData)
+}
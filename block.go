@@ -0,0 +1,129 @@
+package solana
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TransactionDetails is the level of transaction detail returned in a Block
+type TransactionDetails string
+
+const (
+	// FullTransactionDetails returns the transactions of a block with their status metadata
+	FullTransactionDetails TransactionDetails = "full"
+
+	// SignaturesTransactionDetails returns only the signatures of the transactions of a block
+	SignaturesTransactionDetails TransactionDetails = "signatures"
+
+	// NoneTransactionDetails returns no transaction details
+	NoneTransactionDetails TransactionDetails = "none"
+)
+
+// Block is a confirmed block
+type Block struct {
+	// BlockHeight is the number of blocks beneath this block, nil if not available
+	BlockHeight *uint64
+
+	// BlockTime is the estimated production time of the block
+	// as a unix timestamp. nil if not available.
+	BlockTime *int64
+
+	// BlockHash is the base58 encoded hash of the block
+	BlockHash string
+
+	// PreviousBlockHash is the base58 encoded hash of the parent of the block
+	PreviousBlockHash string
+
+	// ParentSlot is the slot of the parent of the block
+	ParentSlot uint64
+
+	// Transactions are the transactions of the block,
+	// set if FullTransactionDetails were requested
+	Transactions []BlockTransaction
+
+	// Signatures are the base58 encoded signatures of the transactions of the block,
+	// set if SignaturesTransactionDetails were requested
+	Signatures []string
+
+	// Rewards are the rewards paid out in the block, set if rewards were requested
+	Rewards []Reward
+}
+
+// BlockTransaction is a transaction of a Block
+type BlockTransaction struct {
+	// Version is the version of the transaction
+	Version TransactionVersion
+
+	// Transaction is the transaction in the requested Encoding
+	Transaction EncodedTransaction
+
+	// Meta is the transaction status metadata. nil if not available.
+	Meta *TransactionMeta
+}
+
+// Reward is a reward paid to an account
+type Reward struct {
+	// PubKey is the base58 encoded address of the account that received the reward
+	PubKey string `json:"pubkey"`
+
+	// Lamports is the number of reward lamports credited or debited
+	Lamports int64 `json:"lamports"`
+
+	// PostBalance is the lamport balance of the account after the reward was applied
+	PostBalance uint64 `json:"postBalance"`
+
+	// RewardType is one of "fee", "rent", "voting" or "staking"
+	RewardType string `json:"rewardType"`
+
+	// Commission is the vote account commission when the
+	// reward was credited, set for voting and staking rewards
+	Commission *uint8 `json:"commission"`
+}
+
+// blockJSONRPCResponse is a block as returned by getBlock
+type blockJSONRPCResponse struct {
+	BlockHeight       *uint64 `json:"blockHeight"`
+	BlockTime         *int64  `json:"blockTime"`
+	BlockHash         string  `json:"blockhash"`
+	PreviousBlockHash string  `json:"previousBlockhash"`
+	ParentSlot        uint64  `json:"parentSlot"`
+	Transactions      []struct {
+		Version     *TransactionVersion `json:"version"`
+		Transaction json.RawMessage     `json:"transaction"`
+		Meta        *TransactionMeta    `json:"meta"`
+	} `json:"transactions"`
+	Signatures []string `json:"signatures"`
+	Rewards    []Reward `json:"rewards"`
+}
+
+// toBlock converts the blockJSONRPCResponse to a Block
+func (r *blockJSONRPCResponse) toBlock() (*Block, error) {
+	block := &Block{
+		BlockHeight:       r.BlockHeight,
+		BlockTime:         r.BlockTime,
+		BlockHash:         r.BlockHash,
+		PreviousBlockHash: r.PreviousBlockHash,
+		ParentSlot:        r.ParentSlot,
+		Signatures:        r.Signatures,
+		Rewards:           r.Rewards,
+	}
+	if r.Transactions != nil {
+		block.Transactions = make([]BlockTransaction, 0, len(r.Transactions))
+	}
+	for i, transaction := range r.Transactions {
+		blockTransaction := BlockTransaction{
+			Version: LegacyTransactionVersion,
+			Meta:    transaction.Meta,
+		}
+		if transaction.Version != nil {
+			blockTransaction.Version = *transaction.Version
+		}
+		var err error
+		blockTransaction.Transaction, err = parseEncodedTransaction(transaction.Transaction)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing transaction %d: %w", i, err)
+		}
+		block.Transactions = append(block.Transactions, blockTransaction)
+	}
+	return block, nil
+}
@@ -0,0 +1,263 @@
+package borsh
+
+import (
+	"github.com/BRBussy/solgo"
+	"github.com/stretchr/testify/require"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+type testEnumKind uint8
+
+const (
+	testEnumUnitKind testEnumKind = iota
+	testEnumAmountKind
+	testEnumNameKind
+)
+
+type testEnum struct {
+	Kind   testEnumKind `borsh:"enum"`
+	Unit   struct{}
+	Amount uint64
+	Name   string
+}
+
+type testStruct struct {
+	Flag     bool
+	Small    int8
+	Count    uint16
+	Balance  int64
+	Name     string
+	Data     []byte
+	Values   []uint32
+	Seed     [4]byte
+	Owner    solana.PublicKey
+	Delegate *solana.PublicKey
+	Memo     *string
+	Ignored  string `borsh:"skip"`
+	private  uint64
+}
+
+func TestRoundTrip(t *testing.T) {
+	owner := solana.MustNewRandomKeypair().PublicKey
+	memo := "memo"
+
+	tests := []struct {
+		name  string
+		value interface{}
+		want  []byte
+	}{
+		{
+			name:  "bool",
+			value: true,
+			want:  []byte{1},
+		},
+		{
+			name:  "u16",
+			value: uint16(0x0102),
+			want:  []byte{0x02, 0x01},
+		},
+		{
+			name:  "negative i32",
+			value: int32(-2),
+			want:  []byte{0xfe, 0xff, 0xff, 0xff},
+		},
+		{
+			name:  "string",
+			value: "abc",
+			want:  []byte{3, 0, 0, 0, 'a', 'b', 'c'},
+		},
+		{
+			name:  "vector",
+			value: []uint16{1, 2},
+			want:  []byte{2, 0, 0, 0, 1, 0, 2, 0},
+		},
+		{
+			name:  "fixed array",
+			value: [3]uint8{1, 2, 3},
+			want:  []byte{1, 2, 3},
+		},
+		{
+			name:  "map ordered by key",
+			value: map[string]uint8{"b": 2, "a": 1},
+			want:  []byte{2, 0, 0, 0, 1, 0, 0, 0, 'a', 1, 1, 0, 0, 0, 'b', 2},
+		},
+		{
+			name:  "u128",
+			value: Uint128{Lo: 1, Hi: 2},
+			want:  []byte{1, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:  "enum unit variant",
+			value: testEnum{Kind: testEnumUnitKind},
+			want:  []byte{0},
+		},
+		{
+			name:  "enum variant with value",
+			value: testEnum{Kind: testEnumAmountKind, Amount: 5},
+			want:  []byte{1, 5, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:  "enum variant with string",
+			value: testEnum{Kind: testEnumNameKind, Name: "a"},
+			want:  []byte{2, 1, 0, 0, 0, 'a'},
+		},
+		{
+			name: "struct",
+			value: testStruct{
+				Flag:    true,
+				Small:   -1,
+				Count:   2,
+				Balance: -3,
+				Name:    "n",
+				Data:    []byte{9},
+				Values:  []uint32{7},
+				Seed:    [4]byte{1, 2, 3, 4},
+				Owner:   owner,
+				Memo:    &memo,
+			},
+			want: append(append(
+				[]byte{
+					1,
+					0xff,
+					2, 0,
+					0xfd, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+					1, 0, 0, 0, 'n',
+					1, 0, 0, 0, 9,
+					1, 0, 0, 0, 7, 0, 0, 0,
+					1, 2, 3, 4,
+				},
+				owner.PublicKey...),
+				0,
+				1, 4, 0, 0, 0, 'm', 'e', 'm', 'o',
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := Marshal(tt.value)
+			require.Nil(t, err)
+			require.Equal(t, tt.want, data)
+
+			decoded := reflect.New(reflect.TypeOf(tt.value))
+			require.Nil(t, Unmarshal(data, decoded.Interface()))
+			require.Equal(t, tt.value, decoded.Elem().Interface())
+		})
+	}
+}
+
+func TestNamedUint8Slice(t *testing.T) {
+	type namedUint8SliceStruct struct {
+		Kinds []testEnumKind
+		Bytes []uint8
+	}
+
+	value := namedUint8SliceStruct{
+		Kinds: []testEnumKind{testEnumAmountKind, testEnumUnitKind, testEnumNameKind},
+		Bytes: []uint8{1, 2},
+	}
+	data, err := Marshal(value)
+	require.Nil(t, err)
+	require.Equal(t, []byte{3, 0, 0, 0, 1, 0, 2, 2, 0, 0, 0, 1, 2}, data)
+
+	var decoded namedUint8SliceStruct
+	require.Nil(t, Unmarshal(data, &decoded))
+	require.Equal(t, value, decoded)
+}
+
+func TestBigIntFields(t *testing.T) {
+	type bigIntStruct struct {
+		Unsigned *big.Int `borsh:"u128"`
+		Signed   *big.Int `borsh:"i128"`
+	}
+
+	value := bigIntStruct{
+		Unsigned: new(big.Int).Lsh(big.NewInt(1), 100),
+		Signed:   big.NewInt(-1),
+	}
+	data, err := Marshal(value)
+	require.Nil(t, err)
+	require.Len(t, data, 32)
+	for _, b := range data[16:] {
+		require.Equal(t, uint8(0xff), b)
+	}
+
+	var decoded bigIntStruct
+	require.Nil(t, Unmarshal(data, &decoded))
+	require.Equal(t, 0, value.Unsigned.Cmp(decoded.Unsigned))
+	require.Equal(t, 0, value.Signed.Cmp(decoded.Signed))
+
+	_, err = Marshal(bigIntStruct{Unsigned: big.NewInt(-1), Signed: big.NewInt(0)})
+	require.ErrorIs(t, err, ErrInvalidValue)
+
+	// big.Int values must be tagged
+	type untaggedBigIntStruct struct {
+		Value *big.Int
+	}
+	_, err = Marshal(untaggedBigIntStruct{Value: big.NewInt(1)})
+	require.ErrorIs(t, err, ErrUnsupportedType)
+	require.ErrorIs(t, Unmarshal([]byte{1}, new(untaggedBigIntStruct)), ErrUnsupportedType)
+	_, err = Marshal(*big.NewInt(1))
+	require.ErrorIs(t, err, ErrUnsupportedType)
+	require.ErrorIs(t, Unmarshal([]byte{}, new(big.Int)), ErrUnsupportedType)
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		target  interface{}
+		wantErr error
+	}{
+		{
+			name:    "short integer",
+			data:    []byte{1, 2},
+			target:  new(uint32),
+			wantErr: ErrUnexpectedEndOfData,
+		},
+		{
+			name:    "string length exceeds data",
+			data:    []byte{5, 0, 0, 0, 'a'},
+			target:  new(string),
+			wantErr: ErrUnexpectedEndOfData,
+		},
+		{
+			name:    "invalid bool",
+			data:    []byte{2},
+			target:  new(bool),
+			wantErr: ErrInvalidData,
+		},
+		{
+			name:    "invalid option tag",
+			data:    []byte{2},
+			target:  new(*uint8),
+			wantErr: ErrInvalidData,
+		},
+		{
+			name:    "invalid enum variant",
+			data:    []byte{3},
+			target:  new(testEnum),
+			wantErr: ErrInvalidData,
+		},
+		{
+			name:    "non pointer target",
+			data:    []byte{1},
+			target:  uint8(0),
+			wantErr: ErrUnsupportedType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, Unmarshal(tt.data, tt.target), tt.wantErr)
+		})
+	}
+}
+
+func TestInvalidTag(t *testing.T) {
+	type invalidTagStruct struct {
+		Value uint64 `borsh:"u128"`
+	}
+	_, err := Marshal(invalidTagStruct{})
+	require.ErrorIs(t, err, ErrInvalidTag)
+}
@@ -0,0 +1,328 @@
+package borsh
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/BRBussy/solgo"
+	"math"
+	"math/big"
+	"reflect"
+)
+
+// Unmarshaler is implemented by types that can decode themselves with a Decoder
+type Unmarshaler interface {
+	UnmarshalBorsh(d *Decoder) error
+}
+
+// Unmarshal decodes the Borsh encoded data into the value pointed to by v.
+// Any data remaining after v has been decoded is ignored, as is commonly the case
+// for account data which may be allocated with more space than its state requires.
+func Unmarshal(data []byte, v interface{}) error {
+	return NewDecoder(data).Decode(v)
+}
+
+// UnmarshalAccountInfo decodes the data of the given solana.AccountInfo into the value
+// pointed to by v. The AccountInfo must be a solana.AccountInfoEncodedData, as is returned
+// by solana.Connection.GetAccountInfo for solana.Base64Encoding and solana.Base58Encoding.
+func UnmarshalAccountInfo(accountInfo solana.AccountInfo, v interface{}) error {
+	encodedAccountInfo, ok := accountInfo.(solana.AccountInfoEncodedData)
+	if !ok {
+		return fmt.Errorf("account info of type %T: %w", accountInfo, ErrUnsupportedType)
+	}
+	data, err := encodedAccountInfo.DecodeData()
+	if err != nil {
+		return fmt.Errorf("error decoding account data: %w", err)
+	}
+	return Unmarshal(data, v)
+}
+
+// Decoder reads Borsh encoded values from data
+type Decoder struct {
+	data []byte
+	pos  int
+}
+
+// NewDecoder returns a Decoder that reads from data
+func NewDecoder(data []byte) *Decoder {
+	return &Decoder{data: data}
+}
+
+// Decode decodes the next value in the data into the value pointed to by v
+func (d *Decoder) Decode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("decode target %T is not a non-nil pointer: %w", v, ErrUnsupportedType)
+	}
+	return d.decode(rv.Elem(), fieldOptions{})
+}
+
+// ReadBytes reads the next n bytes of data
+func (d *Decoder) ReadBytes(n int) ([]byte, error) {
+	if n < 0 || n > d.Remaining() {
+		return nil, fmt.Errorf("reading %d bytes with %d remaining: %w", n, d.Remaining(), ErrUnexpectedEndOfData)
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+// Remaining returns the number of bytes of data that have not yet been read
+func (d *Decoder) Remaining() int {
+	return len(d.data) - d.pos
+}
+
+var (
+	unmarshalerType = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+	uint8Type       = reflect.TypeOf(uint8(0))
+)
+
+func (d *Decoder) decode(rv reflect.Value, opts fieldOptions) error {
+	// check for custom and special cased types
+	if rv.CanAddr() && rv.Addr().Type().Implements(unmarshalerType) {
+		return rv.Addr().Interface().(Unmarshaler).UnmarshalBorsh(d)
+	}
+	switch rv.Type() {
+	case publicKeyType:
+		b, err := d.ReadBytes(32)
+		if err != nil {
+			return err
+		}
+		var publicKey [32]byte
+		copy(publicKey[:], b)
+		rv.Set(reflect.ValueOf(solana.NewPublicKeyFromBytes(publicKey)))
+		return nil
+
+	case bigIntPtrType:
+		if opts.u128 || opts.i128 {
+			value, err := d.readBigInt(opts.i128)
+			if err != nil {
+				return err
+			}
+			rv.Set(reflect.ValueOf(value))
+			return nil
+		}
+		return fmt.Errorf("%s without u128 or i128 tag: %w", rv.Type(), ErrUnsupportedType)
+
+	case bigIntType:
+		return fmt.Errorf("%s: %w", rv.Type(), ErrUnsupportedType)
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		b, err := d.ReadBytes(1)
+		if err != nil {
+			return err
+		}
+		switch b[0] {
+		case 0:
+			rv.SetBool(false)
+		case 1:
+			rv.SetBool(true)
+		default:
+			return fmt.Errorf("bool value %d: %w", b[0], ErrInvalidData)
+		}
+
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		b, err := d.ReadBytes(int(rv.Type().Size()))
+		if err != nil {
+			return err
+		}
+		rv.SetUint(readUint(b))
+
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		b, err := d.ReadBytes(int(rv.Type().Size()))
+		if err != nil {
+			return err
+		}
+		// sign extend from the size of the type
+		shift := 64 - 8*uint(len(b))
+		rv.SetInt(int64(readUint(b)<<shift) >> shift)
+
+	case reflect.Float32:
+		b, err := d.ReadBytes(4)
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(float64(math.Float32frombits(binary.LittleEndian.Uint32(b))))
+
+	case reflect.Float64:
+		b, err := d.ReadBytes(8)
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(b)))
+
+	case reflect.String:
+		length, err := d.readLength()
+		if err != nil {
+			return err
+		}
+		b, err := d.ReadBytes(length)
+		if err != nil {
+			return err
+		}
+		rv.SetString(string(b))
+
+	case reflect.Slice:
+		length, err := d.readLength()
+		if err != nil {
+			return err
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 && !reflect.PtrTo(rv.Type().Elem()).Implements(unmarshalerType) {
+			b, err := d.ReadBytes(length)
+			if err != nil {
+				return err
+			}
+			slice := reflect.MakeSlice(rv.Type(), length, length)
+			if rv.Type().Elem() == uint8Type {
+				reflect.Copy(slice, reflect.ValueOf(b))
+			} else {
+				// elements of a named uint8 type cannot be copied from []byte
+				for i := range b {
+					slice.Index(i).SetUint(uint64(b[i]))
+				}
+			}
+			rv.Set(slice)
+			return nil
+		}
+		if length > d.Remaining() && rv.Type().Elem().Size() > 0 {
+			// every element consumes at least 1 byte
+			return fmt.Errorf("slice of %d elements with %d bytes remaining: %w", length, d.Remaining(), ErrUnexpectedEndOfData)
+		}
+		slice := reflect.MakeSlice(rv.Type(), length, length)
+		for i := 0; i < length; i++ {
+			if err := d.decode(slice.Index(i), fieldOptions{}); err != nil {
+				return err
+			}
+		}
+		rv.Set(slice)
+
+	case reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if err := d.decode(rv.Index(i), fieldOptions{}); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Map:
+		return d.decodeMap(rv)
+
+	case reflect.Ptr:
+		b, err := d.ReadBytes(1)
+		if err != nil {
+			return err
+		}
+		switch b[0] {
+		case 0:
+			rv.Set(reflect.Zero(rv.Type()))
+		case 1:
+			value := reflect.New(rv.Type().Elem())
+			if err := d.decode(value.Elem(), fieldOptions{}); err != nil {
+				return err
+			}
+			rv.Set(value)
+		default:
+			return fmt.Errorf("option tag %d: %w", b[0], ErrInvalidData)
+		}
+
+	case reflect.Struct:
+		return d.decodeStruct(rv)
+
+	default:
+		return fmt.Errorf("%s: %w", rv.Type(), ErrUnsupportedType)
+	}
+
+	return nil
+}
+
+func (d *Decoder) decodeStruct(rv reflect.Value) error {
+	fields, err := structFields(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	// decode enum into the selected variant
+	if len(fields) > 0 && fields[0].opts.enum {
+		b, err := d.ReadBytes(1)
+		if err != nil {
+			return err
+		}
+		variant := int(b[0])
+		if variant >= len(fields)-1 {
+			return fmt.Errorf("%s variant %d of %d: %w", rv.Type(), variant, len(fields)-1, ErrInvalidData)
+		}
+		if _, err := enumVariant(rv.Field(fields[0].index)); err != nil {
+			return err
+		}
+		setEnumVariant(rv.Field(fields[0].index), variant)
+		return d.decode(rv.Field(fields[variant+1].index), fields[variant+1].opts)
+	}
+
+	for _, field := range fields {
+		if err := d.decode(rv.Field(field.index), field.opts); err != nil {
+			return fmt.Errorf("error decoding %s.%s: %w", rv.Type(), rv.Type().Field(field.index).Name, err)
+		}
+	}
+	return nil
+}
+
+func (d *Decoder) decodeMap(rv reflect.Value) error {
+	length, err := d.readLength()
+	if err != nil {
+		return err
+	}
+	if length > d.Remaining() {
+		return fmt.Errorf("map of %d entries with %d bytes remaining: %w", length, d.Remaining(), ErrUnexpectedEndOfData)
+	}
+
+	m := reflect.MakeMapWithSize(rv.Type(), length)
+	for i := 0; i < length; i++ {
+		key := reflect.New(rv.Type().Key()).Elem()
+		if err := d.decode(key, fieldOptions{}); err != nil {
+			return err
+		}
+		value := reflect.New(rv.Type().Elem()).Elem()
+		if err := d.decode(value, fieldOptions{}); err != nil {
+			return err
+		}
+		m.SetMapIndex(key, value)
+	}
+	rv.Set(m)
+	return nil
+}
+
+func (d *Decoder) readBigInt(signed bool) (*big.Int, error) {
+	b, err := d.ReadBytes(16)
+	if err != nil {
+		return nil, err
+	}
+
+	// big.Int bytes are big endian
+	bigEndian := make([]byte, 16)
+	for i := range b {
+		bigEndian[15-i] = b[i]
+	}
+	value := new(big.Int).SetBytes(bigEndian)
+	if signed && b[15]&0x80 != 0 {
+		// two's complement
+		value.Sub(value, twoToThe128)
+	}
+	return value, nil
+}
+
+func (d *Decoder) readLength() (int, error) {
+	b, err := d.ReadBytes(4)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.LittleEndian.Uint32(b)), nil
+}
+
+// readUint reads a little endian unsigned integer of up to 8 bytes
+func readUint(b []byte) uint64 {
+	var v uint64
+	for i := len(b) - 1; i >= 0; i-- {
+		v = v<<8 | uint64(b[i])
+	}
+	return v
+}
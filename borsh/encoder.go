@@ -0,0 +1,288 @@
+// Package borsh implements reflection based encoding and decoding of Go values in the
+// Borsh binary serialization format used by Solana programs for instruction data and
+// account state.
+// See the format specification here: https://borsh.io
+//
+// Go types are mapped to Borsh types as follows:
+//   - bool, uint8-64, int8-64, float32 and float64 are encoded little endian
+//   - Uint128 and Int128 are encoded as u128 and i128, as is a *big.Int field tagged `borsh:"u128"` or `borsh:"i128"`
+//   - big.Int values are not supported without one of these tags
+//   - string and []byte are encoded as a u32 length followed by the bytes
+//   - slices are encoded as a u32 length followed by each element
+//   - arrays are encoded as each element with no length prefix
+//   - maps are encoded as a u32 length followed by each key and value, ordered by key
+//   - pointers are encoded as an Option, with a nil pointer encoded as None
+//   - structs are encoded as each exported field in declaration order
+//   - solana.PublicKey is encoded as its 32 bytes
+//
+// Struct fields tagged `borsh:"skip"` are not encoded. A struct with a first field tagged
+// `borsh:"enum"` is encoded as an enum with fields: the enum field is an integer holding the
+// variant index and each following field is a variant in order. Only the variant selected
+// by the index is encoded. Simple enums without fields are encoded as a uint8 type.
+//
+// Types implementing Marshaler and Unmarshaler may encode and decode themselves.
+package borsh
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/BRBussy/solgo"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+)
+
+// Marshaler is implemented by types that can encode themselves with an Encoder
+type Marshaler interface {
+	MarshalBorsh(e *Encoder) error
+}
+
+// Marshal returns the Borsh encoding of v
+func Marshal(v interface{}) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := NewEncoder(buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Encoder writes Borsh encoded values to a buffer
+type Encoder struct {
+	buf *bytes.Buffer
+}
+
+// NewEncoder returns an Encoder that writes to buf
+func NewEncoder(buf *bytes.Buffer) *Encoder {
+	return &Encoder{buf: buf}
+}
+
+// Encode writes the Borsh encoding of v
+func (e *Encoder) Encode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		// the top level value may be given by reference
+		if rv.IsNil() {
+			return fmt.Errorf("nil pointer: %w", ErrUnsupportedType)
+		}
+		rv = rv.Elem()
+	}
+	return e.encode(rv, fieldOptions{})
+}
+
+// WriteBytes writes the given bytes as is, without a length prefix
+func (e *Encoder) WriteBytes(b []byte) {
+	e.buf.Write(b)
+}
+
+var (
+	marshalerType = reflect.TypeOf((*Marshaler)(nil)).Elem()
+	publicKeyType = reflect.TypeOf(solana.PublicKey{})
+	bigIntPtrType = reflect.TypeOf((*big.Int)(nil))
+	bigIntType    = reflect.TypeOf(big.Int{})
+	twoToThe128   = new(big.Int).Lsh(big.NewInt(1), 128)
+	maxInt128     = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 127), big.NewInt(1))
+	minInt128     = new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 127))
+)
+
+func (e *Encoder) encode(rv reflect.Value, opts fieldOptions) error {
+	// check for custom and special cased types
+	if rv.Type().Implements(marshalerType) {
+		return rv.Interface().(Marshaler).MarshalBorsh(e)
+	}
+	if rv.CanAddr() && rv.Addr().Type().Implements(marshalerType) {
+		return rv.Addr().Interface().(Marshaler).MarshalBorsh(e)
+	}
+	switch rv.Type() {
+	case publicKeyType:
+		publicKey := rv.Interface().(solana.PublicKey)
+		if len(publicKey.PublicKey) != 32 {
+			return fmt.Errorf("public key of %d bytes: %w", len(publicKey.PublicKey), ErrInvalidValue)
+		}
+		e.buf.Write(publicKey.PublicKey)
+		return nil
+
+	case bigIntPtrType:
+		if opts.u128 || opts.i128 {
+			return e.encodeBigInt(rv.Interface().(*big.Int), opts.i128)
+		}
+		// big.Int has no exported fields and would otherwise be silently encoded as an empty struct
+		return fmt.Errorf("%s without u128 or i128 tag: %w", rv.Type(), ErrUnsupportedType)
+
+	case bigIntType:
+		return fmt.Errorf("%s: %w", rv.Type(), ErrUnsupportedType)
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		if rv.Bool() {
+			e.buf.WriteByte(1)
+		} else {
+			e.buf.WriteByte(0)
+		}
+
+	case reflect.Uint8:
+		e.buf.WriteByte(uint8(rv.Uint()))
+	case reflect.Uint16:
+		e.writeFixed(uint16(rv.Uint()))
+	case reflect.Uint32:
+		e.writeFixed(uint32(rv.Uint()))
+	case reflect.Uint64:
+		e.writeFixed(rv.Uint())
+	case reflect.Int8:
+		e.writeFixed(int8(rv.Int()))
+	case reflect.Int16:
+		e.writeFixed(int16(rv.Int()))
+	case reflect.Int32:
+		e.writeFixed(int32(rv.Int()))
+	case reflect.Int64:
+		e.writeFixed(rv.Int())
+	case reflect.Float32:
+		e.writeFixed(float32(rv.Float()))
+	case reflect.Float64:
+		e.writeFixed(rv.Float())
+
+	case reflect.String:
+		if err := e.writeLength(rv.Len()); err != nil {
+			return err
+		}
+		e.buf.WriteString(rv.String())
+
+	case reflect.Slice:
+		if err := e.writeLength(rv.Len()); err != nil {
+			return err
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 && !rv.Type().Elem().Implements(marshalerType) {
+			e.buf.Write(rv.Bytes())
+			return nil
+		}
+		for i := 0; i < rv.Len(); i++ {
+			if err := e.encode(rv.Index(i), fieldOptions{}); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if err := e.encode(rv.Index(i), fieldOptions{}); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Map:
+		return e.encodeMap(rv)
+
+	case reflect.Ptr:
+		if rv.IsNil() {
+			e.buf.WriteByte(0)
+			return nil
+		}
+		e.buf.WriteByte(1)
+		return e.encode(rv.Elem(), fieldOptions{})
+
+	case reflect.Struct:
+		return e.encodeStruct(rv)
+
+	default:
+		return fmt.Errorf("%s: %w", rv.Type(), ErrUnsupportedType)
+	}
+
+	return nil
+}
+
+func (e *Encoder) encodeStruct(rv reflect.Value) error {
+	fields, err := structFields(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	// encode enum with only the selected variant
+	if len(fields) > 0 && fields[0].opts.enum {
+		variant, err := enumVariant(rv.Field(fields[0].index))
+		if err != nil {
+			return err
+		}
+		if variant >= len(fields)-1 {
+			return fmt.Errorf("%s variant %d of %d: %w", rv.Type(), variant, len(fields)-1, ErrInvalidValue)
+		}
+		e.buf.WriteByte(uint8(variant))
+		return e.encode(rv.Field(fields[variant+1].index), fields[variant+1].opts)
+	}
+
+	for _, field := range fields {
+		if err := e.encode(rv.Field(field.index), field.opts); err != nil {
+			return fmt.Errorf("error encoding %s.%s: %w", rv.Type(), rv.Type().Field(field.index).Name, err)
+		}
+	}
+	return nil
+}
+
+func (e *Encoder) encodeMap(rv reflect.Value) error {
+	if err := e.writeLength(rv.Len()); err != nil {
+		return err
+	}
+
+	// entries are encoded ordered by key
+	keys := rv.MapKeys()
+	var less func(i, j int) bool
+	switch rv.Type().Key().Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		less = func(i, j int) bool { return keys[i].Uint() < keys[j].Uint() }
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		less = func(i, j int) bool { return keys[i].Int() < keys[j].Int() }
+	case reflect.String:
+		less = func(i, j int) bool { return keys[i].String() < keys[j].String() }
+	default:
+		return fmt.Errorf("map key %s: %w", rv.Type().Key(), ErrUnsupportedType)
+	}
+	sort.Slice(keys, less)
+
+	for _, key := range keys {
+		if err := e.encode(key, fieldOptions{}); err != nil {
+			return err
+		}
+		if err := e.encode(rv.MapIndex(key), fieldOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Encoder) encodeBigInt(value *big.Int, signed bool) error {
+	if value == nil {
+		return fmt.Errorf("nil big.Int: %w", ErrInvalidValue)
+	}
+	v := new(big.Int).Set(value)
+	if signed {
+		if v.Cmp(maxInt128) > 0 || v.Cmp(minInt128) < 0 {
+			return fmt.Errorf("%s overflows i128: %w", value, ErrInvalidValue)
+		}
+		if v.Sign() < 0 {
+			// two's complement
+			v.Add(v, twoToThe128)
+		}
+	} else if v.Sign() < 0 || v.BitLen() > 128 {
+		return fmt.Errorf("%s overflows u128: %w", value, ErrInvalidValue)
+	}
+
+	// big.Int bytes are big endian
+	b := v.FillBytes(make([]byte, 16))
+	for i := 15; i >= 0; i-- {
+		e.buf.WriteByte(b[i])
+	}
+	return nil
+}
+
+func (e *Encoder) writeFixed(v interface{}) {
+	// writing fixed size values to a bytes.Buffer does not fail
+	_ = binary.Write(e.buf, binary.LittleEndian, v)
+}
+
+func (e *Encoder) writeLength(length int) error {
+	if uint64(length) > math.MaxUint32 {
+		return fmt.Errorf("length %d overflows u32: %w", length, ErrInvalidValue)
+	}
+	e.writeFixed(uint32(length))
+	return nil
+}
@@ -0,0 +1,11 @@
+package borsh
+
+import "errors"
+
+var (
+	ErrUnsupportedType     = errors.New("unsupported type")
+	ErrInvalidValue        = errors.New("invalid value")
+	ErrInvalidData         = errors.New("invalid data")
+	ErrUnexpectedEndOfData = errors.New("unexpected end of data")
+	ErrInvalidTag          = errors.New("invalid borsh tag")
+)
@@ -0,0 +1,98 @@
+package borsh
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// fieldOptions are the options set on a struct field with a borsh tag
+type fieldOptions struct {
+	enum bool
+	u128 bool
+	i128 bool
+}
+
+// field is an encoded field of a struct
+type field struct {
+	index int
+	opts  fieldOptions
+}
+
+// structFieldsCache caches the encoded fields of each struct type
+var structFieldsCache sync.Map
+
+// structFields returns the encoded fields of the given struct type in declaration order
+func structFields(t reflect.Type) ([]field, error) {
+	if cached, found := structFieldsCache.Load(t); found {
+		return cached.([]field), nil
+	}
+
+	fields := make([]field, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		if structField.PkgPath != "" {
+			// unexported
+			continue
+		}
+
+		var opts fieldOptions
+		skip := false
+		if tag, found := structField.Tag.Lookup("borsh"); found {
+			for _, option := range strings.Split(tag, ",") {
+				switch strings.TrimSpace(option) {
+				case "skip":
+					skip = true
+				case "enum":
+					if len(fields) != 0 {
+						return nil, fmt.Errorf("%s.%s: enum must be the first field: %w", t, structField.Name, ErrInvalidTag)
+					}
+					opts.enum = true
+				case "u128":
+					opts.u128 = true
+				case "i128":
+					opts.i128 = true
+				case "":
+				default:
+					return nil, fmt.Errorf("%s.%s: '%s': %w", t, structField.Name, option, ErrInvalidTag)
+				}
+			}
+		}
+		if skip {
+			continue
+		}
+		if (opts.u128 || opts.i128) && structField.Type != bigIntPtrType {
+			return nil, fmt.Errorf("%s.%s: u128 and i128 only apply to *big.Int: %w", t, structField.Name, ErrInvalidTag)
+		}
+		fields = append(fields, field{index: i, opts: opts})
+	}
+
+	structFieldsCache.Store(t, fields)
+	return fields, nil
+}
+
+// enumVariant returns the variant index held in the given enum field
+func enumVariant(rv reflect.Value) (int, error) {
+	switch rv.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
+		return int(rv.Uint()), nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
+		if rv.Int() < 0 {
+			return 0, fmt.Errorf("negative enum variant %d: %w", rv.Int(), ErrInvalidValue)
+		}
+		return int(rv.Int()), nil
+	default:
+		return 0, fmt.Errorf("enum field of type %s: %w", rv.Type(), ErrUnsupportedType)
+	}
+}
+
+// setEnumVariant sets the given variant index on the given enum field
+func setEnumVariant(rv reflect.Value, variant int) {
+	switch rv.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
+		rv.SetUint(uint64(variant))
+	default:
+		rv.SetInt(int64(variant))
+	}
+}
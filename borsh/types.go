@@ -0,0 +1,64 @@
+package borsh
+
+import (
+	"math/big"
+)
+
+// Uint128 is an unsigned 128 bit integer encoded as a Borsh u128
+type Uint128 struct {
+	Lo uint64
+	Hi uint64
+}
+
+// NewUint128FromBigInt returns the Uint128 with the given value.
+// The value is truncated to its lowest 128 bits.
+func NewUint128FromBigInt(value *big.Int) Uint128 {
+	v := new(big.Int).Mod(value, twoToThe128)
+	lo := new(big.Int).And(v, new(big.Int).SetUint64(^uint64(0)))
+	return Uint128{
+		Lo: lo.Uint64(),
+		Hi: new(big.Int).Rsh(v, 64).Uint64(),
+	}
+}
+
+// BigInt returns the value of the Uint128 as a big.Int
+func (u Uint128) BigInt() *big.Int {
+	v := new(big.Int).SetUint64(u.Hi)
+	v.Lsh(v, 64)
+	return v.Or(v, new(big.Int).SetUint64(u.Lo))
+}
+
+// String returns the base 10 value of the Uint128
+func (u Uint128) String() string {
+	return u.BigInt().String()
+}
+
+// Int128 is a signed 128 bit integer encoded as a Borsh i128
+type Int128 struct {
+	Lo uint64
+	Hi int64
+}
+
+// NewInt128FromBigInt returns the Int128 with the given value.
+// The value is truncated to its lowest 128 bits in two's complement.
+func NewInt128FromBigInt(value *big.Int) Int128 {
+	u := NewUint128FromBigInt(value)
+	return Int128{
+		Lo: u.Lo,
+		Hi: int64(u.Hi),
+	}
+}
+
+// BigInt returns the value of the Int128 as a big.Int
+func (i Int128) BigInt() *big.Int {
+	v := Uint128{Lo: i.Lo, Hi: uint64(i.Hi)}.BigInt()
+	if i.Hi < 0 {
+		v.Sub(v, twoToThe128)
+	}
+	return v
+}
+
+// String returns the base 10 value of the Int128
+func (i Int128) String() string {
+	return i.BigInt().String()
+}
@@ -0,0 +1,69 @@
+package bpfLoaderUpgradeableProgram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/BRBussy/solgo"
+)
+
+type CloseParams struct {
+	// AccountPubkey is the buffer, ProgramData or uninitialized account to close
+	// Req: [writer]
+	AccountPubkey solana.PublicKey
+
+	// RecipientPubkey is the account to which the lamports of the closed account are transferred
+	// Req: [writer]
+	RecipientPubkey solana.PublicKey
+
+	// AuthorityPubkey is the authority of the account.
+	// Not required when closing an uninitialized account.
+	// Req: [signer]
+	AuthorityPubkey *solana.PublicKey
+
+	// ProgramPubkey is the program account associated with the account
+	// being closed. Only required when closing a ProgramData account.
+	// Req: [writer]
+	ProgramPubkey *solana.PublicKey
+}
+
+// Close creates a Solana upgradeable BPF loader program Instruction to close
+// a buffer, ProgramData or uninitialized account and reclaim its lamports.
+func Close(params CloseParams) ([]solana.Instruction, error) {
+	// encode instruction data
+	buf := new(bytes.Buffer)
+	if err := binary.Write(
+		buf,
+		binary.LittleEndian,
+		CloseInstruction,
+	); err != nil {
+		return nil, fmt.Errorf("error encoding close data: %w", err)
+	}
+
+	// prepare accounts
+	accountMetas := []solana.InstructionAccountMeta{
+		{PubKey: params.AccountPubkey, IsSigner: false, IsWritable: true},
+		{PubKey: params.RecipientPubkey, IsSigner: false, IsWritable: true},
+	}
+	if params.AuthorityPubkey != nil {
+		accountMetas = append(
+			accountMetas,
+			solana.InstructionAccountMeta{PubKey: *params.AuthorityPubkey, IsSigner: true, IsWritable: false},
+		)
+	}
+	if params.ProgramPubkey != nil {
+		accountMetas = append(
+			accountMetas,
+			solana.InstructionAccountMeta{PubKey: *params.ProgramPubkey, IsSigner: false, IsWritable: true},
+		)
+	}
+
+	// construct and return instruction
+	return []solana.Instruction{
+		{
+			InstructionAccountMeta: accountMetas,
+			ProgramIDPubKey:        ID,
+			Data:                   buf.Bytes(),
+		},
+	}, nil
+}
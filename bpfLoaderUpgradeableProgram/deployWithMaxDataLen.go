@@ -0,0 +1,81 @@
+package bpfLoaderUpgradeableProgram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/BRBussy/solgo"
+	"github.com/BRBussy/solgo/systemProgram"
+	"github.com/BRBussy/solgo/sysvar"
+)
+
+type DeployWithMaxDataLenParams struct {
+	// PayerPubkey is the account that will pay for the ProgramData account
+	// Req: [writer, signer]
+	PayerPubkey solana.PublicKey
+
+	// ProgramPubkey is the program account to deploy to.
+	// It must already have been created with ProgramSize space and be owned by the loader.
+	// Req: [writer]
+	ProgramPubkey solana.PublicKey
+
+	// BufferPubkey is the buffer account holding the program data.
+	// Its lamports are transferred to the ProgramData account and it is closed.
+	// Req: [writer]
+	BufferPubkey solana.PublicKey
+
+	// AuthorityPubkey is the authority of the buffer, which
+	// becomes the upgrade authority of the program.
+	// Req: [signer]
+	AuthorityPubkey solana.PublicKey
+
+	// MaxDataLen is the maximum length of program data that the
+	// ProgramData account can hold for future upgrades.
+	MaxDataLen uint64
+}
+
+type deployWithMaxDataLenInstructionData struct {
+	Instruction Instruction
+	MaxDataLen  uint64
+}
+
+// DeployWithMaxDataLen creates a Solana upgradeable BPF loader program Instruction to
+// deploy a program from a buffer account.
+func DeployWithMaxDataLen(params DeployWithMaxDataLenParams) ([]solana.Instruction, error) {
+	// derive program data address
+	programDataPubkey, err := ProgramDataAddress(params.ProgramPubkey)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving program data address: %w", err)
+	}
+
+	// encode instruction data
+	buf := new(bytes.Buffer)
+	if err := binary.Write(
+		buf,
+		binary.LittleEndian,
+		deployWithMaxDataLenInstructionData{
+			Instruction: DeployWithMaxDataLenInstruction,
+			MaxDataLen:  params.MaxDataLen,
+		},
+	); err != nil {
+		return nil, fmt.Errorf("error encoding deploy with max data len data: %w", err)
+	}
+
+	// construct and return instruction
+	return []solana.Instruction{
+		{
+			InstructionAccountMeta: []solana.InstructionAccountMeta{
+				{PubKey: params.PayerPubkey, IsSigner: true, IsWritable: true},
+				{PubKey: programDataPubkey, IsSigner: false, IsWritable: true},
+				{PubKey: params.ProgramPubkey, IsSigner: false, IsWritable: true},
+				{PubKey: params.BufferPubkey, IsSigner: false, IsWritable: true},
+				{PubKey: sysvar.RentID, IsSigner: false, IsWritable: false},
+				{PubKey: sysvar.ClockID, IsSigner: false, IsWritable: false},
+				{PubKey: systemProgram.ID, IsSigner: false, IsWritable: false},
+				{PubKey: params.AuthorityPubkey, IsSigner: true, IsWritable: false},
+			},
+			ProgramIDPubKey: ID,
+			Data:            buf.Bytes(),
+		},
+	}, nil
+}
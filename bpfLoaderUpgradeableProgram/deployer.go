@@ -0,0 +1,534 @@
+package bpfLoaderUpgradeableProgram
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/BRBussy/solgo"
+	"github.com/BRBussy/solgo/systemProgram"
+	"time"
+)
+
+// Deployer deploys and upgrades programs using the upgradeable BPF loader.
+//
+// Program data is written to a buffer account over many transactions. Before
+// writing, the Deployer reads the current content of the buffer and only writes
+// the chunks that differ from the program data. This allows an interrupted
+// deployment to be resumed by calling Deploy or UpgradeProgram again with the same
+// buffer, and is also used to rewrite any chunks whose transactions did not land.
+type Deployer struct {
+	connection solana.Connection
+	config     *deployerConfig
+}
+
+// deployerConfig is the configuration for a Deployer
+type deployerConfig struct {
+	chunkSize       uint32
+	maxWritePasses  int
+	pollInterval    time.Duration
+	commitmentLevel solana.CommitmentLevel
+}
+
+// DeployerOption makes a change to the deployerConfig
+type DeployerOption interface {
+	apply(*deployerConfig)
+}
+
+type deployerOptionFunc func(*deployerConfig)
+
+func (fn deployerOptionFunc) apply(cfg *deployerConfig) {
+	fn(cfg)
+}
+
+// WithChunkSize sets the number of program data bytes written per transaction
+func WithChunkSize(c uint32) DeployerOption {
+	return deployerOptionFunc(func(config *deployerConfig) {
+		config.chunkSize = c
+	})
+}
+
+// WithMaxWritePasses sets the maximum number of times that the Deployer
+// will read the buffer and write any chunks that are not yet as expected
+// before giving up with ErrBufferWriteIncomplete.
+func WithMaxWritePasses(m int) DeployerOption {
+	return deployerOptionFunc(func(config *deployerConfig) {
+		config.maxWritePasses = m
+	})
+}
+
+// WithPollInterval sets how long the Deployer waits after sending
+// transactions for them to land before reading the buffer again.
+func WithPollInterval(p time.Duration) DeployerOption {
+	return deployerOptionFunc(func(config *deployerConfig) {
+		config.pollInterval = p
+	})
+}
+
+// WithDeployerCommitmentLevel sets the CommitmentLevel used when
+// reading the buffer and fetching recent block hashes.
+func WithDeployerCommitmentLevel(c solana.CommitmentLevel) DeployerOption {
+	return deployerOptionFunc(func(config *deployerConfig) {
+		config.commitmentLevel = c
+	})
+}
+
+// NewDeployer returns a new and configured Deployer.
+//
+// The default returned Deployer is configured with:
+//   - chunkSize: DefaultWriteChunkSize
+//   - maxWritePasses: 5
+//   - pollInterval: 2 seconds
+//   - commitmentLevel: solana.ConfirmedCommitmentLevel
+//
+// The passed opts are used to override these default values and configure the
+// returned Deployer as desired.
+func NewDeployer(connection solana.Connection, opts ...DeployerOption) *Deployer {
+	// prepare default configuration
+	config := &deployerConfig{
+		chunkSize:       DefaultWriteChunkSize,
+		maxWritePasses:  5,
+		pollInterval:    2 * time.Second,
+		commitmentLevel: solana.ConfirmedCommitmentLevel,
+	}
+
+	// apply any provided options
+	for _, opt := range opts {
+		opt.apply(config)
+	}
+
+	return &Deployer{
+		connection: connection,
+		config:     config,
+	}
+}
+
+type WriteBufferParams struct {
+	// Payer pays the transaction fees and the rent of the buffer account
+	Payer solana.KeyPair
+
+	// Buffer is the buffer account to write to.
+	// It is created if it does not yet exist.
+	Buffer solana.KeyPair
+
+	// Authority is the authority of the buffer.
+	// Default value if not specified is Payer.
+	Authority solana.KeyPair
+
+	// ProgramData is the program ELF to write to the buffer
+	ProgramData []byte
+}
+
+// WriteBuffer creates the buffer account if it does not exist and writes the
+// ProgramData to it, skipping any chunks that have already been written.
+func (d *Deployer) WriteBuffer(ctx context.Context, params WriteBufferParams) error {
+	if d.config.chunkSize == 0 {
+		return ErrInvalidChunkSize
+	}
+	if len(params.Authority.PublicKey.PublicKey) == 0 {
+		params.Authority = params.Payer
+	}
+
+	for pass := 0; pass < d.config.maxWritePasses; pass++ {
+		if pass > 0 {
+			// wait for transactions sent in the previous pass to land
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(d.config.pollInterval):
+			}
+		}
+
+		// get the current state of the buffer
+		bufferState, err := d.getState(ctx, params.Buffer.PublicKey)
+		if err != nil {
+			return fmt.Errorf("error getting buffer state: %w", err)
+		}
+
+		// create buffer if it does not exist
+		if bufferState == nil {
+			if err := d.createBuffer(ctx, params); err != nil {
+				return fmt.Errorf("error creating buffer: %w", err)
+			}
+			continue
+		}
+
+		// confirm that buffer is as expected
+		if bufferState.Type != BufferStateType {
+			return fmt.Errorf("buffer account state type %d: %w", bufferState.Type, ErrUnexpectedState)
+		}
+		if bufferState.AuthorityAddress == nil ||
+			bufferState.AuthorityAddress.ToBase58() != params.Authority.PublicKey.ToBase58() {
+			return fmt.Errorf("buffer authority not %s: %w", params.Authority.PublicKey.ToBase58(), ErrUnexpectedAuthority)
+		}
+		if len(bufferState.Data) != len(params.ProgramData) {
+			return fmt.Errorf(
+				"buffer holds %d bytes, program data is %d bytes: %w",
+				len(bufferState.Data), len(params.ProgramData), ErrBufferSizeMismatch,
+			)
+		}
+
+		// write chunks that are not yet as expected, sharing a block hash across the pass
+		blockHash := new(recentBlockHash)
+		noChunksWritten := 0
+		for start := 0; start < len(params.ProgramData); start += int(d.config.chunkSize) {
+			end := start + int(d.config.chunkSize)
+			if end > len(params.ProgramData) {
+				end = len(params.ProgramData)
+			}
+			if bytes.Equal(bufferState.Data[start:end], params.ProgramData[start:end]) {
+				continue
+			}
+
+			writeInstructions, err := Write(WriteParams{
+				BufferPubkey:    params.Buffer.PublicKey,
+				AuthorityPubkey: params.Authority.PublicKey,
+				Offset:          uint32(start),
+				Bytes:           params.ProgramData[start:end],
+				ChunkSize:       d.config.chunkSize,
+			})
+			if err != nil {
+				return fmt.Errorf("error creating write instructions: %w", err)
+			}
+			if _, err := d.sendTransaction(
+				ctx,
+				blockHash,
+				params.Payer,
+				writeInstructions,
+				params.Payer.PrivateKey,
+				params.Authority.PrivateKey,
+			); err != nil {
+				return fmt.Errorf("error sending write transaction at offset %d: %w", start, err)
+			}
+			noChunksWritten++
+		}
+		if noChunksWritten == 0 {
+			return nil
+		}
+	}
+
+	return ErrBufferWriteIncomplete
+}
+
+type DeployProgramParams struct {
+	// Payer pays the transaction fees and the rent of the new accounts
+	Payer solana.KeyPair
+
+	// Program is the program account to be created and deployed to
+	Program solana.KeyPair
+
+	// Buffer is the buffer account to write the ProgramData to before deployment.
+	// It is created if it does not yet exist, and closed by the deployment.
+	Buffer solana.KeyPair
+
+	// Authority is the authority of the buffer, which becomes the upgrade
+	// authority of the program.
+	// Default value if not specified is Payer.
+	Authority solana.KeyPair
+
+	// ProgramData is the program ELF to deploy
+	ProgramData []byte
+
+	// MaxDataLen is the maximum size that the program may grow to in future upgrades.
+	// Default value if not specified is twice the length of ProgramData.
+	MaxDataLen uint64
+}
+
+type DeployProgramResponse struct {
+	// TransactionID identifies the deployment transaction
+	TransactionID string
+}
+
+// DeployProgram writes the ProgramData to the buffer and then
+// deploys it to the program account.
+func (d *Deployer) DeployProgram(ctx context.Context, params DeployProgramParams) (*DeployProgramResponse, error) {
+	if len(params.Authority.PublicKey.PublicKey) == 0 {
+		params.Authority = params.Payer
+	}
+	if params.MaxDataLen == 0 {
+		params.MaxDataLen = uint64(2 * len(params.ProgramData))
+	}
+
+	// write program data to buffer
+	if err := d.WriteBuffer(
+		ctx,
+		WriteBufferParams{
+			Payer:       params.Payer,
+			Buffer:      params.Buffer,
+			Authority:   params.Authority,
+			ProgramData: params.ProgramData,
+		},
+	); err != nil {
+		return nil, fmt.Errorf("error writing buffer: %w", err)
+	}
+
+	// get balance required to create program account
+	getMinimumBalanceResponse, err := d.connection.GetMinimumBalanceForRentExemption(
+		ctx,
+		solana.GetMinimumBalanceForRentExemptionRequest{
+			DataLength:      ProgramSize,
+			CommitmentLevel: d.config.commitmentLevel,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minimum balance for program account: %w", err)
+	}
+
+	// create program account and deploy
+	instructions, err := systemProgram.CreateAccount(systemProgram.CreateAccountParams{
+		FromPubkey:       params.Payer.PublicKey,
+		NewAccountPubkey: params.Program.PublicKey,
+		Lamports:         getMinimumBalanceResponse.Lamports,
+		Space:            ProgramSize,
+		ProgramID:        ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating create program account instruction: %w", err)
+	}
+	deployInstructions, err := DeployWithMaxDataLen(DeployWithMaxDataLenParams{
+		PayerPubkey:     params.Payer.PublicKey,
+		ProgramPubkey:   params.Program.PublicKey,
+		BufferPubkey:    params.Buffer.PublicKey,
+		AuthorityPubkey: params.Authority.PublicKey,
+		MaxDataLen:      params.MaxDataLen,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating deploy instruction: %w", err)
+	}
+	transactionID, err := d.sendTransaction(
+		ctx,
+		new(recentBlockHash),
+		params.Payer,
+		append(instructions, deployInstructions...),
+		params.Payer.PrivateKey,
+		params.Program.PrivateKey,
+		params.Authority.PrivateKey,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error sending deploy transaction: %w", err)
+	}
+
+	return &DeployProgramResponse{
+		TransactionID: transactionID,
+	}, nil
+}
+
+type UpgradeProgramParams struct {
+	// Payer pays the transaction fees and the rent of the buffer account.
+	// The rent of the buffer account is returned to Payer on upgrade.
+	Payer solana.KeyPair
+
+	// ProgramPubkey is the program to upgrade
+	ProgramPubkey solana.PublicKey
+
+	// Buffer is the buffer account to write the ProgramData to before upgrading.
+	// It is created if it does not yet exist, and closed by the upgrade.
+	Buffer solana.KeyPair
+
+	// Authority is the upgrade authority of the program.
+	// Default value if not specified is Payer.
+	Authority solana.KeyPair
+
+	// ProgramData is the new program ELF
+	ProgramData []byte
+}
+
+type UpgradeProgramResponse struct {
+	// TransactionID identifies the upgrade transaction
+	TransactionID string
+}
+
+// UpgradeProgram writes the ProgramData to the buffer and then upgrades the program with it.
+func (d *Deployer) UpgradeProgram(ctx context.Context, params UpgradeProgramParams) (*UpgradeProgramResponse, error) {
+	if len(params.Authority.PublicKey.PublicKey) == 0 {
+		params.Authority = params.Payer
+	}
+
+	// write program data to buffer
+	if err := d.WriteBuffer(
+		ctx,
+		WriteBufferParams{
+			Payer:       params.Payer,
+			Buffer:      params.Buffer,
+			Authority:   params.Authority,
+			ProgramData: params.ProgramData,
+		},
+	); err != nil {
+		return nil, fmt.Errorf("error writing buffer: %w", err)
+	}
+
+	// upgrade
+	upgradeInstructions, err := Upgrade(UpgradeParams{
+		ProgramPubkey:   params.ProgramPubkey,
+		BufferPubkey:    params.Buffer.PublicKey,
+		SpillPubkey:     params.Payer.PublicKey,
+		AuthorityPubkey: params.Authority.PublicKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating upgrade instruction: %w", err)
+	}
+	transactionID, err := d.sendTransaction(
+		ctx,
+		new(recentBlockHash),
+		params.Payer,
+		upgradeInstructions,
+		params.Payer.PrivateKey,
+		params.Authority.PrivateKey,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error sending upgrade transaction: %w", err)
+	}
+
+	return &UpgradeProgramResponse{
+		TransactionID: transactionID,
+	}, nil
+}
+
+// createBuffer sends a transaction to create and initialize the buffer account
+func (d *Deployer) createBuffer(ctx context.Context, params WriteBufferParams) error {
+	// get balance required to create buffer account
+	space := uint64(BufferMetadataSize + len(params.ProgramData))
+	getMinimumBalanceResponse, err := d.connection.GetMinimumBalanceForRentExemption(
+		ctx,
+		solana.GetMinimumBalanceForRentExemptionRequest{
+			DataLength:      space,
+			CommitmentLevel: d.config.commitmentLevel,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error getting minimum balance for buffer account: %w", err)
+	}
+
+	// create and initialize buffer account
+	instructions, err := systemProgram.CreateAccount(systemProgram.CreateAccountParams{
+		FromPubkey:       params.Payer.PublicKey,
+		NewAccountPubkey: params.Buffer.PublicKey,
+		Lamports:         getMinimumBalanceResponse.Lamports,
+		Space:            space,
+		ProgramID:        ID,
+	})
+	if err != nil {
+		return fmt.Errorf("error creating create buffer account instruction: %w", err)
+	}
+	initializeInstructions, err := InitializeBuffer(InitializeBufferParams{
+		BufferPubkey:    params.Buffer.PublicKey,
+		AuthorityPubkey: params.Authority.PublicKey,
+	})
+	if err != nil {
+		return fmt.Errorf("error creating initialize buffer instruction: %w", err)
+	}
+	if _, err := d.sendTransaction(
+		ctx,
+		new(recentBlockHash),
+		params.Payer,
+		append(instructions, initializeInstructions...),
+		params.Payer.PrivateKey,
+		params.Buffer.PrivateKey,
+	); err != nil {
+		return fmt.Errorf("error sending create buffer transaction: %w", err)
+	}
+
+	return nil
+}
+
+// getState gets the decoded State of the account with the given PublicKey.
+// nil is returned if the account does not exist.
+func (d *Deployer) getState(ctx context.Context, publicKey solana.PublicKey) (*State, error) {
+	getAccountInfoResponse, err := d.connection.GetAccountInfo(
+		ctx,
+		solana.GetAccountInfoRequest{
+			PublicKey:       publicKey,
+			CommitmentLevel: d.config.commitmentLevel,
+			Encoding:        solana.Base64Encoding,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error getting account info: %w", err)
+	}
+	accountInfo, ok := getAccountInfoResponse.AccountInfo.(solana.AccountInfoEncodedData)
+	if !ok || accountInfo.GetOwner() == "" {
+		// account does not exist
+		return nil, nil
+	}
+	if accountInfo.GetOwner() != ID.ToBase58() {
+		return nil, fmt.Errorf("account owned by %s: %w", accountInfo.GetOwner(), ErrUnexpectedOwner)
+	}
+
+	data, err := accountInfo.DecodeData()
+	if err != nil {
+		return nil, fmt.Errorf("error decoding account data: %w", err)
+	}
+
+	return DecodeState(data)
+}
+
+// recentBlockHash is a recent block hash shared by the transactions sent by a Deployer,
+// which is fetched when first needed and refreshed once it has expired
+type recentBlockHash struct {
+	blockHash string
+}
+
+// sendTransaction builds a transaction with the given instructions paid for by payer,
+// signs it with the given signers and sends it. The transaction ID is returned.
+// If the given recent block hash has expired then it is refreshed and the transaction resent.
+func (d *Deployer) sendTransaction(
+	ctx context.Context,
+	blockHash *recentBlockHash,
+	payer solana.KeyPair,
+	instructions []solana.Instruction,
+	signers ...solana.PrivateKey,
+) (string, error) {
+	for attempt := 0; ; attempt++ {
+		// get latest block hash if not yet fetched or expired
+		if blockHash.blockHash == "" {
+			getLatestBlockhashResponse, err := d.connection.GetLatestBlockhash(
+				ctx,
+				solana.GetLatestBlockhashRequest{
+					CommitmentLevel: d.config.commitmentLevel,
+				},
+			)
+			if err != nil {
+				return "", fmt.Errorf("error getting latest block hash: %w", err)
+			}
+			blockHash.blockHash = getLatestBlockhashResponse.BlockHash
+		}
+
+		// build and sign transaction
+		txn := solana.NewTransaction()
+		if err := txn.AddInstructions(instructions...); err != nil {
+			return "", fmt.Errorf("error adding instructions: %w", err)
+		}
+		if err := txn.SetFeePayer(payer.PublicKey); err != nil {
+			return "", fmt.Errorf("error setting fee payer: %w", err)
+		}
+		if err := txn.SetRecentBlockHash(blockHash.blockHash); err != nil {
+			return "", fmt.Errorf("error setting recent block hash: %w", err)
+		}
+		if err := txn.Sign(signers...); err != nil {
+			return "", fmt.Errorf("error signing transaction: %w", err)
+		}
+
+		// send transaction
+		sendTransactionResponse, err := d.connection.SendTransaction(
+			ctx,
+			solana.SendTransactionRequest{
+				Transaction: *txn,
+				Encoding:    solana.Base64Encoding,
+			},
+		)
+		if err != nil {
+			// refresh an expired block hash and send again, once
+			var preflightErr *solana.PreflightFailureError
+			if attempt == 0 &&
+				errors.As(err, &preflightErr) &&
+				preflightErr.Err != nil &&
+				preflightErr.Err.Type == solana.BlockhashNotFoundTransactionError {
+				blockHash.blockHash = ""
+				continue
+			}
+			return "", fmt.Errorf("error sending transaction: %w", err)
+		}
+
+		return sendTransactionResponse.TransactionID, nil
+	}
+}
@@ -0,0 +1,248 @@
+package bpfLoaderUpgradeableProgram
+
+import (
+	"context"
+	"encoding/base64"
+	"github.com/BRBussy/solgo"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// mockConnection is a solana.Connection with configurable behaviour
+// for the methods used by the Deployer.
+type mockConnection struct {
+	solana.Connection
+	getAccountInfoFunc      func(request solana.GetAccountInfoRequest) (*solana.GetAccountInfoResponse, error)
+	sendTransactionErrs     []error
+	sendTransactionCalls    []solana.SendTransactionRequest
+	getLatestBlockhashCalls int
+}
+
+func (m *mockConnection) GetAccountInfo(_ context.Context, request solana.GetAccountInfoRequest) (*solana.GetAccountInfoResponse, error) {
+	return m.getAccountInfoFunc(request)
+}
+
+func (m *mockConnection) GetMinimumBalanceForRentExemption(_ context.Context, request solana.GetMinimumBalanceForRentExemptionRequest) (*solana.GetMinimumBalanceForRentExemptionResponse, error) {
+	return &solana.GetMinimumBalanceForRentExemptionResponse{Lamports: request.DataLength * 10}, nil
+}
+
+func (m *mockConnection) GetLatestBlockhash(_ context.Context, _ solana.GetLatestBlockhashRequest) (*solana.GetLatestBlockhashResponse, error) {
+	m.getLatestBlockhashCalls++
+	return &solana.GetLatestBlockhashResponse{BlockHash: "CSymwgTNX1j3E4qhKfJAUE41nBWEwXufoYryPbkde5RR"}, nil
+}
+
+// SendTransaction records the request and fails with the next of sendTransactionErrs, if any
+func (m *mockConnection) SendTransaction(_ context.Context, request solana.SendTransactionRequest) (*solana.SendTransactionResponse, error) {
+	m.sendTransactionCalls = append(m.sendTransactionCalls, request)
+	if len(m.sendTransactionErrs) > 0 {
+		err := m.sendTransactionErrs[0]
+		m.sendTransactionErrs = m.sendTransactionErrs[1:]
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &solana.SendTransactionResponse{TransactionID: request.Transaction.Signature()}, nil
+}
+
+// bufferAccountInfo builds the account info of a buffer holding the given data
+func bufferAccountInfo(authority solana.PublicKey, data []byte) *solana.GetAccountInfoResponse {
+	accountData := append([]byte{1, 0, 0, 0, 1}, authority.PublicKey...)
+	accountData = append(accountData, data...)
+	return &solana.GetAccountInfoResponse{
+		AccountInfo: solana.AccountInfoEncodedData{
+			Data:  []string{base64.StdEncoding.EncodeToString(accountData), string(solana.Base64Encoding)},
+			Owner: ID.ToBase58(),
+		},
+	}
+}
+
+func TestWrite(t *testing.T) {
+	payer := solana.MustNewRandomKeypair()
+	authority := solana.MustNewRandomKeypair()
+	buffer := solana.MustNewRandomKeypair()
+
+	programData := make([]byte, 2*DefaultWriteChunkSize+1)
+	instructions, err := Write(WriteParams{
+		BufferPubkey:    buffer.PublicKey,
+		AuthorityPubkey: authority.PublicKey,
+		Offset:          10,
+		Bytes:           programData,
+	})
+	require.Nil(t, err)
+	require.Len(t, instructions, 3)
+	require.Equal(t, []byte{1, 0, 0, 0, 10, 0, 0, 0, 0x94, 0x03, 0, 0, 0, 0, 0, 0}, instructions[0].Data[:16])
+	require.Equal(t, []byte{1, 0, 0, 0, 0x9e, 0x03, 0, 0, 0x94, 0x03, 0, 0, 0, 0, 0, 0}, instructions[1].Data[:16])
+	require.Equal(t, []byte{1, 0, 0, 0, 0x32, 0x07, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0}, instructions[2].Data[:16])
+
+	// a full chunk should exactly fill a transaction
+	txn := solana.NewTransaction()
+	require.Nil(t, txn.AddInstructions(instructions[0]))
+	require.Nil(t, txn.SetFeePayer(payer.PublicKey))
+	require.Nil(t, txn.SetRecentBlockHash("CSymwgTNX1j3E4qhKfJAUE41nBWEwXufoYryPbkde5RR"))
+	require.Nil(t, txn.Sign(payer.PrivateKey, authority.PrivateKey))
+	txnBytes, err := txn.ToBytes()
+	require.Nil(t, err)
+	require.Len(t, txnBytes, solana.MaxTransactionSize)
+}
+
+func TestDeployer_WriteBuffer(t *testing.T) {
+	payer := solana.MustNewRandomKeypair()
+	buffer := solana.MustNewRandomKeypair()
+
+	programData := make([]byte, 25)
+	for i := range programData {
+		programData[i] = byte(i + 1)
+	}
+
+	t.Run("creates buffer and writes all chunks", func(t *testing.T) {
+		getAccountInfoCalls := 0
+		connection := &mockConnection{}
+		connection.getAccountInfoFunc = func(request solana.GetAccountInfoRequest) (*solana.GetAccountInfoResponse, error) {
+			require.Equal(t, buffer.PublicKey, request.PublicKey)
+			getAccountInfoCalls++
+			switch getAccountInfoCalls {
+			case 1:
+				// buffer does not exist
+				return &solana.GetAccountInfoResponse{AccountInfo: solana.AccountInfoEncodedData{}}, nil
+			case 2:
+				// buffer created but not written to
+				return bufferAccountInfo(payer.PublicKey, make([]byte, len(programData))), nil
+			default:
+				return bufferAccountInfo(payer.PublicKey, programData), nil
+			}
+		}
+
+		require.Nil(t, NewDeployer(connection, WithChunkSize(10), WithPollInterval(0)).WriteBuffer(
+			context.Background(),
+			WriteBufferParams{
+				Payer:       *payer,
+				Buffer:      *buffer,
+				ProgramData: programData,
+			},
+		))
+		require.Equal(t, 3, getAccountInfoCalls)
+
+		// 1 create transaction and 3 write transactions, with
+		// a block hash fetched for the create and for the write pass
+		require.Len(t, connection.sendTransactionCalls, 4)
+		require.Equal(t, 2, connection.getLatestBlockhashCalls)
+	})
+
+	t.Run("refreshes expired block hash", func(t *testing.T) {
+		getAccountInfoCalls := 0
+		connection := &mockConnection{
+			sendTransactionErrs: []error{
+				nil,
+				&solana.PreflightFailureError{Err: &solana.TransactionError{Type: solana.BlockhashNotFoundTransactionError}},
+			},
+		}
+		connection.getAccountInfoFunc = func(request solana.GetAccountInfoRequest) (*solana.GetAccountInfoResponse, error) {
+			getAccountInfoCalls++
+			if getAccountInfoCalls == 1 {
+				return bufferAccountInfo(payer.PublicKey, make([]byte, len(programData))), nil
+			}
+			return bufferAccountInfo(payer.PublicKey, programData), nil
+		}
+
+		require.Nil(t, NewDeployer(connection, WithChunkSize(10), WithPollInterval(0)).WriteBuffer(
+			context.Background(),
+			WriteBufferParams{
+				Payer:       *payer,
+				Buffer:      *buffer,
+				ProgramData: programData,
+			},
+		))
+
+		// the second chunk is resent after its block hash expired
+		require.Len(t, connection.sendTransactionCalls, 4)
+		require.Equal(t, 2, connection.getLatestBlockhashCalls)
+	})
+
+	t.Run("does not resend on other errors", func(t *testing.T) {
+		connection := &mockConnection{
+			sendTransactionErrs: []error{
+				&solana.PreflightFailureError{Err: &solana.TransactionError{Type: solana.AccountNotFoundTransactionError}},
+			},
+		}
+		connection.getAccountInfoFunc = func(request solana.GetAccountInfoRequest) (*solana.GetAccountInfoResponse, error) {
+			return bufferAccountInfo(payer.PublicKey, make([]byte, len(programData))), nil
+		}
+
+		err := NewDeployer(connection, WithChunkSize(10), WithPollInterval(0)).WriteBuffer(
+			context.Background(),
+			WriteBufferParams{
+				Payer:       *payer,
+				Buffer:      *buffer,
+				ProgramData: programData,
+			},
+		)
+		var preflightErr *solana.PreflightFailureError
+		require.ErrorAs(t, err, &preflightErr)
+		require.Len(t, connection.sendTransactionCalls, 1)
+	})
+
+	t.Run("resumes partially written buffer", func(t *testing.T) {
+		partialData := make([]byte, len(programData))
+		copy(partialData, programData[:20])
+
+		getAccountInfoCalls := 0
+		connection := &mockConnection{}
+		connection.getAccountInfoFunc = func(request solana.GetAccountInfoRequest) (*solana.GetAccountInfoResponse, error) {
+			getAccountInfoCalls++
+			if getAccountInfoCalls == 1 {
+				return bufferAccountInfo(payer.PublicKey, partialData), nil
+			}
+			return bufferAccountInfo(payer.PublicKey, programData), nil
+		}
+
+		require.Nil(t, NewDeployer(connection, WithChunkSize(10), WithPollInterval(0)).WriteBuffer(
+			context.Background(),
+			WriteBufferParams{
+				Payer:       *payer,
+				Buffer:      *buffer,
+				ProgramData: programData,
+			},
+		))
+
+		// only the last chunk should have been written
+		require.Len(t, connection.sendTransactionCalls, 1)
+		message, err := connection.sendTransactionCalls[0].Transaction.Message()
+		require.Nil(t, err)
+		require.Equal(t, append([]byte{1, 0, 0, 0, 20, 0, 0, 0, 5, 0, 0, 0, 0, 0, 0, 0}, programData[20:]...), message.Instructions[0].Data)
+	})
+
+	t.Run("gives up after max write passes", func(t *testing.T) {
+		connection := &mockConnection{}
+		connection.getAccountInfoFunc = func(request solana.GetAccountInfoRequest) (*solana.GetAccountInfoResponse, error) {
+			return bufferAccountInfo(payer.PublicKey, make([]byte, len(programData))), nil
+		}
+
+		err := NewDeployer(connection, WithChunkSize(10), WithPollInterval(0), WithMaxWritePasses(2)).WriteBuffer(
+			context.Background(),
+			WriteBufferParams{
+				Payer:       *payer,
+				Buffer:      *buffer,
+				ProgramData: programData,
+			},
+		)
+		require.ErrorIs(t, err, ErrBufferWriteIncomplete)
+		require.Len(t, connection.sendTransactionCalls, 6)
+	})
+
+	t.Run("buffer size mismatch", func(t *testing.T) {
+		connection := &mockConnection{}
+		connection.getAccountInfoFunc = func(request solana.GetAccountInfoRequest) (*solana.GetAccountInfoResponse, error) {
+			return bufferAccountInfo(payer.PublicKey, make([]byte, 3)), nil
+		}
+
+		err := NewDeployer(connection).WriteBuffer(
+			context.Background(),
+			WriteBufferParams{
+				Payer:       *payer,
+				Buffer:      *buffer,
+				ProgramData: programData,
+			},
+		)
+		require.ErrorIs(t, err, ErrBufferSizeMismatch)
+	})
+}
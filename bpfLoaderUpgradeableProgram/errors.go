@@ -0,0 +1,13 @@
+package bpfLoaderUpgradeableProgram
+
+import "errors"
+
+var (
+	ErrInvalidStateData      = errors.New("invalid state data")
+	ErrUnexpectedState       = errors.New("unexpected state")
+	ErrUnexpectedOwner       = errors.New("unexpected owner")
+	ErrUnexpectedAuthority   = errors.New("unexpected authority")
+	ErrBufferSizeMismatch    = errors.New("buffer size mismatch")
+	ErrBufferWriteIncomplete = errors.New("buffer write incomplete")
+	ErrInvalidChunkSize      = errors.New("invalid chunk size")
+)
@@ -0,0 +1,75 @@
+package bpfLoaderUpgradeableProgram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/BRBussy/solgo"
+	"github.com/BRBussy/solgo/systemProgram"
+)
+
+type ExtendProgramParams struct {
+	// ProgramPubkey is the program account whose ProgramData account is to be extended
+	// Req: [writer]
+	ProgramPubkey solana.PublicKey
+
+	// PayerPubkey is the account that will pay for the additional rent
+	// required by the extended ProgramData account.
+	// Not required if the ProgramData account already holds enough lamports.
+	// Req: [writer, signer]
+	PayerPubkey *solana.PublicKey
+
+	// AdditionalBytes is the number of bytes by which to extend the ProgramData account
+	AdditionalBytes uint32
+}
+
+type extendProgramInstructionData struct {
+	Instruction     Instruction
+	AdditionalBytes uint32
+}
+
+// ExtendProgram creates a Solana upgradeable BPF loader program Instruction to
+// extend the ProgramData account of a program so that it can be upgraded to
+// a larger program.
+func ExtendProgram(params ExtendProgramParams) ([]solana.Instruction, error) {
+	// derive program data address
+	programDataPubkey, err := ProgramDataAddress(params.ProgramPubkey)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving program data address: %w", err)
+	}
+
+	// encode instruction data
+	buf := new(bytes.Buffer)
+	if err := binary.Write(
+		buf,
+		binary.LittleEndian,
+		extendProgramInstructionData{
+			Instruction:     ExtendProgramInstruction,
+			AdditionalBytes: params.AdditionalBytes,
+		},
+	); err != nil {
+		return nil, fmt.Errorf("error encoding extend program data: %w", err)
+	}
+
+	// prepare accounts
+	accountMetas := []solana.InstructionAccountMeta{
+		{PubKey: programDataPubkey, IsSigner: false, IsWritable: true},
+		{PubKey: params.ProgramPubkey, IsSigner: false, IsWritable: true},
+	}
+	if params.PayerPubkey != nil {
+		accountMetas = append(
+			accountMetas,
+			solana.InstructionAccountMeta{PubKey: systemProgram.ID, IsSigner: false, IsWritable: false},
+			solana.InstructionAccountMeta{PubKey: *params.PayerPubkey, IsSigner: true, IsWritable: true},
+		)
+	}
+
+	// construct and return instruction
+	return []solana.Instruction{
+		{
+			InstructionAccountMeta: accountMetas,
+			ProgramIDPubKey:        ID,
+			Data:                   buf.Bytes(),
+		},
+	}, nil
+}
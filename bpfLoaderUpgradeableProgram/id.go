@@ -0,0 +1,18 @@
+// Package bpfLoaderUpgradeableProgram provides a set of functions for constructing Solana
+// upgradeable BPF loader program instructions, decoding the state of the accounts it
+// owns and deploying programs with it.
+// See instruction definitions here:
+// https://github.com/solana-labs/solana/blob/v1.16.0/sdk/program/src/loader_upgradeable_instruction.rs
+package bpfLoaderUpgradeableProgram
+
+import solana "github.com/BRBussy/solgo"
+
+// ID is the Solana upgradeable BPF loader program ID
+var ID = solana.NewPublicKeyFromBase58String("BPFLoaderUpgradeab1e11111111111111111111111")
+
+// ProgramDataAddress derives the address of the ProgramData account
+// that holds the executable data of the program with the given programID.
+func ProgramDataAddress(programID solana.PublicKey) (solana.PublicKey, error) {
+	address, _, err := solana.FindProgramAddress([][]byte{programID.PublicKey}, ID)
+	return address, err
+}
@@ -0,0 +1,45 @@
+package bpfLoaderUpgradeableProgram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/BRBussy/solgo"
+)
+
+type InitializeBufferParams struct {
+	// BufferPubkey is the account to initialize as a buffer.
+	// It must already have been created with enough space to hold
+	// BufferMetadataSize plus the program data and be owned by the loader.
+	// Req: [writer]
+	BufferPubkey solana.PublicKey
+
+	// AuthorityPubkey is the authority permitted to write to the buffer
+	AuthorityPubkey solana.PublicKey
+}
+
+// InitializeBuffer creates a Solana upgradeable BPF loader program
+// Instruction to initialize a buffer account
+func InitializeBuffer(params InitializeBufferParams) ([]solana.Instruction, error) {
+	// encode instruction data
+	buf := new(bytes.Buffer)
+	if err := binary.Write(
+		buf,
+		binary.LittleEndian,
+		InitializeBufferInstruction,
+	); err != nil {
+		return nil, fmt.Errorf("error encoding initialize buffer data: %w", err)
+	}
+
+	// construct and return instruction
+	return []solana.Instruction{
+		{
+			InstructionAccountMeta: []solana.InstructionAccountMeta{
+				{PubKey: params.BufferPubkey, IsSigner: false, IsWritable: true},
+				{PubKey: params.AuthorityPubkey, IsSigner: false, IsWritable: false},
+			},
+			ProgramIDPubKey: ID,
+			Data:            buf.Bytes(),
+		},
+	}, nil
+}
@@ -0,0 +1,16 @@
+package bpfLoaderUpgradeableProgram
+
+// Instruction is a Solana upgradeable BPF loader program Instruction.
+// See rust defs here: https://github.com/solana-labs/solana/blob/v1.16.0/sdk/program/src/loader_upgradeable_instruction.rs
+type Instruction uint32
+
+const (
+	InitializeBufferInstruction Instruction = iota
+	WriteInstruction
+	DeployWithMaxDataLenInstruction
+	UpgradeInstruction
+	SetAuthorityInstruction
+	CloseInstruction
+	ExtendProgramInstruction
+	SetAuthorityCheckedInstruction
+)
@@ -0,0 +1,261 @@
+package bpfLoaderUpgradeableProgram
+
+import (
+	"github.com/BRBussy/solgo"
+	"github.com/BRBussy/solgo/systemProgram"
+	"github.com/BRBussy/solgo/sysvar"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDeployWithMaxDataLen(t *testing.T) {
+	payer := solana.MustNewRandomKeypair().PublicKey
+	program := solana.MustNewRandomKeypair().PublicKey
+	buffer := solana.MustNewRandomKeypair().PublicKey
+	authority := solana.MustNewRandomKeypair().PublicKey
+	programData, err := ProgramDataAddress(program)
+	require.Nil(t, err)
+
+	instructions, err := DeployWithMaxDataLen(DeployWithMaxDataLenParams{
+		PayerPubkey:     payer,
+		ProgramPubkey:   program,
+		BufferPubkey:    buffer,
+		AuthorityPubkey: authority,
+		MaxDataLen:      0x0102030405060708,
+	})
+	require.Nil(t, err)
+	require.Equal(
+		t,
+		[]solana.Instruction{
+			{
+				InstructionAccountMeta: []solana.InstructionAccountMeta{
+					{PubKey: payer, IsSigner: true, IsWritable: true},
+					{PubKey: programData, IsWritable: true},
+					{PubKey: program, IsWritable: true},
+					{PubKey: buffer, IsWritable: true},
+					{PubKey: sysvar.RentID},
+					{PubKey: sysvar.ClockID},
+					{PubKey: systemProgram.ID},
+					{PubKey: authority, IsSigner: true},
+				},
+				ProgramIDPubKey: ID,
+				Data: []byte{
+					2, 0, 0, 0, // deploy with max data len instruction, u32
+					8, 7, 6, 5, 4, 3, 2, 1, // max data len, u64
+				},
+			},
+		},
+		instructions,
+	)
+}
+
+func TestUpgrade(t *testing.T) {
+	program := solana.MustNewRandomKeypair().PublicKey
+	buffer := solana.MustNewRandomKeypair().PublicKey
+	spill := solana.MustNewRandomKeypair().PublicKey
+	authority := solana.MustNewRandomKeypair().PublicKey
+	programData, err := ProgramDataAddress(program)
+	require.Nil(t, err)
+
+	instructions, err := Upgrade(UpgradeParams{
+		ProgramPubkey:   program,
+		BufferPubkey:    buffer,
+		SpillPubkey:     spill,
+		AuthorityPubkey: authority,
+	})
+	require.Nil(t, err)
+	require.Equal(
+		t,
+		[]solana.Instruction{
+			{
+				InstructionAccountMeta: []solana.InstructionAccountMeta{
+					{PubKey: programData, IsWritable: true},
+					{PubKey: program, IsWritable: true},
+					{PubKey: buffer, IsWritable: true},
+					{PubKey: spill, IsWritable: true},
+					{PubKey: sysvar.RentID},
+					{PubKey: sysvar.ClockID},
+					{PubKey: authority, IsSigner: true},
+				},
+				ProgramIDPubKey: ID,
+				Data: []byte{
+					3, 0, 0, 0, // upgrade instruction, u32
+				},
+			},
+		},
+		instructions,
+	)
+}
+
+func TestSetAuthority(t *testing.T) {
+	account := solana.MustNewRandomKeypair().PublicKey
+	authority := solana.MustNewRandomKeypair().PublicKey
+	newAuthority := solana.MustNewRandomKeypair().PublicKey
+
+	tests := []struct {
+		name         string
+		newAuthority *solana.PublicKey
+		wantAccounts []solana.InstructionAccountMeta
+	}{
+		{
+			name:         "new authority",
+			newAuthority: &newAuthority,
+			wantAccounts: []solana.InstructionAccountMeta{
+				{PubKey: account, IsWritable: true},
+				{PubKey: authority, IsSigner: true},
+				{PubKey: newAuthority},
+			},
+		},
+		{
+			name: "immutable",
+			wantAccounts: []solana.InstructionAccountMeta{
+				{PubKey: account, IsWritable: true},
+				{PubKey: authority, IsSigner: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instructions, err := SetAuthority(SetAuthorityParams{
+				AccountPubkey:      account,
+				AuthorityPubkey:    authority,
+				NewAuthorityPubkey: tt.newAuthority,
+			})
+			require.Nil(t, err)
+			require.Equal(
+				t,
+				[]solana.Instruction{
+					{
+						InstructionAccountMeta: tt.wantAccounts,
+						ProgramIDPubKey:        ID,
+						Data: []byte{
+							4, 0, 0, 0, // set authority instruction, u32
+						},
+					},
+				},
+				instructions,
+			)
+		})
+	}
+}
+
+func TestClose(t *testing.T) {
+	account := solana.MustNewRandomKeypair().PublicKey
+	recipient := solana.MustNewRandomKeypair().PublicKey
+	authority := solana.MustNewRandomKeypair().PublicKey
+	program := solana.MustNewRandomKeypair().PublicKey
+
+	tests := []struct {
+		name         string
+		authority    *solana.PublicKey
+		program      *solana.PublicKey
+		wantAccounts []solana.InstructionAccountMeta
+	}{
+		{
+			name: "uninitialized account",
+			wantAccounts: []solana.InstructionAccountMeta{
+				{PubKey: account, IsWritable: true},
+				{PubKey: recipient, IsWritable: true},
+			},
+		},
+		{
+			name:      "buffer account",
+			authority: &authority,
+			wantAccounts: []solana.InstructionAccountMeta{
+				{PubKey: account, IsWritable: true},
+				{PubKey: recipient, IsWritable: true},
+				{PubKey: authority, IsSigner: true},
+			},
+		},
+		{
+			name:      "program data account",
+			authority: &authority,
+			program:   &program,
+			wantAccounts: []solana.InstructionAccountMeta{
+				{PubKey: account, IsWritable: true},
+				{PubKey: recipient, IsWritable: true},
+				{PubKey: authority, IsSigner: true},
+				{PubKey: program, IsWritable: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instructions, err := Close(CloseParams{
+				AccountPubkey:   account,
+				RecipientPubkey: recipient,
+				AuthorityPubkey: tt.authority,
+				ProgramPubkey:   tt.program,
+			})
+			require.Nil(t, err)
+			require.Equal(
+				t,
+				[]solana.Instruction{
+					{
+						InstructionAccountMeta: tt.wantAccounts,
+						ProgramIDPubKey:        ID,
+						Data: []byte{
+							5, 0, 0, 0, // close instruction, u32
+						},
+					},
+				},
+				instructions,
+			)
+		})
+	}
+}
+
+func TestExtendProgram(t *testing.T) {
+	program := solana.MustNewRandomKeypair().PublicKey
+	payer := solana.MustNewRandomKeypair().PublicKey
+	programData, err := ProgramDataAddress(program)
+	require.Nil(t, err)
+
+	tests := []struct {
+		name         string
+		payer        *solana.PublicKey
+		wantAccounts []solana.InstructionAccountMeta
+	}{
+		{
+			name: "without payer",
+			wantAccounts: []solana.InstructionAccountMeta{
+				{PubKey: programData, IsWritable: true},
+				{PubKey: program, IsWritable: true},
+			},
+		},
+		{
+			name:  "with payer",
+			payer: &payer,
+			wantAccounts: []solana.InstructionAccountMeta{
+				{PubKey: programData, IsWritable: true},
+				{PubKey: program, IsWritable: true},
+				{PubKey: systemProgram.ID},
+				{PubKey: payer, IsSigner: true, IsWritable: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instructions, err := ExtendProgram(ExtendProgramParams{
+				ProgramPubkey:   program,
+				PayerPubkey:     tt.payer,
+				AdditionalBytes: 0x01020304,
+			})
+			require.Nil(t, err)
+			require.Equal(
+				t,
+				[]solana.Instruction{
+					{
+						InstructionAccountMeta: tt.wantAccounts,
+						ProgramIDPubKey:        ID,
+						Data: []byte{
+							6, 0, 0, 0, // extend program instruction, u32
+							4, 3, 2, 1, // additional bytes, u32
+						},
+					},
+				},
+				instructions,
+			)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package bpfLoaderUpgradeableProgram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/BRBussy/solgo"
+)
+
+type SetAuthorityParams struct {
+	// AccountPubkey is the buffer or ProgramData account whose authority is being set.
+	// To set the upgrade authority of a program use ProgramDataAddress to
+	// derive the address of its ProgramData account.
+	// Req: [writer]
+	AccountPubkey solana.PublicKey
+
+	// AuthorityPubkey is the current authority
+	// Req: [signer]
+	AuthorityPubkey solana.PublicKey
+
+	// NewAuthorityPubkey is the new authority.
+	// If nil the ProgramData account is made immutable, which
+	// is not permitted for buffer accounts.
+	NewAuthorityPubkey *solana.PublicKey
+}
+
+// SetAuthority creates a Solana upgradeable BPF loader program Instruction to
+// set the authority of a buffer or ProgramData account.
+func SetAuthority(params SetAuthorityParams) ([]solana.Instruction, error) {
+	// encode instruction data
+	buf := new(bytes.Buffer)
+	if err := binary.Write(
+		buf,
+		binary.LittleEndian,
+		SetAuthorityInstruction,
+	); err != nil {
+		return nil, fmt.Errorf("error encoding set authority data: %w", err)
+	}
+
+	// prepare accounts
+	accountMetas := []solana.InstructionAccountMeta{
+		{PubKey: params.AccountPubkey, IsSigner: false, IsWritable: true},
+		{PubKey: params.AuthorityPubkey, IsSigner: true, IsWritable: false},
+	}
+	if params.NewAuthorityPubkey != nil {
+		accountMetas = append(
+			accountMetas,
+			solana.InstructionAccountMeta{PubKey: *params.NewAuthorityPubkey, IsSigner: false, IsWritable: false},
+		)
+	}
+
+	// construct and return instruction
+	return []solana.Instruction{
+		{
+			InstructionAccountMeta: accountMetas,
+			ProgramIDPubKey:        ID,
+			Data:                   buf.Bytes(),
+		},
+	}, nil
+}
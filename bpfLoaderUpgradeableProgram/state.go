@@ -0,0 +1,131 @@
+package bpfLoaderUpgradeableProgram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/BRBussy/solgo"
+)
+
+// StateType is the type of an account owned by the upgradeable BPF loader.
+// See rust defs here: https://github.com/solana-labs/solana/blob/v1.16.0/sdk/program/src/bpf_loader_upgradeable.rs
+type StateType uint32
+
+const (
+	// UninitializedStateType is an account that has not yet been initialized
+	UninitializedStateType StateType = iota
+
+	// BufferStateType is an account holding program data that is yet to be deployed
+	BufferStateType
+
+	// ProgramStateType is a deployed program account which references
+	// the ProgramData account that holds its executable data
+	ProgramStateType
+
+	// ProgramDataStateType is an account holding the executable data of a deployed program
+	ProgramDataStateType
+)
+
+const (
+	// BufferMetadataSize is the size of the metadata preceding the program data in a buffer account
+	BufferMetadataSize = 37
+
+	// ProgramDataMetadataSize is the size of the metadata preceding the program data in a ProgramData account
+	ProgramDataMetadataSize = 45
+
+	// ProgramSize is the size of a program account
+	ProgramSize = 36
+)
+
+// State is the decoded state of an account owned by the upgradeable BPF loader
+type State struct {
+	// Type is the type of account
+	Type StateType
+
+	// AuthorityAddress is the authority of a BufferStateType account or the
+	// upgrade authority of a ProgramDataStateType account.
+	// nil if there is no authority, in which case the account is immutable.
+	AuthorityAddress *solana.PublicKey
+
+	// ProgramDataAddress is the address of the ProgramData
+	// account of a ProgramStateType account.
+	ProgramDataAddress solana.PublicKey
+
+	// Slot is the slot at which a ProgramDataStateType account was last modified
+	Slot uint64
+
+	// Data is the program data held by a BufferStateType or ProgramDataStateType account
+	Data []byte
+}
+
+// DecodeState decodes the given account data into a State
+func DecodeState(data []byte) (*State, error) {
+	r := bytes.NewReader(data)
+
+	// read state type
+	var state State
+	if err := binary.Read(r, binary.LittleEndian, &state.Type); err != nil {
+		return nil, fmt.Errorf("error reading state type: %w", err)
+	}
+
+	switch state.Type {
+	case UninitializedStateType:
+
+	case BufferStateType:
+		authorityAddress, err := readOptionalPublicKey(r)
+		if err != nil {
+			return nil, fmt.Errorf("error reading buffer authority: %w", err)
+		}
+		state.AuthorityAddress = authorityAddress
+		if len(data) < BufferMetadataSize {
+			return nil, fmt.Errorf("buffer shorter than metadata: %w", ErrInvalidStateData)
+		}
+		state.Data = data[BufferMetadataSize:]
+
+	case ProgramStateType:
+		var programDataAddress [32]byte
+		if err := binary.Read(r, binary.LittleEndian, &programDataAddress); err != nil {
+			return nil, fmt.Errorf("error reading program data address: %w", err)
+		}
+		state.ProgramDataAddress = solana.NewPublicKeyFromBytes(programDataAddress)
+
+	case ProgramDataStateType:
+		if err := binary.Read(r, binary.LittleEndian, &state.Slot); err != nil {
+			return nil, fmt.Errorf("error reading program data slot: %w", err)
+		}
+		authorityAddress, err := readOptionalPublicKey(r)
+		if err != nil {
+			return nil, fmt.Errorf("error reading upgrade authority: %w", err)
+		}
+		state.AuthorityAddress = authorityAddress
+		if len(data) < ProgramDataMetadataSize {
+			return nil, fmt.Errorf("program data shorter than metadata: %w", ErrInvalidStateData)
+		}
+		state.Data = data[ProgramDataMetadataSize:]
+
+	default:
+		return nil, fmt.Errorf("state type %d: %w", state.Type, ErrInvalidStateData)
+	}
+
+	return &state, nil
+}
+
+// readOptionalPublicKey reads a serialised optional PublicKey.
+// nil is returned if the PublicKey is not set.
+func readOptionalPublicKey(r *bytes.Reader) (*solana.PublicKey, error) {
+	var option struct {
+		IsSet     uint8
+		PublicKey [32]byte
+	}
+	if err := binary.Read(r, binary.LittleEndian, &option.IsSet); err != nil {
+		return nil, err
+	}
+	if option.IsSet == 0 {
+		return nil, nil
+	}
+	if err := binary.Read(r, binary.LittleEndian, &option.PublicKey); err != nil {
+		return nil, err
+	}
+	publicKey := solana.NewPublicKeyFromBytes(option.PublicKey)
+	return &publicKey, nil
+}
@@ -0,0 +1,77 @@
+package bpfLoaderUpgradeableProgram
+
+import (
+	"github.com/BRBussy/solgo"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDecodeState(t *testing.T) {
+	authority := solana.MustNewRandomKeypair().PublicKey
+	programData := solana.MustNewRandomKeypair().PublicKey
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    *State
+		wantErr bool
+	}{
+		{
+			name: "uninitialized",
+			data: []byte{0, 0, 0, 0},
+			want: &State{Type: UninitializedStateType},
+		},
+		{
+			name: "buffer with authority",
+			data: append(append([]byte{1, 0, 0, 0, 1}, authority.PublicKey...), 0xaa, 0xbb),
+			want: &State{
+				Type:             BufferStateType,
+				AuthorityAddress: &authority,
+				Data:             []byte{0xaa, 0xbb},
+			},
+		},
+		{
+			name: "buffer without authority",
+			data: append(append([]byte{1, 0, 0, 0, 0}, make([]byte, 32)...), 0xaa),
+			want: &State{
+				Type: BufferStateType,
+				Data: []byte{0xaa},
+			},
+		},
+		{
+			name: "program",
+			data: append([]byte{2, 0, 0, 0}, programData.PublicKey...),
+			want: &State{
+				Type:               ProgramStateType,
+				ProgramDataAddress: programData,
+			},
+		},
+		{
+			name: "program data",
+			data: append(append([]byte{3, 0, 0, 0, 7, 0, 0, 0, 0, 0, 0, 0, 1}, authority.PublicKey...), 0xcc),
+			want: &State{
+				Type:             ProgramDataStateType,
+				Slot:             7,
+				AuthorityAddress: &authority,
+				Data:             []byte{0xcc},
+			},
+		},
+		{
+			name:    "unknown state type",
+			data:    []byte{4, 0, 0, 0},
+			wantErr: true,
+		},
+		{
+			name:    "truncated program",
+			data:    []byte{2, 0, 0, 0, 1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeState(tt.data)
+			require.Equal(t, tt.wantErr, err != nil)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package bpfLoaderUpgradeableProgram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/BRBussy/solgo"
+	"github.com/BRBussy/solgo/sysvar"
+)
+
+type UpgradeParams struct {
+	// ProgramPubkey is the program account to upgrade
+	// Req: [writer]
+	ProgramPubkey solana.PublicKey
+
+	// BufferPubkey is the buffer account holding the new program data.
+	// It is closed once the upgrade is complete.
+	// Req: [writer]
+	BufferPubkey solana.PublicKey
+
+	// SpillPubkey is the account to which the lamports of the buffer are transferred
+	// Req: [writer]
+	SpillPubkey solana.PublicKey
+
+	// AuthorityPubkey is the upgrade authority of the program,
+	// which must also be the authority of the buffer.
+	// Req: [signer]
+	AuthorityPubkey solana.PublicKey
+}
+
+// Upgrade creates a Solana upgradeable BPF loader program Instruction to
+// upgrade a program with the program data in a buffer account.
+func Upgrade(params UpgradeParams) ([]solana.Instruction, error) {
+	// derive program data address
+	programDataPubkey, err := ProgramDataAddress(params.ProgramPubkey)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving program data address: %w", err)
+	}
+
+	// encode instruction data
+	buf := new(bytes.Buffer)
+	if err := binary.Write(
+		buf,
+		binary.LittleEndian,
+		UpgradeInstruction,
+	); err != nil {
+		return nil, fmt.Errorf("error encoding upgrade data: %w", err)
+	}
+
+	// construct and return instruction
+	return []solana.Instruction{
+		{
+			InstructionAccountMeta: []solana.InstructionAccountMeta{
+				{PubKey: programDataPubkey, IsSigner: false, IsWritable: true},
+				{PubKey: params.ProgramPubkey, IsSigner: false, IsWritable: true},
+				{PubKey: params.BufferPubkey, IsSigner: false, IsWritable: true},
+				{PubKey: params.SpillPubkey, IsSigner: false, IsWritable: true},
+				{PubKey: sysvar.RentID, IsSigner: false, IsWritable: false},
+				{PubKey: sysvar.ClockID, IsSigner: false, IsWritable: false},
+				{PubKey: params.AuthorityPubkey, IsSigner: true, IsWritable: false},
+			},
+			ProgramIDPubKey: ID,
+			Data:            buf.Bytes(),
+		},
+	}, nil
+}
@@ -0,0 +1,95 @@
+package bpfLoaderUpgradeableProgram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/BRBussy/solgo"
+)
+
+// DefaultWriteChunkSize is the largest number of bytes that can be written to a
+// buffer by a Write instruction in a Transaction that is signed by both a fee payer
+// and a separate buffer authority while staying within solana.MaxTransactionSize.
+//
+// The remaining 316 bytes of the Transaction are made up of:
+//   - 129 bytes for the 2 signatures and their length
+//   - 164 bytes for the message header, 4 account keys and recent block hash
+//   - 7 bytes for the instruction count, program index, account indices and data length
+//   - 16 bytes for the Write instruction, offset and bytes length
+const DefaultWriteChunkSize = solana.MaxTransactionSize - 316
+
+type WriteParams struct {
+	// BufferPubkey is the buffer account to write to
+	// Req: [writer]
+	BufferPubkey solana.PublicKey
+
+	// AuthorityPubkey is the authority of the buffer
+	// Req: [signer]
+	AuthorityPubkey solana.PublicKey
+
+	// Offset is the offset at which to start writing Bytes, relative
+	// to the start of the program data in the buffer.
+	Offset uint32
+
+	// Bytes are the program data bytes to write
+	Bytes []byte
+
+	// ChunkSize is the maximum number of bytes written per instruction.
+	// Default value if not specified is DefaultWriteChunkSize.
+	ChunkSize uint32
+}
+
+type writeInstructionDataHeader struct {
+	Instruction Instruction
+	Offset      uint32
+	BytesLength uint64
+}
+
+// Write creates Solana upgradeable BPF loader program Instructions to write
+// program data to a buffer account.
+// Bytes are split into chunks of at most ChunkSize, with a Write instruction
+// returned for each chunk. Each Instruction should be sent in its own Transaction.
+func Write(params WriteParams) ([]solana.Instruction, error) {
+	chunkSize := params.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = DefaultWriteChunkSize
+	}
+
+	instructions := make([]solana.Instruction, 0)
+	for start := uint32(0); start < uint32(len(params.Bytes)); start += chunkSize {
+		end := start + chunkSize
+		if end > uint32(len(params.Bytes)) {
+			end = uint32(len(params.Bytes))
+		}
+
+		// encode instruction data
+		buf := new(bytes.Buffer)
+		if err := binary.Write(
+			buf,
+			binary.LittleEndian,
+			writeInstructionDataHeader{
+				Instruction: WriteInstruction,
+				Offset:      params.Offset + start,
+				BytesLength: uint64(end - start),
+			},
+		); err != nil {
+			return nil, fmt.Errorf("error encoding write data: %w", err)
+		}
+		buf.Write(params.Bytes[start:end])
+
+		// construct instruction
+		instructions = append(
+			instructions,
+			solana.Instruction{
+				InstructionAccountMeta: []solana.InstructionAccountMeta{
+					{PubKey: params.BufferPubkey, IsSigner: false, IsWritable: true},
+					{PubKey: params.AuthorityPubkey, IsSigner: true, IsWritable: false},
+				},
+				ProgramIDPubKey: ID,
+				Data:            buf.Bytes(),
+			},
+		)
+	}
+
+	return instructions, nil
+}
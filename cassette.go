@@ -0,0 +1,60 @@
+package solana
+
+import (
+	"github.com/BRBussy/solgo/internal/pkg/jsonrpc"
+	"io"
+)
+
+// Cassette holds json-rpc calls, and their responses, recorded by a JSONRPCConnection configured
+// WithCassetteRecording. A JSONRPCConnection configured WithCassetteReplay serves calls from the
+// Cassette instead of an endpoint, so that tests of code using the connection run offline and
+// deterministically. Cassettes are stored as JSON lines, with a recorded call on each line.
+type Cassette struct {
+	cassette *jsonrpc.Cassette
+}
+
+// ReadCassette reads a Cassette from the given reader, e.g. a file written WithCassetteRecording
+func ReadCassette(r io.Reader) (*Cassette, error) {
+	cassette, err := jsonrpc.ReadCassette(r)
+	if err != nil {
+		return nil, err
+	}
+	return &Cassette{cassette: cassette}, nil
+}
+
+// Remaining returns the number of recorded calls of the Cassette that have not been replayed
+func (c *Cassette) Remaining() int {
+	return c.cassette.Remaining()
+}
+
+// CassetteMatcher reports whether a request matches a recorded request. The params of both
+// requests are as decoded from json, i.e. numbers are float64s and objects are map[string]interface{}s.
+type CassetteMatcher func(request, recorded JSONRPCRequest) bool
+
+// MatchMethod matches requests of the same method, regardless of params
+func MatchMethod(request, recorded JSONRPCRequest) bool {
+	return request.Method == recorded.Method
+}
+
+// MatchMethodAndParams returns a CassetteMatcher that matches requests of the same method and params.
+// The given keys are removed from all objects in the params before comparison, e.g. to ignore
+// "minContextSlot" or "commitment" configuration that varies between a recording and a replay.
+func MatchMethodAndParams(ignoredKeys ...string) CassetteMatcher {
+	matcher := jsonrpc.MatchMethodAndParams(ignoredKeys...)
+	return func(request, recorded JSONRPCRequest) bool {
+		return matcher(
+			jsonrpc.CassetteRequest{Method: request.Method, Params: request.Params},
+			jsonrpc.CassetteRequest{Method: recorded.Method, Params: recorded.Params},
+		)
+	}
+}
+
+// toJSONRPCMatcher converts the CassetteMatcher to a jsonrpc.Matcher
+func (m CassetteMatcher) toJSONRPCMatcher() jsonrpc.Matcher {
+	return func(request, recorded jsonrpc.CassetteRequest) bool {
+		return m(
+			JSONRPCRequest{Method: request.Method, Params: request.Params},
+			JSONRPCRequest{Method: recorded.Method, Params: recorded.Params},
+		)
+	}
+}
@@ -0,0 +1,122 @@
+package solana
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithCassetteRecording_WithCassetteReplay(t *testing.T) {
+	publicKey := NewPublicKeyFromBase58String("9B5XszUGdMaxCZ7uSQhPzdks5ZQSmWxrmzCSvtJ6Ns6g")
+
+	// prepare server that responds to getBalance and getSlot
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var request struct {
+			Method string `json:"method"`
+		}
+		require.NoError(t, json.Unmarshal(body, &request))
+		switch request.Method {
+		case "getBalance":
+			_, _ = io.WriteString(w, `{"jsonrpc":"2.0","result":{"context":{"slot":1},"value":10},"id":1}`)
+		case "getSlot":
+			_, _ = io.WriteString(w, `{"jsonrpc":"2.0","result":42,"id":1}`)
+		default:
+			_, _ = io.WriteString(w, `{"jsonrpc":"2.0","error":{"code":-32601,"message":"Method not found"},"id":1}`)
+		}
+	}))
+
+	// record calls
+	var cassetteData bytes.Buffer
+	connection := NewJSONRPCConnection(
+		WithEndpoint(server.URL),
+		WithCassetteRecording(&cassetteData),
+	)
+	getBalanceResponse, err := connection.GetBalance(context.Background(), GetBalanceRequest{PublicKey: publicKey})
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), getBalanceResponse.Value)
+	_, err = connection.GetSlot(context.Background(), GetSlotRequest{})
+	require.NoError(t, err)
+	_, err = connection.GetFirstAvailableBlock(context.Background())
+	require.Error(t, err)
+
+	// replay calls offline
+	server.Close()
+	cassette, err := ReadCassette(&cassetteData)
+	require.NoError(t, err)
+	require.Equal(t, 3, cassette.Remaining())
+	connection = NewJSONRPCConnection(
+		WithEndpoint(server.URL),
+		WithCassetteReplay(cassette, nil),
+	)
+	replayedGetBalanceResponse, err := connection.GetBalance(context.Background(), GetBalanceRequest{PublicKey: publicKey})
+	require.NoError(t, err)
+	require.Equal(t, getBalanceResponse, replayedGetBalanceResponse)
+	getSlotResponse, err := connection.GetSlot(context.Background(), GetSlotRequest{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), getSlotResponse.Slot)
+	_, err = connection.GetFirstAvailableBlock(context.Background())
+	var rpcErr *RPCError
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, 0, cassette.Remaining())
+
+	// calls that were not recorded fail
+	_, err = connection.GetSlot(context.Background(), GetSlotRequest{})
+	require.ErrorIs(t, err, ErrUnmatchedCall)
+}
+
+func TestCassetteMatcher(t *testing.T) {
+	request := JSONRPCRequest{
+		Method: "getBalance",
+		Params: []interface{}{"address", map[string]interface{}{"commitment": "confirmed"}},
+	}
+
+	tests := []struct {
+		name     string
+		matcher  CassetteMatcher
+		recorded JSONRPCRequest
+		want     bool
+	}{
+		{
+			name:     "method and params match",
+			matcher:  MatchMethodAndParams(),
+			recorded: request,
+			want:     true,
+		},
+		{
+			name:    "params do not match",
+			matcher: MatchMethodAndParams(),
+			recorded: JSONRPCRequest{
+				Method: "getBalance",
+				Params: []interface{}{"address", map[string]interface{}{"commitment": "finalized"}},
+			},
+			want: false,
+		},
+		{
+			name:    "ignored params do not need to match",
+			matcher: MatchMethodAndParams("commitment"),
+			recorded: JSONRPCRequest{
+				Method: "getBalance",
+				Params: []interface{}{"address", map[string]interface{}{"commitment": "finalized"}},
+			},
+			want: true,
+		},
+		{
+			name:     "method matches",
+			matcher:  MatchMethod,
+			recorded: JSONRPCRequest{Method: "getBalance"},
+			want:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.matcher(request, tt.recorded))
+		})
+	}
+}
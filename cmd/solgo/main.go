@@ -0,0 +1,88 @@
+// Command solgo provides tooling for working with Solana programs from Go.
+//
+// Usage:
+//
+//	solgo anchor -idl <idl.json> [-out <dir>] [-package <name>] [-address <program id>]
+//
+// The anchor command generates a Go client package for an Anchor program from its IDL,
+// with instruction builders, account and event decoders and error codes.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/BRBussy/solgo/internal/pkg/anchorGenerator"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "anchor":
+		if err := anchor(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "solgo anchor: %s\n", err)
+			os.Exit(1)
+		}
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: solgo anchor -idl <idl.json> [-out <dir>] [-package <name>] [-address <program id>]")
+}
+
+func anchor(args []string) error {
+	// parse flags
+	flags := flag.NewFlagSet("anchor", flag.ExitOnError)
+	idlFile := flags.String("idl", "", "path to the Anchor IDL json file")
+	outDir := flags.String("out", ".", "directory in which to write the generated package")
+	packageName := flags.String("package", "", "name of the generated package, defaults to the program name")
+	programAddress := flags.String("address", "", "program ID, defaults to the address in the IDL metadata")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *idlFile == "" {
+		flags.Usage()
+		return fmt.Errorf("-idl is required")
+	}
+
+	// read and parse idl
+	idlData, err := os.ReadFile(*idlFile)
+	if err != nil {
+		return fmt.Errorf("error reading idl: %w", err)
+	}
+	idl, err := anchorGenerator.ParseIDL(idlData)
+	if err != nil {
+		return err
+	}
+
+	// generate package
+	config := anchorGenerator.Config{
+		PackageName:    *packageName,
+		ProgramAddress: *programAddress,
+	}
+	src, err := anchorGenerator.Generate(*idl, config)
+	if err != nil {
+		return err
+	}
+
+	// write package
+	if err := os.MkdirAll(*outDir, 0755); err != nil {
+		return fmt.Errorf("error creating output directory: %w", err)
+	}
+	// the file is named after the package
+	outFile := filepath.Join(*outDir, anchorGenerator.PackageName(*idl, config)+".go")
+	if err := os.WriteFile(outFile, src, 0644); err != nil {
+		return fmt.Errorf("error writing generated package: %w", err)
+	}
+	fmt.Println(outFile)
+
+	return nil
+}
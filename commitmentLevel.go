@@ -22,3 +22,19 @@ var (
 	RootCommitmentLevel         CommitmentLevel = "root"         // Deprecated as of v1.5.5
 	MaxCommitmentLevel          CommitmentLevel = "max"          // Deprecated as of v1.5.5
 )
+
+// rank returns the rank of the CommitmentLevel from 1 for ProcessedCommitmentLevel
+// to 3 for FinalizedCommitmentLevel, mapping deprecated levels to their replacements.
+// 0 is returned for unknown levels.
+func (c CommitmentLevel) rank() int {
+	switch c {
+	case ProcessedCommitmentLevel, RecentCommitmentLevel:
+		return 1
+	case ConfirmedCommitmentLevel, SingleCommitmentLevel, SingleGossipCommitmentLevel:
+		return 2
+	case FinalizedCommitmentLevel, RootCommitmentLevel, MaxCommitmentLevel:
+		return 3
+	default:
+		return 0
+	}
+}
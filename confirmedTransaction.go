@@ -0,0 +1,330 @@
+package solana
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// TransactionVersion is the version of a transaction message
+type TransactionVersion int
+
+const (
+	// LegacyTransactionVersion is the version of transactions with a legacy message
+	LegacyTransactionVersion TransactionVersion = -1
+
+	// V0TransactionVersion is the version of transactions with a v0 message,
+	// which supports address lookup tables
+	V0TransactionVersion TransactionVersion = 0
+)
+
+// UnmarshalJSON implements json.Unmarshaler for TransactionVersion,
+// which is given as either "legacy" or a version number
+func (v *TransactionVersion) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case `"legacy"`, "null":
+		*v = LegacyTransactionVersion
+		return nil
+	}
+	version, err := strconv.Atoi(string(data))
+	if err != nil {
+		return fmt.Errorf("transaction version %s: %w", string(data), ErrUnexpectedResponse)
+	}
+	*v = TransactionVersion(version)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler for TransactionVersion
+func (v TransactionVersion) MarshalJSON() ([]byte, error) {
+	if v == LegacyTransactionVersion {
+		return []byte(`"legacy"`), nil
+	}
+	return []byte(strconv.Itoa(int(v))), nil
+}
+
+// ConfirmedTransaction is a transaction that has been confirmed in a block
+type ConfirmedTransaction struct {
+	// Slot is the slot in which the transaction was processed
+	Slot uint64
+
+	// BlockTime is the estimated production time of the block in which the transaction
+	// was processed, as a unix timestamp. nil if not available.
+	BlockTime *int64
+
+	// Version is the version of the transaction
+	Version TransactionVersion
+
+	// Transaction is the transaction in the requested Encoding
+	Transaction EncodedTransaction
+
+	// Meta is the transaction status metadata. nil if not available.
+	Meta *TransactionMeta
+}
+
+// EncodedTransaction is a transaction as returned by the rpc in a requested Encoding.
+// Exactly one of Data, JSON or Parsed is set.
+type EncodedTransaction struct {
+	// Data is the serialised transaction, set for Base58Encoding and Base64Encoding
+	Data []byte
+
+	// JSON is the transaction, set for JSONEncoding
+	JSON *TransactionJSON
+
+	// Parsed is the transaction with parsed instructions, set for JSONParsedEncoding
+	Parsed *ParsedTransactionJSON
+}
+
+// TransactionJSON is a transaction returned in JSONEncoding
+type TransactionJSON struct {
+	// Signatures are the base58 encoded signatures of the transaction
+	Signatures []string    `json:"signatures"`
+	Message    MessageJSON `json:"message"`
+}
+
+// MessageJSON is a transaction message returned in JSONEncoding
+type MessageJSON struct {
+	Header MessageHeader `json:"header"`
+
+	// AccountKeys are the base58 encoded static account keys of the message
+	AccountKeys []string `json:"accountKeys"`
+
+	// RecentBlockHash is the base58 encoded recent block hash of the message
+	RecentBlockHash string `json:"recentBlockhash"`
+
+	Instructions []CompiledInstructionJSON `json:"instructions"`
+
+	// AddressTableLookups are the address lookup tables used
+	// by a V0TransactionVersion message to load accounts
+	AddressTableLookups []AddressTableLookup `json:"addressTableLookups"`
+}
+
+// CompiledInstructionJSON is a CompiledInstruction returned in JSONEncoding
+type CompiledInstructionJSON struct {
+	ProgramIDIndex int `json:"programIdIndex"`
+
+	// Accounts are the indices of the accounts to be passed to the program
+	Accounts []int `json:"accounts"`
+
+	// Data is the base58 encoded instruction data
+	Data string `json:"data"`
+
+	// StackHeight is the invocation stack height of the instruction, if available
+	StackHeight *int `json:"stackHeight"`
+}
+
+// AddressTableLookup is a lookup of accounts from an address lookup table
+type AddressTableLookup struct {
+	// AccountKey is the base58 encoded address of the address lookup table
+	AccountKey string `json:"accountKey"`
+
+	// WritableIndexes are the indices of the writable accounts loaded from the table
+	WritableIndexes []int `json:"writableIndexes"`
+
+	// ReadonlyIndexes are the indices of the read-only accounts loaded from the table
+	ReadonlyIndexes []int `json:"readonlyIndexes"`
+}
+
+// ParsedTransactionJSON is a transaction returned in JSONParsedEncoding
+type ParsedTransactionJSON struct {
+	// Signatures are the base58 encoded signatures of the transaction
+	Signatures []string          `json:"signatures"`
+	Message    ParsedMessageJSON `json:"message"`
+}
+
+// ParsedMessageJSON is a transaction message returned in JSONParsedEncoding
+type ParsedMessageJSON struct {
+	AccountKeys []ParsedAccountKey `json:"accountKeys"`
+
+	// RecentBlockHash is the base58 encoded recent block hash of the message
+	RecentBlockHash string `json:"recentBlockhash"`
+
+	Instructions []ParsedInstructionJSON `json:"instructions"`
+
+	// AddressTableLookups are the address lookup tables used
+	// by a V0TransactionVersion message to load accounts
+	AddressTableLookups []AddressTableLookup `json:"addressTableLookups"`
+}
+
+// ParsedAccountKey is an account key of a message returned in JSONParsedEncoding
+type ParsedAccountKey struct {
+	// PubKey is the base58 encoded account key
+	PubKey   string `json:"pubkey"`
+	Signer   bool   `json:"signer"`
+	Writable bool   `json:"writable"`
+
+	// Source is either "transaction" or "lookupTable"
+	Source string `json:"source"`
+}
+
+// ParsedInstructionJSON is an instruction returned in JSONParsedEncoding.
+// Parsed is set if the node has a parser for the program, otherwise Accounts and Data are set.
+type ParsedInstructionJSON struct {
+	// ProgramID is the base58 encoded ID of the program invoked
+	ProgramID string `json:"programId"`
+
+	// Program is the name of the program, set if Parsed is set
+	Program string `json:"program"`
+
+	// Parsed is the parsed instruction
+	Parsed json.RawMessage `json:"parsed"`
+
+	// Accounts are the base58 encoded accounts passed to the program
+	Accounts []string `json:"accounts"`
+
+	// Data is the base58 encoded instruction data
+	Data string `json:"data"`
+
+	// StackHeight is the invocation stack height of the instruction, if available
+	StackHeight *int `json:"stackHeight"`
+}
+
+// TransactionMeta is the status metadata of a confirmed transaction
+type TransactionMeta struct {
+	// Err is the error with which the transaction failed. nil if it succeeded.
+	Err *TransactionError `json:"err"`
+
+	// Fee is the fee in lamports charged for the transaction
+	Fee uint64 `json:"fee"`
+
+	// PreBalances are the lamport balances of each account before the transaction was processed
+	PreBalances []uint64 `json:"preBalances"`
+
+	// PostBalances are the lamport balances of each account after the transaction was processed
+	PostBalances []uint64 `json:"postBalances"`
+
+	// PreTokenBalances are the token balances of token accounts before the transaction was processed
+	PreTokenBalances []TokenBalance `json:"preTokenBalances"`
+
+	// PostTokenBalances are the token balances of token accounts after the transaction was processed
+	PostTokenBalances []TokenBalance `json:"postTokenBalances"`
+
+	// InnerInstructions are the instructions invoked by programs during the transaction
+	InnerInstructions []InnerInstructions `json:"innerInstructions"`
+
+	// LogMessages are the messages logged during the transaction.
+	// nil if log message recording was not enabled.
+	LogMessages []string `json:"logMessages"`
+
+	// LoadedAddresses are the accounts loaded from address lookup tables
+	LoadedAddresses *LoadedAddresses `json:"loadedAddresses"`
+
+	// ComputeUnitsConsumed is the number of compute units consumed by the transaction, if available
+	ComputeUnitsConsumed *uint64 `json:"computeUnitsConsumed"`
+
+	// ReturnData is the most recent return data set by a program during the transaction, if any
+	ReturnData *ReturnData `json:"returnData"`
+}
+
+// ReturnData is data returned by a program with the set_return_data syscall
+type ReturnData struct {
+	// ProgramID is the base58 encoded ID of the program that set the data
+	ProgramID string
+
+	// Data is the data returned
+	Data []byte
+}
+
+// UnmarshalJSON implements json.Unmarshaler for ReturnData,
+// which has its data given as a [data, encoding] pair
+func (r *ReturnData) UnmarshalJSON(data []byte) error {
+	returnData := new(
+		struct {
+			ProgramID string   `json:"programId"`
+			Data      []string `json:"data"`
+		},
+	)
+	if err := json.Unmarshal(data, returnData); err != nil {
+		return err
+	}
+	r.ProgramID = returnData.ProgramID
+	var err error
+	r.Data, err = AccountInfoEncodedData{Data: returnData.Data}.DecodeData()
+	if err != nil {
+		return fmt.Errorf("error decoding return data: %w", err)
+	}
+	return nil
+}
+
+// TokenBalance is the balance of a token account
+type TokenBalance struct {
+	// AccountIndex is the index of the token account in the transaction's accounts
+	AccountIndex int `json:"accountIndex"`
+
+	// Mint is the base58 encoded mint of the token
+	Mint string `json:"mint"`
+
+	// Owner is the base58 encoded owner of the token account, if available
+	Owner string `json:"owner"`
+
+	// ProgramID is the base58 encoded token program that owns the account, if available
+	ProgramID string `json:"programId"`
+
+	UITokenAmount TokenAmount `json:"uiTokenAmount"`
+}
+
+// TokenAmount is an amount of tokens
+type TokenAmount struct {
+	// Amount is the raw amount of tokens as a base 10 string
+	Amount string `json:"amount"`
+
+	// Decimals is the number of decimals configured for the token's mint
+	Decimals uint8 `json:"decimals"`
+
+	// UIAmountString is the amount of tokens as a string, accounting for decimals
+	UIAmountString string `json:"uiAmountString"`
+}
+
+// InnerInstructions are the instructions invoked by programs during the
+// processing of the transaction instruction at Index
+type InnerInstructions struct {
+	// Index is the index of the transaction instruction
+	Index int
+
+	// Instructions are the inner instructions, set unless JSONParsedEncoding was requested
+	Instructions []CompiledInstructionJSON
+
+	// ParsedInstructions are the inner instructions, set if JSONParsedEncoding was requested
+	ParsedInstructions []ParsedInstructionJSON
+}
+
+// UnmarshalJSON implements json.Unmarshaler for InnerInstructions, which
+// holds compiled or parsed instructions depending on the requested Encoding
+func (i *InnerInstructions) UnmarshalJSON(data []byte) error {
+	innerInstructions := new(
+		struct {
+			Index        int               `json:"index"`
+			Instructions []json.RawMessage `json:"instructions"`
+		},
+	)
+	if err := json.Unmarshal(data, innerInstructions); err != nil {
+		return err
+	}
+	i.Index = innerInstructions.Index
+	for _, instructionData := range innerInstructions.Instructions {
+		// compiled instructions reference the program by index
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(instructionData, &fields); err != nil {
+			return err
+		}
+		if _, found := fields["programIdIndex"]; found {
+			var instruction CompiledInstructionJSON
+			if err := json.Unmarshal(instructionData, &instruction); err != nil {
+				return err
+			}
+			i.Instructions = append(i.Instructions, instruction)
+			continue
+		}
+		var instruction ParsedInstructionJSON
+		if err := json.Unmarshal(instructionData, &instruction); err != nil {
+			return err
+		}
+		i.ParsedInstructions = append(i.ParsedInstructions, instruction)
+	}
+	return nil
+}
+
+// LoadedAddresses are the base58 encoded addresses loaded from address lookup tables
+type LoadedAddresses struct {
+	Writable []string `json:"writable"`
+	Readonly []string `json:"readonly"`
+}
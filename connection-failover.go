@@ -0,0 +1,678 @@
+package solana
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ensure FailoverConnection implements Connection
+var _ Connection = &FailoverConnection{}
+
+const (
+	// DefaultFailoverHealthCheckInterval is the default interval
+	// at which a FailoverConnection checks the health of its endpoints
+	DefaultFailoverHealthCheckInterval = 10 * time.Second
+
+	// DefaultFailoverHealthCheckTimeout is the default time
+	// allowed for the health check of each endpoint
+	DefaultFailoverHealthCheckTimeout = 5 * time.Second
+
+	// DefaultFailoverMaxSlotLag is the default number of slots that an endpoint may
+	// fall behind the most advanced endpoint before it is considered unhealthy
+	DefaultFailoverMaxSlotLag = 50
+)
+
+// FailoverStrategy determines the order in which a FailoverConnection attempts its healthy endpoints
+type FailoverStrategy string
+
+const (
+	// RoundRobinFailoverStrategy spreads calls across healthy endpoints in turn
+	RoundRobinFailoverStrategy FailoverStrategy = "roundRobin"
+
+	// PrimaryFallbackFailoverStrategy calls the first healthy endpoint in the order given,
+	// so that later endpoints are only called if earlier endpoints are unhealthy or fail
+	PrimaryFallbackFailoverStrategy FailoverStrategy = "primaryFallback"
+
+	// LowestLatencyFailoverStrategy calls the healthy endpoint with the
+	// lowest latency, as measured by the most recent health checks
+	LowestLatencyFailoverStrategy FailoverStrategy = "lowestLatency"
+)
+
+// FailoverConnection is an implementation of the solana.Connection interface that
+// routes each call to one of several Connections, typically JSONRPCConnections
+// to different rpc providers, according to a FailoverStrategy.
+//
+// The health of each endpoint is checked periodically with getHealth and getSlot.
+// An endpoint is unhealthy if getHealth fails, or if its processed slot is more than
+// maxSlotLag slots behind that of the most advanced endpoint. Unhealthy endpoints
+// are not called until a later health check finds them healthy again, unless
+// no endpoint is healthy, in which case all endpoints are attempted.
+//
+// If a call fails because of the endpoint, e.g. with a transport error or because
+// the node is unhealthy or does not yet have the requested data, then the call is
+// attempted on the next endpoint. Errors with which any node would respond, such as
+// invalid params, and errors that occur locally, such as failing to encode a transaction
+// or to parse a response, are returned without failing over.
+//
+// SendTransaction is fanned out to all healthy endpoints, and succeeds if any of them succeeds.
+//
+// Close the FailoverConnection to stop its health checks.
+type FailoverConnection struct {
+	config *failoverConnectionConfig
+
+	// ctx is cancelled when the connection is closed
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// mu guards the following, as well as the state of each endpoint
+	mu        sync.Mutex
+	endpoints []*failoverEndpoint
+	next      int
+}
+
+// failoverEndpoint is an endpoint of a FailoverConnection
+// and its status as of its last health check
+type failoverEndpoint struct {
+	connection Connection
+	healthy    bool
+	checked    bool
+	slot       uint64
+	latency    time.Duration
+	err        error
+}
+
+// FailoverEndpointStatus is the status of an endpoint of a FailoverConnection
+type FailoverEndpointStatus struct {
+	// Connection is the connection to the endpoint
+	Connection Connection
+
+	// Healthy is whether calls are routed to the endpoint
+	Healthy bool
+
+	// Slot is the processed slot of the endpoint as of its last successful health check
+	Slot uint64
+
+	// Latency is the latency of the endpoint as measured by its health checks
+	Latency time.Duration
+
+	// Err is the error with which the endpoint was found to be unhealthy, if it is unhealthy
+	Err error
+}
+
+// failoverConnectionConfig is the configuration for a FailoverConnection
+type failoverConnectionConfig struct {
+	strategy            FailoverStrategy
+	healthCheckInterval time.Duration
+	healthCheckTimeout  time.Duration
+	maxSlotLag          uint64
+}
+
+// FailoverConnectionOption makes a change to the failoverConnectionConfig
+type FailoverConnectionOption interface {
+	apply(*failoverConnectionConfig)
+}
+
+type failoverConnectionOptionFunc func(*failoverConnectionConfig)
+
+func (fn failoverConnectionOptionFunc) apply(cfg *failoverConnectionConfig) {
+	fn(cfg)
+}
+
+// WithFailoverStrategy sets the FailoverStrategy of the FailoverConnection
+func WithFailoverStrategy(s FailoverStrategy) FailoverConnectionOption {
+	return failoverConnectionOptionFunc(func(config *failoverConnectionConfig) {
+		config.strategy = s
+	})
+}
+
+// WithFailoverHealthCheckInterval sets the interval at which the FailoverConnection
+// checks the health of its endpoints. An interval of 0 disables periodic health checks,
+// in which case health is only checked by calling CheckHealth.
+func WithFailoverHealthCheckInterval(d time.Duration) FailoverConnectionOption {
+	return failoverConnectionOptionFunc(func(config *failoverConnectionConfig) {
+		config.healthCheckInterval = d
+	})
+}
+
+// WithFailoverHealthCheckTimeout sets the time allowed for the health check of each endpoint
+func WithFailoverHealthCheckTimeout(d time.Duration) FailoverConnectionOption {
+	return failoverConnectionOptionFunc(func(config *failoverConnectionConfig) {
+		config.healthCheckTimeout = d
+	})
+}
+
+// WithFailoverMaxSlotLag sets the number of slots that an endpoint may fall
+// behind the most advanced endpoint before it is considered unhealthy
+func WithFailoverMaxSlotLag(n uint64) FailoverConnectionOption {
+	return failoverConnectionOptionFunc(func(config *failoverConnectionConfig) {
+		config.maxSlotLag = n
+	})
+}
+
+// NewFailoverConnection returns a new and configured FailoverConnection that routes calls
+// to the given connections, which should all be configured for the same network.
+// Endpoints are considered healthy until they are first checked.
+//
+// The default returned FailoverConnection is configured with:
+//  - strategy: RoundRobinFailoverStrategy
+//  - healthCheckInterval: DefaultFailoverHealthCheckInterval
+//  - healthCheckTimeout: DefaultFailoverHealthCheckTimeout
+//  - maxSlotLag: DefaultFailoverMaxSlotLag
+//
+// The passed opts are used to override these default values and configure the
+// returned FailoverConnection as desired.
+func NewFailoverConnection(connections []Connection, opts ...FailoverConnectionOption) (*FailoverConnection, error) {
+	if len(connections) == 0 {
+		return nil, ErrNoConnections
+	}
+
+	// prepare default configuration
+	config := &failoverConnectionConfig{
+		strategy:            RoundRobinFailoverStrategy,
+		healthCheckInterval: DefaultFailoverHealthCheckInterval,
+		healthCheckTimeout:  DefaultFailoverHealthCheckTimeout,
+		maxSlotLag:          DefaultFailoverMaxSlotLag,
+	}
+
+	// apply any provided options
+	for _, opt := range opts {
+		opt.apply(config)
+	}
+
+	// validate configuration
+	switch config.strategy {
+	case RoundRobinFailoverStrategy, PrimaryFallbackFailoverStrategy, LowestLatencyFailoverStrategy:
+	default:
+		return nil, fmt.Errorf("invalid failover strategy '%s'", config.strategy)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	f := &FailoverConnection{
+		config:    config,
+		ctx:       ctx,
+		cancel:    cancel,
+		endpoints: make([]*failoverEndpoint, len(connections)),
+	}
+	for i, connection := range connections {
+		f.endpoints[i] = &failoverEndpoint{
+			connection: connection,
+			healthy:    true,
+		}
+	}
+	if config.healthCheckInterval > 0 {
+		go f.checkHealthPeriodically()
+	}
+
+	return f, nil
+}
+
+// Close stops the health checks of the connection
+func (f *FailoverConnection) Close() error {
+	f.cancel()
+	return nil
+}
+
+// EndpointStatuses returns the status of each endpoint, in the order given to NewFailoverConnection
+func (f *FailoverConnection) EndpointStatuses() []FailoverEndpointStatus {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	statuses := make([]FailoverEndpointStatus, len(f.endpoints))
+	for i, endpoint := range f.endpoints {
+		statuses[i] = FailoverEndpointStatus{
+			Connection: endpoint.connection,
+			Healthy:    endpoint.healthy,
+			Slot:       endpoint.slot,
+			Latency:    endpoint.latency,
+			Err:        endpoint.err,
+		}
+	}
+	return statuses
+}
+
+// CheckHealth checks the health of all endpoints, and returns
+// ErrNoHealthyEndpoints if none of them are healthy
+func (f *FailoverConnection) CheckHealth(ctx context.Context) error {
+	// check each endpoint
+	type result struct {
+		slot    uint64
+		latency time.Duration
+		err     error
+	}
+	results := make([]result, len(f.endpoints))
+	var wg sync.WaitGroup
+	for i, endpoint := range f.endpoints {
+		wg.Add(1)
+		go func(i int, connection Connection) {
+			defer wg.Done()
+			results[i].slot, results[i].latency, results[i].err = f.checkEndpointHealth(ctx, connection)
+		}(i, endpoint.connection)
+	}
+	wg.Wait()
+
+	// determine most advanced slot
+	var maxSlot uint64
+	for _, r := range results {
+		if r.err == nil && r.slot > maxSlot {
+			maxSlot = r.slot
+		}
+	}
+
+	// update endpoints, ejecting those that have fallen behind
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	healthy := false
+	for i, endpoint := range f.endpoints {
+		r := results[i]
+		if r.err == nil && maxSlot-r.slot > f.config.maxSlotLag {
+			r.err = fmt.Errorf("%d slots behind: %w", maxSlot-r.slot, ErrEndpointBehind)
+		}
+		if r.err != nil {
+			endpoint.healthy, endpoint.err = false, r.err
+			continue
+		}
+		endpoint.healthy, endpoint.err = true, nil
+		endpoint.slot = r.slot
+		if endpoint.checked {
+			// smooth latency so that a single slow check does not reorder endpoints
+			endpoint.latency = (endpoint.latency*4 + r.latency) / 5
+		} else {
+			endpoint.latency = r.latency
+		}
+		endpoint.checked = true
+		healthy = true
+	}
+	if !healthy {
+		return ErrNoHealthyEndpoints
+	}
+
+	return nil
+}
+
+// checkEndpointHealth checks the health of the given connection,
+// returning its processed slot and the latency of getting it
+func (f *FailoverConnection) checkEndpointHealth(ctx context.Context, connection Connection) (uint64, time.Duration, error) {
+	if f.config.healthCheckTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, f.config.healthCheckTimeout)
+		defer cancel()
+	}
+
+	if err := connection.GetHealth(ctx); err != nil {
+		return 0, 0, fmt.Errorf("error getting health: %w", err)
+	}
+
+	start := time.Now()
+	getSlotResponse, err := connection.GetSlot(ctx, GetSlotRequest{CommitmentLevel: ProcessedCommitmentLevel})
+	if err != nil {
+		return 0, 0, fmt.Errorf("error getting slot: %w", err)
+	}
+
+	return getSlotResponse.Slot, time.Since(start), nil
+}
+
+// checkHealthPeriodically checks the health of all endpoints
+// at the configured interval until the connection is closed
+func (f *FailoverConnection) checkHealthPeriodically() {
+	ticker := time.NewTicker(f.config.healthCheckInterval)
+	defer ticker.Stop()
+	for {
+		_ = f.CheckHealth(f.ctx)
+		select {
+		case <-f.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// orderedEndpoints returns the healthy endpoints in the order in which they should be
+// attempted according to the strategy, or all endpoints if none are healthy
+func (f *FailoverConnection) orderedEndpoints() []*failoverEndpoint {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	endpoints := make([]*failoverEndpoint, 0, len(f.endpoints))
+	for _, endpoint := range f.endpoints {
+		if endpoint.healthy {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	if len(endpoints) == 0 {
+		// attempt all endpoints in case any have recovered
+		endpoints = append(endpoints, f.endpoints...)
+	}
+
+	switch f.config.strategy {
+	case RoundRobinFailoverStrategy:
+		start := f.next % len(endpoints)
+		f.next++
+		endpoints = append(endpoints[start:], endpoints[:start]...)
+
+	case LowestLatencyFailoverStrategy:
+		sort.SliceStable(endpoints, func(i, j int) bool {
+			return endpoints[i].latency < endpoints[j].latency
+		})
+	}
+
+	return endpoints
+}
+
+// markUnhealthy marks the given endpoint as unhealthy until its next health check
+func (f *FailoverConnection) markUnhealthy(endpoint *failoverEndpoint, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	endpoint.healthy, endpoint.err = false, err
+}
+
+// call performs the given call on the ordered endpoints until
+// it succeeds or fails with an error that is not failed over
+func (f *FailoverConnection) call(ctx context.Context, fn func(connection Connection) error) error {
+	var err error
+	for _, endpoint := range f.orderedEndpoints() {
+		if err = fn(endpoint.connection); err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+		failover, unhealthy := classifyFailoverError(err)
+		if unhealthy {
+			f.markUnhealthy(endpoint, err)
+		}
+		if !failover {
+			return err
+		}
+	}
+	return err
+}
+
+// classifyFailoverError returns whether a call that failed with the given error should be
+// attempted on another endpoint, and whether the endpoint should be considered unhealthy
+func classifyFailoverError(err error) (failover bool, unhealthy bool) {
+	var nodeUnhealthyError *NodeUnhealthyError
+	var preflightFailureError *PreflightFailureError
+	var rpcError *RPCError
+	var httpError *HTTPError
+	var netError net.Error
+	switch {
+	case errors.As(err, &nodeUnhealthyError):
+		return true, true
+
+	case errors.As(err, &preflightFailureError):
+		return false, false
+
+	case errors.As(err, &rpcError):
+		switch rpcError.Code {
+		case BlockCleanedUpRPCErrorCode,
+			BlockNotAvailableRPCErrorCode,
+			NoSnapshotRPCErrorCode,
+			LongTermStorageSlotSkippedRPCErrorCode,
+			TransactionHistoryNotAvailableRPCErrorCode,
+			BlockStatusNotAvailableYetRPCErrorCode,
+			MinContextSlotNotReachedRPCErrorCode:
+			// another node may have the requested data
+			return true, false
+		}
+		return false, false
+
+	case errors.As(err, &httpError), errors.Is(err, ErrConnectionRefused), errors.As(err, &netError):
+		// the endpoint could not be reached or responded with an http error
+		return true, true
+
+	default:
+		// e.g. the request could not be encoded or the response could not be parsed,
+		// which would fail on another endpoint too
+		return false, false
+	}
+}
+
+func (f *FailoverConnection) Network() Network {
+	return f.endpoints[0].connection.Network()
+}
+
+func (f *FailoverConnection) Commitment() CommitmentLevel {
+	return f.endpoints[0].connection.Commitment()
+}
+
+func (f *FailoverConnection) GetAccountInfo(ctx context.Context, request GetAccountInfoRequest) (*GetAccountInfoResponse, error) {
+	var response *GetAccountInfoResponse
+	err := f.call(ctx, func(connection Connection) (err error) {
+		response, err = connection.GetAccountInfo(ctx, request)
+		return err
+	})
+	return response, err
+}
+
+func (f *FailoverConnection) GetMultipleAccounts(ctx context.Context, request GetMultipleAccountsRequest) (*GetMultipleAccountsResponse, error) {
+	var response *GetMultipleAccountsResponse
+	err := f.call(ctx, func(connection Connection) (err error) {
+		response, err = connection.GetMultipleAccounts(ctx, request)
+		return err
+	})
+	return response, err
+}
+
+func (f *FailoverConnection) GetProgramAccounts(ctx context.Context, request GetProgramAccountsRequest) (*GetProgramAccountsResponse, error) {
+	var response *GetProgramAccountsResponse
+	err := f.call(ctx, func(connection Connection) (err error) {
+		response, err = connection.GetProgramAccounts(ctx, request)
+		return err
+	})
+	return response, err
+}
+
+func (f *FailoverConnection) GetBalance(ctx context.Context, request GetBalanceRequest) (*GetBalanceResponse, error) {
+	var response *GetBalanceResponse
+	err := f.call(ctx, func(connection Connection) (err error) {
+		response, err = connection.GetBalance(ctx, request)
+		return err
+	})
+	return response, err
+}
+
+// Deprecated: getRecentBlockhash has been removed from current validators.
+// Use GetLatestBlockhash, and GetFeeCalculator or GetFeeForMessage to determine fees.
+func (f *FailoverConnection) GetRecentBlockHash(ctx context.Context, request GetRecentBlockHashRequest) (*GetRecentBlockHashResponse, error) {
+	var response *GetRecentBlockHashResponse
+	err := f.call(ctx, func(connection Connection) (err error) {
+		response, err = connection.GetRecentBlockHash(ctx, request)
+		return err
+	})
+	return response, err
+}
+
+func (f *FailoverConnection) GetLatestBlockhash(ctx context.Context, request GetLatestBlockhashRequest) (*GetLatestBlockhashResponse, error) {
+	var response *GetLatestBlockhashResponse
+	err := f.call(ctx, func(connection Connection) (err error) {
+		response, err = connection.GetLatestBlockhash(ctx, request)
+		return err
+	})
+	return response, err
+}
+
+func (f *FailoverConnection) IsBlockhashValid(ctx context.Context, request IsBlockhashValidRequest) (*IsBlockhashValidResponse, error) {
+	var response *IsBlockhashValidResponse
+	err := f.call(ctx, func(connection Connection) (err error) {
+		response, err = connection.IsBlockhashValid(ctx, request)
+		return err
+	})
+	return response, err
+}
+
+func (f *FailoverConnection) GetFeeForMessage(ctx context.Context, request GetFeeForMessageRequest) (*GetFeeForMessageResponse, error) {
+	var response *GetFeeForMessageResponse
+	err := f.call(ctx, func(connection Connection) (err error) {
+		response, err = connection.GetFeeForMessage(ctx, request)
+		return err
+	})
+	return response, err
+}
+
+func (f *FailoverConnection) GetMinimumBalanceForRentExemption(ctx context.Context, request GetMinimumBalanceForRentExemptionRequest) (*GetMinimumBalanceForRentExemptionResponse, error) {
+	var response *GetMinimumBalanceForRentExemptionResponse
+	err := f.call(ctx, func(connection Connection) (err error) {
+		response, err = connection.GetMinimumBalanceForRentExemption(ctx, request)
+		return err
+	})
+	return response, err
+}
+
+func (f *FailoverConnection) GetSignaturesForAddress(ctx context.Context, request GetSignaturesForAddressRequest) (*GetSignaturesForAddressResponse, error) {
+	var response *GetSignaturesForAddressResponse
+	err := f.call(ctx, func(connection Connection) (err error) {
+		response, err = connection.GetSignaturesForAddress(ctx, request)
+		return err
+	})
+	return response, err
+}
+
+func (f *FailoverConnection) GetTransaction(ctx context.Context, request GetTransactionRequest) (*GetTransactionResponse, error) {
+	var response *GetTransactionResponse
+	err := f.call(ctx, func(connection Connection) (err error) {
+		response, err = connection.GetTransaction(ctx, request)
+		return err
+	})
+	return response, err
+}
+
+// GetHealth returns nil if any endpoint is healthy
+func (f *FailoverConnection) GetHealth(ctx context.Context) error {
+	return f.call(ctx, func(connection Connection) error {
+		return connection.GetHealth(ctx)
+	})
+}
+
+func (f *FailoverConnection) GetSlot(ctx context.Context, request GetSlotRequest) (*GetSlotResponse, error) {
+	var response *GetSlotResponse
+	err := f.call(ctx, func(connection Connection) (err error) {
+		response, err = connection.GetSlot(ctx, request)
+		return err
+	})
+	return response, err
+}
+
+func (f *FailoverConnection) GetBlockHeight(ctx context.Context, request GetBlockHeightRequest) (*GetBlockHeightResponse, error) {
+	var response *GetBlockHeightResponse
+	err := f.call(ctx, func(connection Connection) (err error) {
+		response, err = connection.GetBlockHeight(ctx, request)
+		return err
+	})
+	return response, err
+}
+
+func (f *FailoverConnection) GetBlock(ctx context.Context, request GetBlockRequest) (*GetBlockResponse, error) {
+	var response *GetBlockResponse
+	err := f.call(ctx, func(connection Connection) (err error) {
+		response, err = connection.GetBlock(ctx, request)
+		return err
+	})
+	return response, err
+}
+
+func (f *FailoverConnection) GetBlocks(ctx context.Context, request GetBlocksRequest) (*GetBlocksResponse, error) {
+	var response *GetBlocksResponse
+	err := f.call(ctx, func(connection Connection) (err error) {
+		response, err = connection.GetBlocks(ctx, request)
+		return err
+	})
+	return response, err
+}
+
+func (f *FailoverConnection) GetBlocksWithLimit(ctx context.Context, request GetBlocksWithLimitRequest) (*GetBlocksWithLimitResponse, error) {
+	var response *GetBlocksWithLimitResponse
+	err := f.call(ctx, func(connection Connection) (err error) {
+		response, err = connection.GetBlocksWithLimit(ctx, request)
+		return err
+	})
+	return response, err
+}
+
+func (f *FailoverConnection) GetBlockTime(ctx context.Context, request GetBlockTimeRequest) (*GetBlockTimeResponse, error) {
+	var response *GetBlockTimeResponse
+	err := f.call(ctx, func(connection Connection) (err error) {
+		response, err = connection.GetBlockTime(ctx, request)
+		return err
+	})
+	return response, err
+}
+
+func (f *FailoverConnection) GetFirstAvailableBlock(ctx context.Context) (*GetFirstAvailableBlockResponse, error) {
+	var response *GetFirstAvailableBlockResponse
+	err := f.call(ctx, func(connection Connection) (err error) {
+		response, err = connection.GetFirstAvailableBlock(ctx)
+		return err
+	})
+	return response, err
+}
+
+func (f *FailoverConnection) MinimumLedgerSlot(ctx context.Context) (*MinimumLedgerSlotResponse, error) {
+	var response *MinimumLedgerSlotResponse
+	err := f.call(ctx, func(connection Connection) (err error) {
+		response, err = connection.MinimumLedgerSlot(ctx)
+		return err
+	})
+	return response, err
+}
+
+func (f *FailoverConnection) GetSignatureStatuses(ctx context.Context, request GetSignatureStatusesRequest) (*GetSignatureStatusesResponse, error) {
+	var response *GetSignatureStatusesResponse
+	err := f.call(ctx, func(connection Connection) (err error) {
+		response, err = connection.GetSignatureStatuses(ctx, request)
+		return err
+	})
+	return response, err
+}
+
+func (f *FailoverConnection) SimulateTransaction(ctx context.Context, request SimulateTransactionRequest) (*SimulateTransactionResponse, error) {
+	var response *SimulateTransactionResponse
+	err := f.call(ctx, func(connection Connection) (err error) {
+		response, err = connection.SimulateTransaction(ctx, request)
+		return err
+	})
+	return response, err
+}
+
+// SendTransaction sends the transaction to all healthy endpoints concurrently so that it reaches
+// the cluster even if some endpoints fail to forward it. The first successful response is returned.
+// If all endpoints fail then an error with which a node responded is preferred over a transport error.
+func (f *FailoverConnection) SendTransaction(ctx context.Context, request SendTransactionRequest) (*SendTransactionResponse, error) {
+	// send to all endpoints
+	type result struct {
+		response *SendTransactionResponse
+		err      error
+	}
+	endpoints := f.orderedEndpoints()
+	results := make(chan result, len(endpoints))
+	for _, endpoint := range endpoints {
+		go func(endpoint *failoverEndpoint) {
+			response, err := endpoint.connection.SendTransaction(ctx, request)
+			if err != nil && ctx.Err() == nil {
+				if _, unhealthy := classifyFailoverError(err); unhealthy {
+					f.markUnhealthy(endpoint, err)
+				}
+			}
+			results <- result{response: response, err: err}
+		}(endpoint)
+	}
+
+	// return first success, or the most relevant error
+	var err error
+	var errFailover bool
+	for range endpoints {
+		r := <-results
+		if r.err == nil {
+			return r.response, nil
+		}
+		failover, _ := classifyFailoverError(r.err)
+		if err == nil || (errFailover && !failover) {
+			err, errFailover = r.err, failover
+		}
+	}
+
+	return nil, err
+}
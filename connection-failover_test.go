@@ -0,0 +1,355 @@
+package solana
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// failoverTestConnection is a Connection that serves the methods used in
+// FailoverConnection tests, identifying itself by index in its responses
+type failoverTestConnection struct {
+	Connection
+	index     uint64
+	healthErr error
+	slot      uint64
+	slotDelay time.Duration
+	err       error
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (c *failoverTestConnection) GetHealth(_ context.Context) error {
+	return c.healthErr
+}
+
+func (c *failoverTestConnection) GetSlot(_ context.Context, request GetSlotRequest) (*GetSlotResponse, error) {
+	if request.CommitmentLevel != ProcessedCommitmentLevel {
+		return nil, errors.New("unexpected commitment level")
+	}
+	time.Sleep(c.slotDelay)
+	return &GetSlotResponse{Slot: c.slot}, nil
+}
+
+func (c *failoverTestConnection) GetBalance(_ context.Context, _ GetBalanceRequest) (*GetBalanceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &GetBalanceResponse{Value: c.index}, nil
+}
+
+func (c *failoverTestConnection) SendTransaction(_ context.Context, _ SendTransactionRequest) (*SendTransactionResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &SendTransactionResponse{TransactionID: "sig1"}, nil
+}
+
+func newFailoverTestConnections(n int) ([]*failoverTestConnection, []Connection) {
+	testConnections := make([]*failoverTestConnection, n)
+	connections := make([]Connection, n)
+	for i := range testConnections {
+		testConnections[i] = &failoverTestConnection{index: uint64(i), slot: 1000}
+		connections[i] = testConnections[i]
+	}
+	return testConnections, connections
+}
+
+func TestNewFailoverConnection(t *testing.T) {
+	_, err := NewFailoverConnection(nil)
+	require.ErrorIs(t, err, ErrNoConnections)
+
+	_, connections := newFailoverTestConnections(1)
+	_, err = NewFailoverConnection(connections, WithFailoverStrategy("random"))
+	require.Error(t, err)
+
+	// health is checked periodically until closed
+	testConnections, connections := newFailoverTestConnections(2)
+	testConnections[1].healthErr = &NodeUnhealthyError{Message: "unhealthy"}
+	f, err := NewFailoverConnection(connections, WithFailoverHealthCheckInterval(5*time.Millisecond))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, f.Close())
+	}()
+	require.Eventually(
+		t,
+		func() bool {
+			return !f.EndpointStatuses()[1].Healthy
+		},
+		time.Second,
+		5*time.Millisecond,
+	)
+	require.True(t, f.EndpointStatuses()[0].Healthy)
+}
+
+func TestFailoverConnection_Strategies(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy FailoverStrategy
+		want     []uint64
+	}{
+		{
+			name:     "round robin",
+			strategy: RoundRobinFailoverStrategy,
+			want:     []uint64{0, 1, 2, 0},
+		},
+		{
+			name:     "primary fallback",
+			strategy: PrimaryFallbackFailoverStrategy,
+			want:     []uint64{0, 0, 0, 0},
+		},
+		{
+			name:     "lowest latency",
+			strategy: LowestLatencyFailoverStrategy,
+			want:     []uint64{1, 1, 1, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testConnections, connections := newFailoverTestConnections(3)
+			testConnections[0].slotDelay = 40 * time.Millisecond
+			testConnections[2].slotDelay = 20 * time.Millisecond
+			f, err := NewFailoverConnection(
+				connections,
+				WithFailoverStrategy(tt.strategy),
+				WithFailoverHealthCheckInterval(0),
+			)
+			require.NoError(t, err)
+			require.NoError(t, f.CheckHealth(context.Background()))
+
+			got := make([]uint64, len(tt.want))
+			for i := range got {
+				response, err := f.GetBalance(context.Background(), GetBalanceRequest{})
+				require.NoError(t, err)
+				got[i] = response.Value
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFailoverConnection_Failover(t *testing.T) {
+	transportErr := fmt.Errorf("error performing http request: %w", ErrConnectionRefused)
+	invalidParamsErr := &RPCError{Code: -32602, Message: "invalid params"}
+
+	tests := []struct {
+		name          string
+		errs          []error
+		wantValue     uint64
+		wantErr       error
+		wantCalls     []int
+		wantUnhealthy []bool
+	}{
+		{
+			name:          "transport error fails over and ejects endpoint",
+			errs:          []error{transportErr, nil},
+			wantValue:     1,
+			wantCalls:     []int{1, 1},
+			wantUnhealthy: []bool{true, false},
+		},
+		{
+			name:          "node unhealthy fails over and ejects endpoint",
+			errs:          []error{&NodeUnhealthyError{Message: "unhealthy"}, nil},
+			wantValue:     1,
+			wantCalls:     []int{1, 1},
+			wantUnhealthy: []bool{true, false},
+		},
+		{
+			name:          "min context slot not reached fails over",
+			errs:          []error{&RPCError{Code: MinContextSlotNotReachedRPCErrorCode}, nil},
+			wantValue:     1,
+			wantCalls:     []int{1, 1},
+			wantUnhealthy: []bool{false, false},
+		},
+		{
+			name:          "invalid params not failed over",
+			errs:          []error{invalidParamsErr, nil},
+			wantErr:       invalidParamsErr,
+			wantCalls:     []int{1, 0},
+			wantUnhealthy: []bool{false, false},
+		},
+		{
+			name:          "http error fails over and ejects endpoint",
+			errs:          []error{&HTTPError{Code: http.StatusBadGateway}, nil},
+			wantValue:     1,
+			wantCalls:     []int{1, 1},
+			wantUnhealthy: []bool{true, false},
+		},
+		{
+			name:          "local error not failed over",
+			errs:          []error{fmt.Errorf("error marshalling to base64: %w", ErrTransactionTooLarge), nil},
+			wantErr:       ErrTransactionTooLarge,
+			wantCalls:     []int{1, 0},
+			wantUnhealthy: []bool{false, false},
+		},
+		{
+			name:          "all endpoints fail",
+			errs:          []error{transportErr, transportErr},
+			wantErr:       transportErr,
+			wantCalls:     []int{1, 1},
+			wantUnhealthy: []bool{true, true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testConnections, connections := newFailoverTestConnections(len(tt.errs))
+			for i, err := range tt.errs {
+				testConnections[i].err = err
+			}
+			f, err := NewFailoverConnection(
+				connections,
+				WithFailoverStrategy(PrimaryFallbackFailoverStrategy),
+				WithFailoverHealthCheckInterval(0),
+			)
+			require.NoError(t, err)
+
+			response, err := f.GetBalance(context.Background(), GetBalanceRequest{})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantValue, response.Value)
+			}
+			for i, status := range f.EndpointStatuses() {
+				require.Equal(t, tt.wantCalls[i], testConnections[i].calls, "calls of endpoint %d", i)
+				require.Equal(t, tt.wantUnhealthy[i], !status.Healthy, "health of endpoint %d", i)
+			}
+		})
+	}
+}
+
+func TestFailoverConnection_CheckHealth(t *testing.T) {
+	testConnections, connections := newFailoverTestConnections(4)
+	testConnections[1].slot = 990
+	testConnections[2].slot = 900
+	testConnections[3].healthErr = &NodeUnhealthyError{Message: "unhealthy"}
+	f, err := NewFailoverConnection(
+		connections,
+		WithFailoverStrategy(PrimaryFallbackFailoverStrategy),
+		WithFailoverHealthCheckInterval(0),
+		WithFailoverMaxSlotLag(50),
+	)
+	require.NoError(t, err)
+	require.NoError(t, f.CheckHealth(context.Background()))
+
+	// endpoints that are behind or unhealthy are ejected
+	statuses := f.EndpointStatuses()
+	require.True(t, statuses[0].Healthy)
+	require.Equal(t, uint64(1000), statuses[0].Slot)
+	require.True(t, statuses[1].Healthy)
+	require.False(t, statuses[2].Healthy)
+	require.ErrorIs(t, statuses[2].Err, ErrEndpointBehind)
+	require.False(t, statuses[3].Healthy)
+	var nodeUnhealthyError *NodeUnhealthyError
+	require.ErrorAs(t, statuses[3].Err, &nodeUnhealthyError)
+
+	// ejected endpoints are not failed over to
+	testConnections[0].err = ErrConnectionRefused
+	testConnections[1].err = ErrConnectionRefused
+	_, err = f.GetBalance(context.Background(), GetBalanceRequest{})
+	require.Error(t, err)
+	require.Equal(t, 0, testConnections[2].calls)
+
+	// all endpoints are attempted if none are healthy
+	testConnections[0].healthErr = &NodeUnhealthyError{Message: "unhealthy"}
+	testConnections[1].healthErr = &NodeUnhealthyError{Message: "unhealthy"}
+	testConnections[2].healthErr = &NodeUnhealthyError{Message: "unhealthy"}
+	require.ErrorIs(t, f.CheckHealth(context.Background()), ErrNoHealthyEndpoints)
+	response, err := f.GetBalance(context.Background(), GetBalanceRequest{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), response.Value)
+
+	// recovered endpoints are restored
+	testConnections[0].healthErr = nil
+	testConnections[0].err = nil
+	require.NoError(t, f.CheckHealth(context.Background()))
+	require.True(t, f.EndpointStatuses()[0].Healthy)
+}
+
+func TestFailoverConnection_SendTransaction(t *testing.T) {
+	transportErr := fmt.Errorf("error performing http request: %w", ErrConnectionRefused)
+	preflightErr := &PreflightFailureError{Message: "preflight failure"}
+	localErr := fmt.Errorf("1300 bytes: %w", ErrTransactionTooLarge)
+
+	tests := []struct {
+		name          string
+		errs          []error
+		wantErr       error
+		wantCalls     []int
+		wantUnhealthy []bool
+	}{
+		{
+			name:          "sent to all healthy endpoints",
+			errs:          []error{nil, transportErr, nil},
+			wantCalls:     []int{1, 1, 0},
+			wantUnhealthy: []bool{false, true, true},
+		},
+		{
+			name:          "node error preferred",
+			errs:          []error{transportErr, preflightErr, nil},
+			wantErr:       preflightErr,
+			wantCalls:     []int{1, 1, 0},
+			wantUnhealthy: []bool{true, false, true},
+		},
+		{
+			name:          "local error does not eject endpoints",
+			errs:          []error{localErr, localErr, nil},
+			wantErr:       ErrTransactionTooLarge,
+			wantCalls:     []int{1, 1, 0},
+			wantUnhealthy: []bool{false, false, true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testConnections, connections := newFailoverTestConnections(len(tt.errs))
+			for i, err := range tt.errs {
+				testConnections[i].err = err
+			}
+			testConnections[2].healthErr = &NodeUnhealthyError{Message: "unhealthy"}
+			f, err := NewFailoverConnection(connections, WithFailoverHealthCheckInterval(0))
+			require.NoError(t, err)
+			require.NoError(t, f.CheckHealth(context.Background()))
+
+			response, err := f.SendTransaction(context.Background(), SendTransactionRequest{})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, &SendTransactionResponse{TransactionID: "sig1"}, response)
+			}
+
+			// wait for sends still in flight
+			require.Eventually(
+				t,
+				func() bool {
+					for i, testConnection := range testConnections {
+						testConnection.mu.Lock()
+						calls := testConnection.calls
+						testConnection.mu.Unlock()
+						if calls != tt.wantCalls[i] {
+							return false
+						}
+					}
+					return true
+				},
+				time.Second,
+				time.Millisecond,
+			)
+			for i, status := range f.EndpointStatuses() {
+				require.Equal(t, tt.wantUnhealthy[i], !status.Healthy)
+			}
+		})
+	}
+}
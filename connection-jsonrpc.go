@@ -2,8 +2,12 @@ package solana
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"github.com/BRBussy/solgo/internal/pkg/jsonrpc"
+	"io"
+	"net/http"
 )
 
 // ensure JSONRPCConnection implements Connection
@@ -20,6 +24,14 @@ type jsonrpcConnectionConfig struct {
 	network         Network
 	endpoint        string
 	commitmentLevel CommitmentLevel
+	retryPolicy     *RetryPolicy
+	rateLimiter     *RateLimiter
+	httpClient      *http.Client
+	customHeaders   map[string]string
+	interceptors    []JSONRPCInterceptor
+	recording       io.Writer
+	replay          *Cassette
+	replayMatcher   CassetteMatcher
 }
 
 // JSONRPCConnectionOption makes a change to the jsonrpcConnectionConfig
@@ -57,6 +69,68 @@ func WithEndpoint(e string) JSONRPCConnectionOption {
 	})
 }
 
+// WithRetryPolicy sets a RetryPolicy on the JSONRPCConnection
+// with which calls that fail with transient errors are retried
+func WithRetryPolicy(p RetryPolicy) JSONRPCConnectionOption {
+	return jsonrpcConnectionOptionFunc(func(config *jsonrpcConnectionConfig) {
+		config.retryPolicy = &p
+	})
+}
+
+// WithRateLimiter sets a RateLimiter on the JSONRPCConnection with which the rate of calls is limited.
+// The same RateLimiter can be set on multiple JSONRPCConnections to share its limits between them.
+func WithRateLimiter(l *RateLimiter) JSONRPCConnectionOption {
+	return jsonrpcConnectionOptionFunc(func(config *jsonrpcConnectionConfig) {
+		config.rateLimiter = l
+	})
+}
+
+// WithHTTPClient sets the http.Client with which the JSONRPCConnection
+// makes calls, e.g. to set a proxy, timeouts or tls options
+func WithHTTPClient(c *http.Client) JSONRPCConnectionOption {
+	return jsonrpcConnectionOptionFunc(func(config *jsonrpcConnectionConfig) {
+		config.httpClient = c
+	})
+}
+
+// WithCustomHeaders sets http headers that are sent with every call made by the JSONRPCConnection
+func WithCustomHeaders(h map[string]string) JSONRPCConnectionOption {
+	return jsonrpcConnectionOptionFunc(func(config *jsonrpcConnectionConfig) {
+		config.customHeaders = h
+	})
+}
+
+// WithInterceptors adds JSONRPCInterceptors through which each call made by the
+// JSONRPCConnection is passed. Interceptors are called in the order added, such
+// that the first interceptor is outermost. Results of calls are read in full before
+// being parsed so that interceptors see the Result of each response.
+func WithInterceptors(interceptors ...JSONRPCInterceptor) JSONRPCConnectionOption {
+	return jsonrpcConnectionOptionFunc(func(config *jsonrpcConnectionConfig) {
+		config.interceptors = append(config.interceptors, interceptors...)
+	})
+}
+
+// WithCassetteRecording records each call made by the JSONRPCConnection, and its responses, to
+// the given writer as a line of JSON. The recording can be read with ReadCassette and replayed
+// by a JSONRPCConnection configured WithCassetteReplay.
+func WithCassetteRecording(w io.Writer) JSONRPCConnectionOption {
+	return jsonrpcConnectionOptionFunc(func(config *jsonrpcConnectionConfig) {
+		config.recording = w
+	})
+}
+
+// WithCassetteReplay serves the calls made by the JSONRPCConnection from the given Cassette instead of
+// the endpoint. Each call is served by the first recorded call that matches it, according to the given
+// CassetteMatcher, that has not already been served. If the matcher is nil then calls are matched with
+// MatchMethodAndParams. Recorded errors are replayed as an HTTPError, ErrConnectionRefused or context error
+// if they were one. Calls that match no remaining recorded call fail with ErrUnmatchedCall.
+func WithCassetteReplay(c *Cassette, matcher CassetteMatcher) JSONRPCConnectionOption {
+	return jsonrpcConnectionOptionFunc(func(config *jsonrpcConnectionConfig) {
+		config.replay = c
+		config.replayMatcher = matcher
+	})
+}
+
 // NewJSONRPCConnection returns a new and configured JSONRPCConnection.
 //
 // The default returned JSONRPCConnection is configured with:
@@ -79,8 +153,38 @@ func NewJSONRPCConnection(opts ...JSONRPCConnectionOption) *JSONRPCConnection {
 		opt.apply(config)
 	}
 
+	// prepare json-rpc client options
+	var clientOpts jsonrpc.RPCClientOpts
+	if config.retryPolicy != nil {
+		clientOpts.RetryPolicy = config.retryPolicy.toJSONRPCRetryPolicy()
+	}
+	if config.rateLimiter != nil {
+		clientOpts.RateLimiter = config.rateLimiter.rateLimiter
+	}
+	clientOpts.HTTPClient = config.httpClient
+	clientOpts.CustomHeaders = config.customHeaders
+
+	// prepare json-rpc client
+	var jsonRPCClient jsonrpc.Client = jsonrpc.NewHTTPClientFromOpts(config.endpoint, clientOpts)
+	if config.replay != nil {
+		matcher := jsonrpc.MatchMethodAndParams()
+		if config.replayMatcher != nil {
+			matcher = config.replayMatcher.toJSONRPCMatcher()
+		}
+		jsonRPCClient = jsonrpc.NewReplayClient(config.replay.cassette, matcher)
+	} else if config.recording != nil {
+		jsonRPCClient = jsonrpc.NewRecordingClient(jsonRPCClient, config.recording)
+	}
+	if len(config.interceptors) > 0 {
+		interceptors := make([]jsonrpc.Interceptor, len(config.interceptors))
+		for i, interceptor := range config.interceptors {
+			interceptors[i] = interceptor.toJSONRPCInterceptor()
+		}
+		jsonRPCClient = jsonrpc.NewInterceptedClient(jsonRPCClient, interceptors...)
+	}
+
 	return &JSONRPCConnection{
-		jsonRPCClient: jsonrpc.NewHTTPClient(config.endpoint),
+		jsonRPCClient: jsonRPCClient,
 		config:        config,
 	}
 }
@@ -94,6 +198,25 @@ func (j *JSONRPCConnection) Commitment() CommitmentLevel {
 }
 
 func (j *JSONRPCConnection) GetAccountInfo(ctx context.Context, request GetAccountInfoRequest) (*GetAccountInfoResponse, error) {
+	// perform rpc call
+	rpcResponse, err := j.jsonRPCClient.CallParamArray(
+		ctx,
+		"getAccountInfo",
+		nil,
+		j.getAccountInfoParams(request)...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error performing getAccountInfo json-rpc call: %w", err)
+	}
+	if rpcResponse.Error != nil {
+		return nil, fmt.Errorf("error set on rpc response: %w", newRPCError(rpcResponse.Error))
+	}
+
+	return parseGetAccountInfoResponse(rpcResponse, request.Encoding)
+}
+
+// getAccountInfoParams returns the params of a getAccountInfo call for the given request
+func (j *JSONRPCConnection) getAccountInfoParams(request GetAccountInfoRequest) []interface{} {
 	// prepare configuration object
 	config := map[string]interface{}{
 		"commitment": j.Commitment(),
@@ -110,24 +233,14 @@ func (j *JSONRPCConnection) GetAccountInfo(ctx context.Context, request GetAccou
 		config["encoding"] = request.Encoding
 	}
 
-	// perform rpc call
-	rpcResponse, err := j.jsonRPCClient.CallParamArray(
-		ctx,
-		"getAccountInfo",
-		nil,
-		request.PublicKey.ToBase58(),
-		config,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("error performing getAccountInfo json-rpc call: %w", err)
-	}
-	if rpcResponse.Error != nil {
-		return nil, fmt.Errorf("error set on rpc response: %w", rpcResponse.Error)
-	}
+	return []interface{}{request.PublicKey.ToBase58(), config}
+}
 
+// parseGetAccountInfoResponse parses the response of a getAccountInfo call made with the given encoding
+func parseGetAccountInfoResponse(rpcResponse *jsonrpc.RPCResponse, encoding Encoding) (*GetAccountInfoResponse, error) {
 	// parse response by type
 	var response GetAccountInfoResponse
-	switch request.Encoding {
+	switch encoding {
 	case JSONParsedEncoding:
 		r := new(
 			struct {
@@ -158,15 +271,127 @@ func (j *JSONRPCConnection) GetAccountInfo(ctx context.Context, request GetAccou
 	return &response, nil
 }
 
-type getBalanceJSONRPCResponse struct {
-	Context Context `json:"context"`
-	Value   uint64  `json:"value"`
+func (j *JSONRPCConnection) GetMultipleAccounts(ctx context.Context, request GetMultipleAccountsRequest) (*GetMultipleAccountsResponse, error) {
+	// prepare configuration object
+	config := map[string]interface{}{
+		"commitment": j.Commitment(),
+		"encoding":   Base64Encoding,
+	}
+
+	// set commitment level if provided
+	if request.CommitmentLevel != "" {
+		config["commitment"] = request.CommitmentLevel
+	}
+
+	// set encoding if provided
+	if request.Encoding != "" {
+		config["encoding"] = request.Encoding
+	}
+
+	// set data slice if provided
+	if request.DataSlice != nil {
+		config["dataSlice"] = *request.DataSlice
+	}
+
+	// set min context slot if provided
+	if request.MinContextSlot != 0 {
+		config["minContextSlot"] = request.MinContextSlot
+	}
+
+	// perform rpc call for each chunk of public keys
+	response := GetMultipleAccountsResponse{
+		AccountInfos: make([]AccountInfo, 0, len(request.PublicKeys)),
+	}
+	for start := 0; start < len(request.PublicKeys); start += MaxGetMultipleAccountsPublicKeys {
+		end := start + MaxGetMultipleAccountsPublicKeys
+		if end > len(request.PublicKeys) {
+			end = len(request.PublicKeys)
+		}
+		publicKeys := make([]string, 0, end-start)
+		for _, publicKey := range request.PublicKeys[start:end] {
+			publicKeys = append(publicKeys, publicKey.ToBase58())
+		}
+
+		// perform rpc call
+		rpcResponse, err := j.jsonRPCClient.CallParamArray(
+			ctx,
+			"getMultipleAccounts",
+			nil,
+			publicKeys,
+			config,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error performing getMultipleAccounts json-rpc call: %w", err)
+		}
+		if rpcResponse.Error != nil {
+			return nil, fmt.Errorf("error set on rpc response: %w", newRPCError(rpcResponse.Error))
+		}
+
+		// parse response
+		r := new(
+			struct {
+				Context Context           `json:"context"`
+				Value   []json.RawMessage `json:"value"`
+			},
+		)
+		if err := rpcResponse.GetObject(r); err != nil {
+			return nil, fmt.Errorf("error parsing getMultipleAccounts response: %w", err)
+		}
+		if len(r.Value) != len(publicKeys) {
+			return nil, fmt.Errorf("%d account infos for %d public keys: %w", len(r.Value), len(publicKeys), ErrUnexpectedResponse)
+		}
+		if start == 0 || r.Context.Slot < response.Context.Slot {
+			response.Context = r.Context
+		}
+		for _, value := range r.Value {
+			accountInfo, err := parseAccountInfo(value)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing getMultipleAccounts response: %w", err)
+			}
+			response.AccountInfos = append(response.AccountInfos, accountInfo)
+		}
+	}
+
+	return &response, nil
 }
 
-func (j *JSONRPCConnection) GetBalance(ctx context.Context, request GetBalanceRequest) (*GetBalanceResponse, error) {
+// parseAccountInfo parses the given account info json value.
+// The AccountInfo returned is an AccountInfoJSONData if the account data was
+// parsed by the node and an AccountInfoEncodedData otherwise, or nil if the value is null.
+func parseAccountInfo(value json.RawMessage) (AccountInfo, error) {
+	if len(value) == 0 || string(value) == "null" {
+		return nil, nil
+	}
+
+	// determine if data was parsed or encoded
+	data := new(
+		struct {
+			Data json.RawMessage `json:"data"`
+		},
+	)
+	if err := json.Unmarshal(value, data); err != nil {
+		return nil, err
+	}
+	if len(data.Data) > 0 && data.Data[0] == '{' {
+		var accountInfo AccountInfoJSONData
+		if err := json.Unmarshal(value, &accountInfo); err != nil {
+			return nil, err
+		}
+		return accountInfo, nil
+	}
+
+	var accountInfo AccountInfoEncodedData
+	if err := json.Unmarshal(value, &accountInfo); err != nil {
+		return nil, err
+	}
+	return accountInfo, nil
+}
+
+func (j *JSONRPCConnection) GetProgramAccounts(ctx context.Context, request GetProgramAccountsRequest) (*GetProgramAccountsResponse, error) {
 	// prepare configuration object
 	config := map[string]interface{}{
 		"commitment": j.Commitment(),
+		"encoding":   Base64Encoding,
 	}
 
 	// set commitment level if provided
@@ -174,21 +399,162 @@ func (j *JSONRPCConnection) GetBalance(ctx context.Context, request GetBalanceRe
 		config["commitment"] = request.CommitmentLevel
 	}
 
+	// set encoding if provided
+	if request.Encoding != "" {
+		config["encoding"] = request.Encoding
+	}
+
+	// set data slice if provided
+	if request.DataSlice != nil {
+		config["dataSlice"] = *request.DataSlice
+	}
+
+	// set filters if provided
+	if len(request.Filters) > 0 {
+		config["filters"] = request.Filters
+	}
+
+	// set min context slot if provided
+	if request.MinContextSlot != 0 {
+		config["minContextSlot"] = request.MinContextSlot
+	}
+
+	// set with context if requested
+	if request.WithContext {
+		config["withContext"] = true
+	}
+
+	// prepare decoder to decode program accounts as the response is read
+	var response GetProgramAccountsResponse
+	resultDecoder := func(decoder *json.Decoder) error {
+		if !request.WithContext {
+			return decodeProgramAccounts(decoder, &response.ProgramAccounts)
+		}
+
+		// result is wrapped in an object with the context
+		if err := expectJSONDelim(decoder, '{'); err != nil {
+			return err
+		}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+			switch key {
+			case "context":
+				err = decoder.Decode(&response.Context)
+			case "value":
+				err = decodeProgramAccounts(decoder, &response.ProgramAccounts)
+			default:
+				err = decoder.Decode(new(json.RawMessage))
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return expectJSONDelim(decoder, '}')
+	}
+
+	// perform rpc call
+	rpcResponse, err := j.jsonRPCClient.CallParamArrayWithResultDecoder(
+		ctx,
+		"getProgramAccounts",
+		nil,
+		resultDecoder,
+		request.ProgramID.ToBase58(),
+		config,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error performing getProgramAccounts json-rpc call: %w", err)
+	}
+	if rpcResponse.Error != nil {
+		return nil, fmt.Errorf("error set on rpc response: %w", newRPCError(rpcResponse.Error))
+	}
+
+	return &response, nil
+}
+
+// decodeProgramAccounts decodes a json array of program accounts
+// with the given json.Decoder, appending each to programAccounts
+func decodeProgramAccounts(decoder *json.Decoder, programAccounts *[]ProgramAccount) error {
+	if err := expectJSONDelim(decoder, '['); err != nil {
+		return err
+	}
+	for decoder.More() {
+		programAccount := new(
+			struct {
+				PublicKey string          `json:"pubkey"`
+				Account   json.RawMessage `json:"account"`
+			},
+		)
+		if err := decoder.Decode(programAccount); err != nil {
+			return err
+		}
+		accountInfo, err := parseAccountInfo(programAccount.Account)
+		if err != nil {
+			return fmt.Errorf("error parsing account info of %s: %w", programAccount.PublicKey, err)
+		}
+		*programAccounts = append(*programAccounts, ProgramAccount{
+			PublicKey:   NewPublicKeyFromBase58String(programAccount.PublicKey),
+			AccountInfo: accountInfo,
+		})
+	}
+	return expectJSONDelim(decoder, ']')
+}
+
+// expectJSONDelim reads the next token from the given json.Decoder
+// and returns an error if it is not the given delimiter
+func expectJSONDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected '%v', got '%v': %w", delim, token, ErrUnexpectedResponse)
+	}
+	return nil
+}
+
+type getBalanceJSONRPCResponse struct {
+	Context Context `json:"context"`
+	Value   uint64  `json:"value"`
+}
+
+func (j *JSONRPCConnection) GetBalance(ctx context.Context, request GetBalanceRequest) (*GetBalanceResponse, error) {
 	// perform rpc call
 	rpcResponse, err := j.jsonRPCClient.CallParamArray(
 		ctx,
 		"getBalance",
 		nil,
-		request.PublicKey.ToBase58(),
-		config,
+		j.getBalanceParams(request)...,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error performing getBalance json-rpc call: %w", err)
 	}
 	if rpcResponse.Error != nil {
-		return nil, fmt.Errorf("error set on rpc response: %w", rpcResponse.Error)
+		return nil, fmt.Errorf("error set on rpc response: %w", newRPCError(rpcResponse.Error))
+	}
+
+	return parseGetBalanceResponse(rpcResponse)
+}
+
+// getBalanceParams returns the params of a getBalance call for the given request
+func (j *JSONRPCConnection) getBalanceParams(request GetBalanceRequest) []interface{} {
+	// prepare configuration object
+	config := map[string]interface{}{
+		"commitment": j.Commitment(),
+	}
+
+	// set commitment level if provided
+	if request.CommitmentLevel != "" {
+		config["commitment"] = request.CommitmentLevel
 	}
 
+	return []interface{}{request.PublicKey.ToBase58(), config}
+}
+
+// parseGetBalanceResponse parses the response of a getBalance call
+func parseGetBalanceResponse(rpcResponse *jsonrpc.RPCResponse) (*GetBalanceResponse, error) {
 	// parse response
 	response := new(getBalanceJSONRPCResponse)
 	if err := rpcResponse.GetObject(response); err != nil {
@@ -211,6 +577,8 @@ type getRecentBlockHashJSONRPCResponse struct {
 	}
 }
 
+// Deprecated: getRecentBlockhash has been removed from current validators.
+// Use GetLatestBlockhash, and GetFeeCalculator or GetFeeForMessage to determine fees.
 func (j *JSONRPCConnection) GetRecentBlockHash(ctx context.Context, request GetRecentBlockHashRequest) (*GetRecentBlockHashResponse, error) {
 	// prepare configuration object
 	config := map[string]interface{}{
@@ -233,7 +601,7 @@ func (j *JSONRPCConnection) GetRecentBlockHash(ctx context.Context, request GetR
 		return nil, fmt.Errorf("error performing getRecentBlockhash json-rpc call: %w", err)
 	}
 	if rpcResponse.Error != nil {
-		return nil, fmt.Errorf("error set on rpc response: %w", rpcResponse.Error)
+		return nil, fmt.Errorf("error set on rpc response: %w", newRPCError(rpcResponse.Error))
 	}
 
 	// parse response
@@ -252,6 +620,852 @@ func (j *JSONRPCConnection) GetRecentBlockHash(ctx context.Context, request GetR
 	}, nil
 }
 
+func (j *JSONRPCConnection) GetLatestBlockhash(ctx context.Context, request GetLatestBlockhashRequest) (*GetLatestBlockhashResponse, error) {
+	// prepare configuration object
+	config := map[string]interface{}{
+		"commitment": j.Commitment(),
+	}
+
+	// set commitment level if provided
+	if request.CommitmentLevel != "" {
+		config["commitment"] = request.CommitmentLevel
+	}
+
+	// set min context slot if provided
+	if request.MinContextSlot != 0 {
+		config["minContextSlot"] = request.MinContextSlot
+	}
+
+	// perform rpc call
+	rpcResponse, err := j.jsonRPCClient.CallParamArray(
+		ctx,
+		"getLatestBlockhash",
+		nil,
+		config,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error performing getLatestBlockhash json-rpc call: %w", err)
+	}
+	if rpcResponse.Error != nil {
+		return nil, fmt.Errorf("error set on rpc response: %w", newRPCError(rpcResponse.Error))
+	}
+
+	// parse response
+	response := new(
+		struct {
+			Context Context `json:"context"`
+			Value   struct {
+				BlockHash            string `json:"blockhash"`
+				LastValidBlockHeight uint64 `json:"lastValidBlockHeight"`
+			} `json:"value"`
+		},
+	)
+	if err := rpcResponse.GetObject(response); err != nil {
+		return nil, fmt.Errorf("error parsing getLatestBlockhash response: %w", err)
+	}
+
+	return &GetLatestBlockhashResponse{
+		Context:              response.Context,
+		BlockHash:            response.Value.BlockHash,
+		LastValidBlockHeight: response.Value.LastValidBlockHeight,
+	}, nil
+}
+
+func (j *JSONRPCConnection) IsBlockhashValid(ctx context.Context, request IsBlockhashValidRequest) (*IsBlockhashValidResponse, error) {
+	// prepare configuration object
+	config := map[string]interface{}{
+		"commitment": j.Commitment(),
+	}
+
+	// set commitment level if provided
+	if request.CommitmentLevel != "" {
+		config["commitment"] = request.CommitmentLevel
+	}
+
+	// set min context slot if provided
+	if request.MinContextSlot != 0 {
+		config["minContextSlot"] = request.MinContextSlot
+	}
+
+	// perform rpc call
+	rpcResponse, err := j.jsonRPCClient.CallParamArray(
+		ctx,
+		"isBlockhashValid",
+		nil,
+		request.BlockHash,
+		config,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error performing isBlockhashValid json-rpc call: %w", err)
+	}
+	if rpcResponse.Error != nil {
+		return nil, fmt.Errorf("error set on rpc response: %w", newRPCError(rpcResponse.Error))
+	}
+
+	// parse response
+	response := new(
+		struct {
+			Context Context `json:"context"`
+			Value   bool    `json:"value"`
+		},
+	)
+	if err := rpcResponse.GetObject(response); err != nil {
+		return nil, fmt.Errorf("error parsing isBlockhashValid response: %w", err)
+	}
+
+	return &IsBlockhashValidResponse{
+		Context: response.Context,
+		Valid:   response.Value,
+	}, nil
+}
+
+func (j *JSONRPCConnection) GetFeeForMessage(ctx context.Context, request GetFeeForMessageRequest) (*GetFeeForMessageResponse, error) {
+	// prepare configuration object
+	config := map[string]interface{}{
+		"commitment": j.Commitment(),
+	}
+
+	// set commitment level if provided
+	if request.CommitmentLevel != "" {
+		config["commitment"] = request.CommitmentLevel
+	}
+
+	// set min context slot if provided
+	if request.MinContextSlot != 0 {
+		config["minContextSlot"] = request.MinContextSlot
+	}
+
+	// serialise message
+	messageData, err := request.Message.ToBytes()
+	if err != nil {
+		return nil, fmt.Errorf("error serialising message: %w", err)
+	}
+
+	// perform rpc call
+	rpcResponse, err := j.jsonRPCClient.CallParamArray(
+		ctx,
+		"getFeeForMessage",
+		nil,
+		base64.StdEncoding.EncodeToString(messageData),
+		config,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error performing getFeeForMessage json-rpc call: %w", err)
+	}
+	if rpcResponse.Error != nil {
+		return nil, fmt.Errorf("error set on rpc response: %w", newRPCError(rpcResponse.Error))
+	}
+
+	// parse response
+	response := new(
+		struct {
+			Context Context `json:"context"`
+			Value   *uint64 `json:"value"`
+		},
+	)
+	if err := rpcResponse.GetObject(response); err != nil {
+		return nil, fmt.Errorf("error parsing getFeeForMessage response: %w", err)
+	}
+
+	return &GetFeeForMessageResponse{
+		Context: response.Context,
+		Fee:     response.Value,
+	}, nil
+}
+
+func (j *JSONRPCConnection) GetMinimumBalanceForRentExemption(ctx context.Context, request GetMinimumBalanceForRentExemptionRequest) (*GetMinimumBalanceForRentExemptionResponse, error) {
+	// prepare configuration object
+	config := map[string]interface{}{
+		"commitment": j.Commitment(),
+	}
+
+	// set commitment level if provided
+	if request.CommitmentLevel != "" {
+		config["commitment"] = request.CommitmentLevel
+	}
+
+	// perform rpc call
+	rpcResponse, err := j.jsonRPCClient.CallParamArray(
+		ctx,
+		"getMinimumBalanceForRentExemption",
+		nil,
+		request.DataLength,
+		config,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error performing getMinimumBalanceForRentExemption json-rpc call: %w", err)
+	}
+	if rpcResponse.Error != nil {
+		return nil, fmt.Errorf("error set on rpc response: %w", newRPCError(rpcResponse.Error))
+	}
+
+	// parse response
+	response := new(uint64)
+	if err := rpcResponse.GetObject(response); err != nil {
+		return nil, fmt.Errorf("error parsing getMinimumBalanceForRentExemption response: %w", err)
+	}
+
+	return &GetMinimumBalanceForRentExemptionResponse{
+		Lamports: *response,
+	}, nil
+}
+
+func (j *JSONRPCConnection) GetSignaturesForAddress(ctx context.Context, request GetSignaturesForAddressRequest) (*GetSignaturesForAddressResponse, error) {
+	// prepare configuration object
+	config := map[string]interface{}{
+		"commitment": j.Commitment(),
+	}
+
+	// set commitment level if provided
+	if request.CommitmentLevel != "" {
+		config["commitment"] = request.CommitmentLevel
+	}
+
+	// set pagination parameters if provided
+	if request.Before != "" {
+		config["before"] = request.Before
+	}
+	if request.Until != "" {
+		config["until"] = request.Until
+	}
+	if request.Limit != 0 {
+		config["limit"] = request.Limit
+	}
+
+	// set min context slot if provided
+	if request.MinContextSlot != 0 {
+		config["minContextSlot"] = request.MinContextSlot
+	}
+
+	// perform rpc call
+	rpcResponse, err := j.jsonRPCClient.CallParamArray(
+		ctx,
+		"getSignaturesForAddress",
+		nil,
+		request.Address.ToBase58(),
+		config,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error performing getSignaturesForAddress json-rpc call: %w", err)
+	}
+	if rpcResponse.Error != nil {
+		return nil, fmt.Errorf("error set on rpc response: %w", newRPCError(rpcResponse.Error))
+	}
+
+	// parse response
+	var response GetSignaturesForAddressResponse
+	if err := rpcResponse.GetObject(&response.Signatures); err != nil {
+		return nil, fmt.Errorf("error parsing getSignaturesForAddress response: %w", err)
+	}
+
+	return &response, nil
+}
+
+func (j *JSONRPCConnection) GetTransaction(ctx context.Context, request GetTransactionRequest) (*GetTransactionResponse, error) {
+	// prepare configuration object
+	config := map[string]interface{}{
+		"commitment": j.Commitment(),
+		"encoding":   JSONEncoding,
+	}
+
+	// set commitment level if provided
+	if request.CommitmentLevel != "" {
+		config["commitment"] = request.CommitmentLevel
+	}
+
+	// set encoding if provided
+	if request.Encoding != "" {
+		config["encoding"] = request.Encoding
+	}
+
+	// set max supported transaction version if provided
+	if request.MaxSupportedTransactionVersion != nil {
+		config["maxSupportedTransactionVersion"] = int(*request.MaxSupportedTransactionVersion)
+	}
+
+	// perform rpc call
+	rpcResponse, err := j.jsonRPCClient.CallParamArray(
+		ctx,
+		"getTransaction",
+		nil,
+		request.Signature,
+		config,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error performing getTransaction json-rpc call: %w", err)
+	}
+	if rpcResponse.Error != nil {
+		return nil, fmt.Errorf("error set on rpc response: %w", newRPCError(rpcResponse.Error))
+	}
+
+	// parse response
+	r := new(
+		struct {
+			Slot        uint64              `json:"slot"`
+			BlockTime   *int64              `json:"blockTime"`
+			Version     *TransactionVersion `json:"version"`
+			Transaction json.RawMessage     `json:"transaction"`
+			Meta        *TransactionMeta    `json:"meta"`
+		},
+	)
+	if err := rpcResponse.GetObject(&r); err != nil {
+		return nil, fmt.Errorf("error parsing getTransaction response: %w", err)
+	}
+	if r == nil {
+		return &GetTransactionResponse{}, nil
+	}
+	confirmedTransaction := &ConfirmedTransaction{
+		Slot:      r.Slot,
+		BlockTime: r.BlockTime,
+		Version:   LegacyTransactionVersion,
+		Meta:      r.Meta,
+	}
+	if r.Version != nil {
+		confirmedTransaction.Version = *r.Version
+	}
+	confirmedTransaction.Transaction, err = parseEncodedTransaction(r.Transaction)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing getTransaction response: %w", err)
+	}
+
+	return &GetTransactionResponse{
+		ConfirmedTransaction: confirmedTransaction,
+	}, nil
+}
+
+// parseEncodedTransaction parses the given transaction json value, which is
+// either a [data, encoding] pair or a json or jsonParsed transaction object
+func parseEncodedTransaction(value json.RawMessage) (EncodedTransaction, error) {
+	var encodedTransaction EncodedTransaction
+
+	// transaction data is given as a [data, encoding] pair
+	if len(value) > 0 && value[0] == '[' {
+		var data []string
+		if err := json.Unmarshal(value, &data); err != nil {
+			return encodedTransaction, err
+		}
+		var err error
+		encodedTransaction.Data, err = AccountInfoEncodedData{Data: data}.DecodeData()
+		if err != nil {
+			return encodedTransaction, fmt.Errorf("error decoding transaction data: %w", err)
+		}
+		return encodedTransaction, nil
+	}
+
+	// parsed transactions have account keys given as objects
+	transaction := new(
+		struct {
+			Message struct {
+				AccountKeys []json.RawMessage `json:"accountKeys"`
+			} `json:"message"`
+		},
+	)
+	if err := json.Unmarshal(value, transaction); err != nil {
+		return encodedTransaction, err
+	}
+	accountKeys := transaction.Message.AccountKeys
+	if len(accountKeys) > 0 && accountKeys[0][0] == '{' {
+		encodedTransaction.Parsed = new(ParsedTransactionJSON)
+		if err := json.Unmarshal(value, encodedTransaction.Parsed); err != nil {
+			return encodedTransaction, err
+		}
+		return encodedTransaction, nil
+	}
+
+	encodedTransaction.JSON = new(TransactionJSON)
+	if err := json.Unmarshal(value, encodedTransaction.JSON); err != nil {
+		return encodedTransaction, err
+	}
+	return encodedTransaction, nil
+}
+
+func (j *JSONRPCConnection) GetHealth(ctx context.Context) error {
+	// perform rpc call
+	rpcResponse, err := j.jsonRPCClient.CallParamArray(
+		ctx,
+		"getHealth",
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("error performing getHealth json-rpc call: %w", err)
+	}
+	if rpcResponse.Error != nil {
+		return fmt.Errorf("error set on rpc response: %w", newRPCError(rpcResponse.Error))
+	}
+
+	// parse response
+	var health string
+	if err := rpcResponse.GetObject(&health); err != nil {
+		return fmt.Errorf("error parsing getHealth response: %w", err)
+	}
+	if health != "ok" {
+		return fmt.Errorf("unexpected health %q: %w", health, ErrUnexpectedResponse)
+	}
+
+	return nil
+}
+
+func (j *JSONRPCConnection) GetSlot(ctx context.Context, request GetSlotRequest) (*GetSlotResponse, error) {
+	// prepare configuration object
+	config := map[string]interface{}{
+		"commitment": j.Commitment(),
+	}
+
+	// set commitment level if provided
+	if request.CommitmentLevel != "" {
+		config["commitment"] = request.CommitmentLevel
+	}
+
+	// set min context slot if provided
+	if request.MinContextSlot != 0 {
+		config["minContextSlot"] = request.MinContextSlot
+	}
+
+	// perform rpc call
+	rpcResponse, err := j.jsonRPCClient.CallParamArray(
+		ctx,
+		"getSlot",
+		nil,
+		config,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error performing getSlot json-rpc call: %w", err)
+	}
+	if rpcResponse.Error != nil {
+		return nil, fmt.Errorf("error set on rpc response: %w", newRPCError(rpcResponse.Error))
+	}
+
+	// parse response
+	var response GetSlotResponse
+	if err := rpcResponse.GetObject(&response.Slot); err != nil {
+		return nil, fmt.Errorf("error parsing getSlot response: %w", err)
+	}
+
+	return &response, nil
+}
+
+func (j *JSONRPCConnection) GetBlockHeight(ctx context.Context, request GetBlockHeightRequest) (*GetBlockHeightResponse, error) {
+	// prepare configuration object
+	config := map[string]interface{}{
+		"commitment": j.Commitment(),
+	}
+
+	// set commitment level if provided
+	if request.CommitmentLevel != "" {
+		config["commitment"] = request.CommitmentLevel
+	}
+
+	// set min context slot if provided
+	if request.MinContextSlot != 0 {
+		config["minContextSlot"] = request.MinContextSlot
+	}
+
+	// perform rpc call
+	rpcResponse, err := j.jsonRPCClient.CallParamArray(
+		ctx,
+		"getBlockHeight",
+		nil,
+		config,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error performing getBlockHeight json-rpc call: %w", err)
+	}
+	if rpcResponse.Error != nil {
+		return nil, fmt.Errorf("error set on rpc response: %w", newRPCError(rpcResponse.Error))
+	}
+
+	// parse response
+	var response GetBlockHeightResponse
+	if err := rpcResponse.GetObject(&response.BlockHeight); err != nil {
+		return nil, fmt.Errorf("error parsing getBlockHeight response: %w", err)
+	}
+
+	return &response, nil
+}
+
+func (j *JSONRPCConnection) GetBlock(ctx context.Context, request GetBlockRequest) (*GetBlockResponse, error) {
+	// prepare configuration object
+	config := map[string]interface{}{
+		"commitment":         j.Commitment(),
+		"encoding":           JSONEncoding,
+		"transactionDetails": FullTransactionDetails,
+		"rewards":            !request.ExcludeRewards,
+	}
+
+	// set commitment level if provided
+	if request.CommitmentLevel != "" {
+		config["commitment"] = request.CommitmentLevel
+	}
+
+	// set encoding if provided
+	if request.Encoding != "" {
+		config["encoding"] = request.Encoding
+	}
+
+	// set transaction details if provided
+	if request.TransactionDetails != "" {
+		config["transactionDetails"] = request.TransactionDetails
+	}
+
+	// set max supported transaction version if provided
+	if request.MaxSupportedTransactionVersion != nil {
+		config["maxSupportedTransactionVersion"] = int(*request.MaxSupportedTransactionVersion)
+	}
+
+	// perform rpc call
+	rpcResponse, err := j.jsonRPCClient.CallParamArray(
+		ctx,
+		"getBlock",
+		nil,
+		request.Slot,
+		config,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error performing getBlock json-rpc call: %w", err)
+	}
+	if rpcResponse.Error != nil {
+		return nil, fmt.Errorf("error set on rpc response: %w", newRPCError(rpcResponse.Error))
+	}
+
+	// parse response
+	r := new(blockJSONRPCResponse)
+	if err := rpcResponse.GetObject(&r); err != nil {
+		return nil, fmt.Errorf("error parsing getBlock response: %w", err)
+	}
+	if r == nil {
+		return &GetBlockResponse{}, nil
+	}
+	block, err := r.toBlock()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing getBlock response: %w", err)
+	}
+
+	return &GetBlockResponse{
+		Block: block,
+	}, nil
+}
+
+func (j *JSONRPCConnection) GetBlocks(ctx context.Context, request GetBlocksRequest) (*GetBlocksResponse, error) {
+	// prepare configuration object
+	config := map[string]interface{}{
+		"commitment": j.Commitment(),
+	}
+
+	// set commitment level if provided
+	if request.CommitmentLevel != "" {
+		config["commitment"] = request.CommitmentLevel
+	}
+
+	// prepare params, end slot is optional
+	params := []interface{}{request.StartSlot}
+	if request.EndSlot != nil {
+		params = append(params, *request.EndSlot)
+	}
+	params = append(params, config)
+
+	// perform rpc call
+	rpcResponse, err := j.jsonRPCClient.CallParamArray(
+		ctx,
+		"getBlocks",
+		nil,
+		params...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error performing getBlocks json-rpc call: %w", err)
+	}
+	if rpcResponse.Error != nil {
+		return nil, fmt.Errorf("error set on rpc response: %w", newRPCError(rpcResponse.Error))
+	}
+
+	// parse response
+	var response GetBlocksResponse
+	if err := rpcResponse.GetObject(&response.Slots); err != nil {
+		return nil, fmt.Errorf("error parsing getBlocks response: %w", err)
+	}
+
+	return &response, nil
+}
+
+func (j *JSONRPCConnection) GetBlocksWithLimit(ctx context.Context, request GetBlocksWithLimitRequest) (*GetBlocksWithLimitResponse, error) {
+	// prepare configuration object
+	config := map[string]interface{}{
+		"commitment": j.Commitment(),
+	}
+
+	// set commitment level if provided
+	if request.CommitmentLevel != "" {
+		config["commitment"] = request.CommitmentLevel
+	}
+
+	// perform rpc call
+	rpcResponse, err := j.jsonRPCClient.CallParamArray(
+		ctx,
+		"getBlocksWithLimit",
+		nil,
+		request.StartSlot,
+		request.Limit,
+		config,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error performing getBlocksWithLimit json-rpc call: %w", err)
+	}
+	if rpcResponse.Error != nil {
+		return nil, fmt.Errorf("error set on rpc response: %w", newRPCError(rpcResponse.Error))
+	}
+
+	// parse response
+	var response GetBlocksWithLimitResponse
+	if err := rpcResponse.GetObject(&response.Slots); err != nil {
+		return nil, fmt.Errorf("error parsing getBlocksWithLimit response: %w", err)
+	}
+
+	return &response, nil
+}
+
+func (j *JSONRPCConnection) GetBlockTime(ctx context.Context, request GetBlockTimeRequest) (*GetBlockTimeResponse, error) {
+	// perform rpc call
+	rpcResponse, err := j.jsonRPCClient.CallParamArray(
+		ctx,
+		"getBlockTime",
+		nil,
+		request.Slot,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error performing getBlockTime json-rpc call: %w", err)
+	}
+	if rpcResponse.Error != nil {
+		return nil, fmt.Errorf("error set on rpc response: %w", newRPCError(rpcResponse.Error))
+	}
+
+	// parse response
+	var response GetBlockTimeResponse
+	if err := rpcResponse.GetObject(&response.BlockTime); err != nil {
+		return nil, fmt.Errorf("error parsing getBlockTime response: %w", err)
+	}
+
+	return &response, nil
+}
+
+func (j *JSONRPCConnection) GetFirstAvailableBlock(ctx context.Context) (*GetFirstAvailableBlockResponse, error) {
+	// perform rpc call
+	rpcResponse, err := j.jsonRPCClient.CallParamArray(
+		ctx,
+		"getFirstAvailableBlock",
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error performing getFirstAvailableBlock json-rpc call: %w", err)
+	}
+	if rpcResponse.Error != nil {
+		return nil, fmt.Errorf("error set on rpc response: %w", newRPCError(rpcResponse.Error))
+	}
+
+	// parse response
+	var response GetFirstAvailableBlockResponse
+	if err := rpcResponse.GetObject(&response.Slot); err != nil {
+		return nil, fmt.Errorf("error parsing getFirstAvailableBlock response: %w", err)
+	}
+
+	return &response, nil
+}
+
+func (j *JSONRPCConnection) MinimumLedgerSlot(ctx context.Context) (*MinimumLedgerSlotResponse, error) {
+	// perform rpc call
+	rpcResponse, err := j.jsonRPCClient.CallParamArray(
+		ctx,
+		"minimumLedgerSlot",
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error performing minimumLedgerSlot json-rpc call: %w", err)
+	}
+	if rpcResponse.Error != nil {
+		return nil, fmt.Errorf("error set on rpc response: %w", newRPCError(rpcResponse.Error))
+	}
+
+	// parse response
+	var response MinimumLedgerSlotResponse
+	if err := rpcResponse.GetObject(&response.Slot); err != nil {
+		return nil, fmt.Errorf("error parsing minimumLedgerSlot response: %w", err)
+	}
+
+	return &response, nil
+}
+
+func (j *JSONRPCConnection) GetSignatureStatuses(ctx context.Context, request GetSignatureStatusesRequest) (*GetSignatureStatusesResponse, error) {
+	// prepare configuration object
+	config := map[string]interface{}{
+		"searchTransactionHistory": request.SearchTransactionHistory,
+	}
+
+	// perform rpc call for each chunk of signatures
+	response := GetSignatureStatusesResponse{
+		Statuses: make([]*SignatureStatus, 0, len(request.Signatures)),
+	}
+	for start := 0; start < len(request.Signatures); start += MaxGetSignatureStatusesSignatures {
+		end := start + MaxGetSignatureStatusesSignatures
+		if end > len(request.Signatures) {
+			end = len(request.Signatures)
+		}
+		signatures := request.Signatures[start:end]
+
+		// perform rpc call
+		rpcResponse, err := j.jsonRPCClient.CallParamArray(
+			ctx,
+			"getSignatureStatuses",
+			nil,
+			signatures,
+			config,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error performing getSignatureStatuses json-rpc call: %w", err)
+		}
+		if rpcResponse.Error != nil {
+			return nil, fmt.Errorf("error set on rpc response: %w", newRPCError(rpcResponse.Error))
+		}
+
+		// parse response
+		r := new(
+			struct {
+				Context Context            `json:"context"`
+				Value   []*SignatureStatus `json:"value"`
+			},
+		)
+		if err := rpcResponse.GetObject(r); err != nil {
+			return nil, fmt.Errorf("error parsing getSignatureStatuses response: %w", err)
+		}
+		if len(r.Value) != len(signatures) {
+			return nil, fmt.Errorf("%d statuses for %d signatures: %w", len(r.Value), len(signatures), ErrUnexpectedResponse)
+		}
+		if start == 0 || r.Context.Slot < response.Context.Slot {
+			response.Context = r.Context
+		}
+		response.Statuses = append(response.Statuses, r.Value...)
+	}
+
+	return &response, nil
+}
+
+func (j *JSONRPCConnection) SimulateTransaction(ctx context.Context, request SimulateTransactionRequest) (*SimulateTransactionResponse, error) {
+	// prepare configuration object
+	config := map[string]interface{}{
+		"commitment": j.Commitment(),
+		"sigVerify":  request.SigVerify,
+	}
+
+	// prepare transaction as indicated
+	var txnData string
+	var err error
+	switch request.Encoding {
+	case Base58Encoding:
+		config["encoding"] = Base58Encoding
+		txnData, err = request.Transaction.ToBase58()
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling to base58: %w", err)
+		}
+
+	case Base64Encoding:
+		fallthrough
+	default:
+		config["encoding"] = Base64Encoding
+		txnData, err = request.Transaction.ToBase64()
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling to base64: %w", err)
+		}
+	}
+
+	// set commitment level if provided
+	if request.CommitmentLevel != "" {
+		config["commitment"] = request.CommitmentLevel
+	}
+
+	// set replace recent block hash if requested
+	if request.ReplaceRecentBlockHash {
+		config["replaceRecentBlockhash"] = true
+	}
+
+	// set accounts if provided
+	if request.Accounts != nil {
+		addresses := make([]string, 0, len(request.Accounts.PublicKeys))
+		for _, publicKey := range request.Accounts.PublicKeys {
+			addresses = append(addresses, publicKey.ToBase58())
+		}
+		accountsEncoding := Base64Encoding
+		if request.Accounts.Encoding != "" {
+			accountsEncoding = request.Accounts.Encoding
+		}
+		config["accounts"] = map[string]interface{}{
+			"addresses": addresses,
+			"encoding":  accountsEncoding,
+		}
+	}
+
+	// set inner instructions if requested
+	if request.InnerInstructions {
+		config["innerInstructions"] = true
+	}
+
+	// set min context slot if provided
+	if request.MinContextSlot != 0 {
+		config["minContextSlot"] = request.MinContextSlot
+	}
+
+	// perform rpc call
+	rpcResponse, err := j.jsonRPCClient.CallParamArray(
+		ctx,
+		"simulateTransaction",
+		nil,
+		txnData,
+		config,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error performing simulateTransaction json-rpc call: %w", err)
+	}
+	if rpcResponse.Error != nil {
+		return nil, fmt.Errorf("error set on rpc response: %w", newRPCError(rpcResponse.Error))
+	}
+
+	// parse response
+	r := new(
+		struct {
+			Context Context `json:"context"`
+			Value   struct {
+				Err                  *TransactionError     `json:"err"`
+				Logs                 []string              `json:"logs"`
+				Accounts             []json.RawMessage     `json:"accounts"`
+				UnitsConsumed        *uint64               `json:"unitsConsumed"`
+				ReturnData           *ReturnData           `json:"returnData"`
+				InnerInstructions    []InnerInstructions   `json:"innerInstructions"`
+				ReplacementBlockHash *ReplacementBlockHash `json:"replacementBlockhash"`
+			} `json:"value"`
+		},
+	)
+	if err := rpcResponse.GetObject(r); err != nil {
+		return nil, fmt.Errorf("error parsing simulateTransaction response: %w", err)
+	}
+	response := SimulateTransactionResponse{
+		Context:              r.Context,
+		Err:                  r.Value.Err,
+		Logs:                 r.Value.Logs,
+		UnitsConsumed:        r.Value.UnitsConsumed,
+		ReturnData:           r.Value.ReturnData,
+		InnerInstructions:    r.Value.InnerInstructions,
+		ReplacementBlockHash: r.Value.ReplacementBlockHash,
+	}
+	if r.Value.Accounts != nil {
+		response.Accounts = make([]AccountInfo, 0, len(r.Value.Accounts))
+	}
+	for _, value := range r.Value.Accounts {
+		accountInfo, err := parseAccountInfo(value)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing simulateTransaction response: %w", err)
+		}
+		response.Accounts = append(response.Accounts, accountInfo)
+	}
+
+	return &response, nil
+}
+
 func (j *JSONRPCConnection) SendTransaction(ctx context.Context, request SendTransactionRequest) (*SendTransactionResponse, error) {
 	// prepare configuration object
 	config := map[string]interface{}{
@@ -273,7 +1487,7 @@ func (j *JSONRPCConnection) SendTransaction(ctx context.Context, request SendTra
 		fallthrough
 	default:
 		config["encoding"] = Base58Encoding
-		txnData, err = request.Transaction.ToBase58([32]byte{})
+		txnData, err = request.Transaction.ToBase58()
 		if err != nil {
 			return nil, fmt.Errorf("error marshalling to base58: %w", err)
 		}
@@ -301,7 +1515,7 @@ func (j *JSONRPCConnection) SendTransaction(ctx context.Context, request SendTra
 		return nil, fmt.Errorf("error performing sendTransaction json-rpc call: %w", err)
 	}
 	if rpcResponse.Error != nil {
-		return nil, fmt.Errorf("error set on rpc response: %s", rpcResponse.Error.Error())
+		return nil, fmt.Errorf("error set on rpc response: %w", newRPCError(rpcResponse.Error))
 	}
 
 	// parse response
@@ -2,14 +2,21 @@ package solana
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/BRBussy/solgo/internal/pkg/jsonrpc"
 	"github.com/stretchr/testify/require"
+	"net/http"
 	"testing"
+	"time"
 )
 
 func TestNewJSONRPCConnection(t *testing.T) {
+	rateLimiter := NewRateLimiter(RateLimit{PerSecond: 10, Burst: 10}, nil)
+	httpClient := &http.Client{Timeout: time.Minute}
+
 	type args struct {
 		opts []JSONRPCConnectionOption
 	}
@@ -70,6 +77,72 @@ func TestNewJSONRPCConnection(t *testing.T) {
 				return c
 			}(),
 		},
+		{
+			name: "WithRetryPolicy config",
+			args: args{
+				opts: []JSONRPCConnectionOption{
+					WithRetryPolicy(DefaultRetryPolicy()),
+				},
+			},
+			want: func() *JSONRPCConnection {
+				c := NewJSONRPCConnection()
+				retryPolicy := DefaultRetryPolicy()
+				c.config.retryPolicy = &retryPolicy
+				c.jsonRPCClient = jsonrpc.NewHTTPClientFromOpts(
+					MainnetBeta.MustToRPCURL(),
+					jsonrpc.RPCClientOpts{
+						RetryPolicy: &jsonrpc.RetryPolicy{
+							MaxAttempts:          4,
+							MinBackoff:           250 * time.Millisecond,
+							MaxBackoff:           5 * time.Second,
+							NonIdempotentMethods: []string{"sendTransaction", "requestAirdrop"},
+						},
+					},
+				)
+				return c
+			}(),
+		},
+		{
+			name: "WithHTTPClient and WithCustomHeaders config",
+			args: args{
+				opts: []JSONRPCConnectionOption{
+					WithHTTPClient(httpClient),
+					WithCustomHeaders(map[string]string{"Authorization": "Bearer token"}),
+				},
+			},
+			want: func() *JSONRPCConnection {
+				c := NewJSONRPCConnection()
+				c.config.httpClient = httpClient
+				c.config.customHeaders = map[string]string{"Authorization": "Bearer token"}
+				c.jsonRPCClient = jsonrpc.NewHTTPClientFromOpts(
+					MainnetBeta.MustToRPCURL(),
+					jsonrpc.RPCClientOpts{
+						HTTPClient:    httpClient,
+						CustomHeaders: map[string]string{"Authorization": "Bearer token"},
+					},
+				)
+				return c
+			}(),
+		},
+		{
+			name: "WithRateLimiter config",
+			args: args{
+				opts: []JSONRPCConnectionOption{
+					WithRateLimiter(rateLimiter),
+				},
+			},
+			want: func() *JSONRPCConnection {
+				c := NewJSONRPCConnection()
+				c.config.rateLimiter = rateLimiter
+				c.jsonRPCClient = jsonrpc.NewHTTPClientFromOpts(
+					MainnetBeta.MustToRPCURL(),
+					jsonrpc.RPCClientOpts{
+						RateLimiter: rateLimiter.rateLimiter,
+					},
+				)
+				return c
+			}(),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -752,3 +825,2291 @@ func TestJSONRPCConnection_GetRecentBlockHash(t *testing.T) {
 		})
 	}
 }
+
+func TestJSONRPCConnection_GetMinimumBalanceForRentExemption(t *testing.T) {
+	type fields struct {
+		jsonRPCClient *jsonrpc.MockClient
+		config        *jsonrpcConnectionConfig
+	}
+	type args struct {
+		ctx     context.Context
+		request GetMinimumBalanceForRentExemptionRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *GetMinimumBalanceForRentExemptionResponse
+		wantErr bool
+	}{
+		{
+			name: "error performing json rpc call - commitment config not provided",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						require.Equalf(t, "getMinimumBalanceForRentExemption", method, "method not as expected")
+						require.Equalf(
+							t,
+							[]interface{}{
+								uint64(50),
+								map[string]interface{}{
+									"commitment": MaxCommitmentLevel,
+								},
+							},
+							params,
+							"params not as expected",
+						)
+
+						return nil, errors.New("some err")
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				request: GetMinimumBalanceForRentExemptionRequest{DataLength: 50},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error set on rpc response - commitment config provided",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						require.Equalf(
+							t,
+							[]interface{}{
+								uint64(50),
+								map[string]interface{}{
+									"commitment": ProcessedCommitmentLevel,
+								},
+							},
+							params,
+							"params not as expected",
+						)
+
+						return &jsonrpc.RPCResponse{
+							Error: &jsonrpc.RPCError{Message: "bad things happened"},
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				request: GetMinimumBalanceForRentExemptionRequest{
+					DataLength:      50,
+					CommitmentLevel: ProcessedCommitmentLevel,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error parsing response",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						return &jsonrpc.RPCResponse{
+							Result: []byte("invalid data here"),
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				request: GetMinimumBalanceForRentExemptionRequest{DataLength: 50},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "success",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						return &jsonrpc.RPCResponse{
+							Result: []byte("500"),
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				request: GetMinimumBalanceForRentExemptionRequest{DataLength: 50},
+			},
+			want:    &GetMinimumBalanceForRentExemptionResponse{Lamports: 500},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fields.jsonRPCClient.T = t
+			j := &JSONRPCConnection{
+				jsonRPCClient: tt.fields.jsonRPCClient,
+				config:        tt.fields.config,
+			}
+			got, err := j.GetMinimumBalanceForRentExemption(tt.args.ctx, tt.args.request)
+			require.Equal(t, tt.wantErr, err != nil)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestJSONRPCConnection_GetMultipleAccounts(t *testing.T) {
+	// prepare 150 public keys so that the request is split across 2 calls
+	publicKeys := make([]PublicKey, 150)
+	for i := range publicKeys {
+		publicKeys[i] = MustNewRandomKeypair().PublicKey
+	}
+	base58PublicKeys := func(publicKeys []PublicKey) []string {
+		b58PublicKeys := make([]string, 0, len(publicKeys))
+		for _, publicKey := range publicKeys {
+			b58PublicKeys = append(b58PublicKeys, publicKey.ToBase58())
+		}
+		return b58PublicKeys
+	}
+
+	type fields struct {
+		jsonRPCClient *jsonrpc.MockClient
+		config        *jsonrpcConnectionConfig
+	}
+	type args struct {
+		ctx     context.Context
+		request GetMultipleAccountsRequest
+	}
+	tests := []struct {
+		name            string
+		fields          fields
+		args            args
+		want            *GetMultipleAccountsResponse
+		wantErr         bool
+		wantInvocations int
+	}{
+		{
+			name: "error performing json rpc call - no config provided",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						require.Equalf(t, "getMultipleAccounts", method, "method not as expected")
+						require.Equalf(
+							t,
+							[]interface{}{
+								base58PublicKeys(publicKeys[:1]),
+								map[string]interface{}{
+									"commitment": MaxCommitmentLevel,
+									"encoding":   Base64Encoding,
+								},
+							},
+							params,
+							"params not as expected",
+						)
+
+						return nil, errors.New("some err")
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				request: GetMultipleAccountsRequest{PublicKeys: publicKeys[:1]},
+			},
+			want:            nil,
+			wantErr:         true,
+			wantInvocations: 1,
+		},
+		{
+			name: "error set on rpc response - all config provided",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						require.Equalf(
+							t,
+							[]interface{}{
+								base58PublicKeys(publicKeys[:1]),
+								map[string]interface{}{
+									"commitment":     ProcessedCommitmentLevel,
+									"encoding":       Base58Encoding,
+									"dataSlice":      DataSlice{Offset: 8, Length: 32},
+									"minContextSlot": uint64(100),
+								},
+							},
+							params,
+							"params not as expected",
+						)
+
+						return &jsonrpc.RPCResponse{
+							Error: &jsonrpc.RPCError{Message: "bad things happened"},
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				request: GetMultipleAccountsRequest{
+					PublicKeys:      publicKeys[:1],
+					CommitmentLevel: ProcessedCommitmentLevel,
+					Encoding:        Base58Encoding,
+					DataSlice:       &DataSlice{Offset: 8, Length: 32},
+					MinContextSlot:  100,
+				},
+			},
+			want:            nil,
+			wantErr:         true,
+			wantInvocations: 1,
+		},
+		{
+			name: "unexpected number of account infos in response",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						return &jsonrpc.RPCResponse{
+							Result: json.RawMessage(`{"context": {"slot": 1}, "value": [null]}`),
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				request: GetMultipleAccountsRequest{PublicKeys: publicKeys[:2]},
+			},
+			want:            nil,
+			wantErr:         true,
+			wantInvocations: 1,
+		},
+		{
+			name: "success - request split across calls",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						// build response with an account for every key except the last, which does not exist
+						requestedKeys := params[0].([]string)
+						values := make([]json.RawMessage, 0, len(requestedKeys))
+						for i := range requestedKeys {
+							if i == len(requestedKeys)-1 {
+								values = append(values, json.RawMessage("null"))
+								continue
+							}
+							values = append(values, json.RawMessage(fmt.Sprintf(
+								`{"data": ["AQ==", "base64"], "executable": false, "lamports": %d, "owner": "11111111111111111111111111111111", "rentEpoch": 2}`,
+								i,
+							)))
+						}
+						switch m.CallParamArrayFuncInvocations {
+						case 1:
+							require.Equal(t, base58PublicKeys(publicKeys[:100]), requestedKeys)
+						case 2:
+							require.Equal(t, base58PublicKeys(publicKeys[100:]), requestedKeys)
+						}
+						valuesJSON, err := json.Marshal(values)
+						require.Nil(t, err)
+
+						return &jsonrpc.RPCResponse{
+							Result: json.RawMessage(fmt.Sprintf(
+								`{"context": {"slot": %d}, "value": %s}`,
+								12-m.CallParamArrayFuncInvocations,
+								valuesJSON,
+							)),
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				request: GetMultipleAccountsRequest{PublicKeys: publicKeys},
+			},
+			want: func() *GetMultipleAccountsResponse {
+				response := &GetMultipleAccountsResponse{Context: Context{Slot: 10}}
+				for _, chunkSize := range []int{100, 50} {
+					for i := 0; i < chunkSize; i++ {
+						if i == chunkSize-1 {
+							response.AccountInfos = append(response.AccountInfos, nil)
+							continue
+						}
+						response.AccountInfos = append(response.AccountInfos, AccountInfoEncodedData{
+							Lamports:  uint64(i),
+							Data:      []string{"AQ==", "base64"},
+							Owner:     "11111111111111111111111111111111",
+							RentEpoch: 2,
+						})
+					}
+				}
+				return response
+			}(),
+			wantErr:         false,
+			wantInvocations: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.fields.jsonRPCClient != nil {
+				tt.fields.jsonRPCClient.T = t
+			}
+			j := &JSONRPCConnection{
+				jsonRPCClient: tt.fields.jsonRPCClient,
+				config:        tt.fields.config,
+			}
+			got, err := j.GetMultipleAccounts(tt.args.ctx, tt.args.request)
+			require.Equalf(t, tt.wantErr, err != nil, "error is nil")
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantInvocations, tt.fields.jsonRPCClient.CallParamArrayFuncInvocations)
+		})
+	}
+}
+
+func TestJSONRPCConnection_GetProgramAccounts(t *testing.T) {
+	programID := MustNewRandomKeypair().PublicKey
+	account1 := MustNewRandomKeypair().PublicKey
+	account2 := MustNewRandomKeypair().PublicKey
+	programAccountsJSON := fmt.Sprintf(`[
+  {
+    "pubkey": "%s",
+    "account": {"data": ["AQ==", "base64"], "executable": false, "lamports": 10, "owner": "%s", "rentEpoch": 2}
+  },
+  {
+    "pubkey": "%s",
+    "account": {"data": ["Ag==", "base64"], "executable": false, "lamports": 20, "owner": "%s", "rentEpoch": 2}
+  }
+]`, account1.ToBase58(), programID.ToBase58(), account2.ToBase58(), programID.ToBase58())
+	programAccounts := []ProgramAccount{
+		{
+			PublicKey: account1,
+			AccountInfo: AccountInfoEncodedData{
+				Lamports:  10,
+				Data:      []string{"AQ==", "base64"},
+				Owner:     programID.ToBase58(),
+				RentEpoch: 2,
+			},
+		},
+		{
+			PublicKey: account2,
+			AccountInfo: AccountInfoEncodedData{
+				Lamports:  20,
+				Data:      []string{"Ag==", "base64"},
+				Owner:     programID.ToBase58(),
+				RentEpoch: 2,
+			},
+		},
+	}
+
+	type fields struct {
+		jsonRPCClient *jsonrpc.MockClient
+		config        *jsonrpcConnectionConfig
+	}
+	type args struct {
+		ctx     context.Context
+		request GetProgramAccountsRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *GetProgramAccountsResponse
+		wantErr bool
+	}{
+		{
+			name: "error performing json rpc call - no config provided",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						require.Equalf(t, "getProgramAccounts", method, "method not as expected")
+						require.Equalf(
+							t,
+							[]interface{}{
+								programID.ToBase58(),
+								map[string]interface{}{
+									"commitment": MaxCommitmentLevel,
+									"encoding":   Base64Encoding,
+								},
+							},
+							params,
+							"params not as expected",
+						)
+
+						return nil, errors.New("some err")
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				request: GetProgramAccountsRequest{ProgramID: programID},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error set on rpc response - all config provided",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						require.Equalf(
+							t,
+							[]interface{}{
+								programID.ToBase58(),
+								map[string]interface{}{
+									"commitment": ProcessedCommitmentLevel,
+									"encoding":   Base58Encoding,
+									"dataSlice":  DataSlice{Offset: 0, Length: 8},
+									"filters": []ProgramAccountsFilter{
+										NewDataSizeFilter(165),
+										NewMemcmpFilter(32, []byte{1, 2, 3}),
+									},
+									"minContextSlot": uint64(100),
+									"withContext":    true,
+								},
+							},
+							params,
+							"params not as expected",
+						)
+
+						return &jsonrpc.RPCResponse{
+							Error: &jsonrpc.RPCError{Message: "bad things happened"},
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				request: GetProgramAccountsRequest{
+					ProgramID:       programID,
+					CommitmentLevel: ProcessedCommitmentLevel,
+					Encoding:        Base58Encoding,
+					DataSlice:       &DataSlice{Offset: 0, Length: 8},
+					Filters: []ProgramAccountsFilter{
+						NewDataSizeFilter(165),
+						NewMemcmpFilter(32, []byte{1, 2, 3}),
+					},
+					MinContextSlot: 100,
+					WithContext:    true,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error parsing response",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						return &jsonrpc.RPCResponse{
+							Result: json.RawMessage(`{"not": "an array"}`),
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				request: GetProgramAccountsRequest{ProgramID: programID},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "success",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						return &jsonrpc.RPCResponse{
+							Result: json.RawMessage(programAccountsJSON),
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				request: GetProgramAccountsRequest{ProgramID: programID},
+			},
+			want: &GetProgramAccountsResponse{
+				ProgramAccounts: programAccounts,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success with context",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						return &jsonrpc.RPCResponse{
+							Result: json.RawMessage(`{"context": {"slot": 5}, "value": ` + programAccountsJSON + `}`),
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				request: GetProgramAccountsRequest{
+					ProgramID:   programID,
+					WithContext: true,
+				},
+			},
+			want: &GetProgramAccountsResponse{
+				Context:         Context{Slot: 5},
+				ProgramAccounts: programAccounts,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.fields.jsonRPCClient != nil {
+				tt.fields.jsonRPCClient.T = t
+			}
+			j := &JSONRPCConnection{
+				jsonRPCClient: tt.fields.jsonRPCClient,
+				config:        tt.fields.config,
+			}
+			got, err := j.GetProgramAccounts(tt.args.ctx, tt.args.request)
+			require.Equalf(t, tt.wantErr, err != nil, "error is nil")
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestJSONRPCConnection_GetSignaturesForAddress(t *testing.T) {
+	address := MustNewRandomKeypair().PublicKey
+	blockTime := int64(1700000000)
+	memo := "hello"
+
+	type fields struct {
+		jsonRPCClient *jsonrpc.MockClient
+		config        *jsonrpcConnectionConfig
+	}
+	type args struct {
+		ctx     context.Context
+		request GetSignaturesForAddressRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *GetSignaturesForAddressResponse
+		wantErr bool
+	}{
+		{
+			name: "error performing json rpc call",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						require.Equalf(t, "getSignaturesForAddress", method, "method not as expected")
+						require.Equalf(
+							t,
+							[]interface{}{
+								address.ToBase58(),
+								map[string]interface{}{
+									"commitment":     FinalizedCommitmentLevel,
+									"before":         "sig3",
+									"until":          "sig1",
+									"limit":          uint(2),
+									"minContextSlot": uint64(7),
+								},
+							},
+							params,
+							"params not as expected",
+						)
+
+						return nil, errors.New("some err")
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				request: GetSignaturesForAddressRequest{
+					Address:         address,
+					CommitmentLevel: FinalizedCommitmentLevel,
+					Before:          "sig3",
+					Until:           "sig1",
+					Limit:           2,
+					MinContextSlot:  7,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error set on rpc response",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						return &jsonrpc.RPCResponse{
+							Error: &jsonrpc.RPCError{Message: "bad things happened"},
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				request: GetSignaturesForAddressRequest{Address: address},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "success",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						return &jsonrpc.RPCResponse{
+							Result: json.RawMessage(`[
+  {"signature": "sig2", "slot": 20, "err": null, "memo": "hello", "blockTime": 1700000000, "confirmationStatus": "finalized"},
+  {"signature": "sig1", "slot": 10, "err": {"InstructionError": [0, "InvalidArgument"]}, "memo": null, "blockTime": null, "confirmationStatus": "finalized"}
+]`),
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				request: GetSignaturesForAddressRequest{Address: address},
+			},
+			want: &GetSignaturesForAddressResponse{
+				Signatures: []SignatureInfo{
+					{
+						Signature:          "sig2",
+						Slot:               20,
+						Memo:               &memo,
+						BlockTime:          &blockTime,
+						ConfirmationStatus: FinalizedCommitmentLevel,
+					},
+					{
+						Signature: "sig1",
+						Slot:      10,
+						Err: &TransactionError{
+							Type: InstructionErrorTransactionError,
+							InstructionError: &InstructionError{
+								Index: 0,
+								Err:   InvalidArgumentInstructionErrorType,
+							},
+						},
+						ConfirmationStatus: FinalizedCommitmentLevel,
+					},
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.fields.jsonRPCClient != nil {
+				tt.fields.jsonRPCClient.T = t
+			}
+			j := &JSONRPCConnection{
+				jsonRPCClient: tt.fields.jsonRPCClient,
+				config:        tt.fields.config,
+			}
+			got, err := j.GetSignaturesForAddress(tt.args.ctx, tt.args.request)
+			require.Equalf(t, tt.wantErr, err != nil, "error is nil")
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestJSONRPCConnection_GetTransaction(t *testing.T) {
+	v0 := V0TransactionVersion
+	blockTime := int64(1700000000)
+	computeUnitsConsumed := uint64(1500)
+	stackHeight := 2
+	metaJSON := `{
+  "err": null,
+  "fee": 5000,
+  "preBalances": [100000, 0, 1],
+  "postBalances": [94000, 1000, 1],
+  "preTokenBalances": [],
+  "postTokenBalances": [
+    {
+      "accountIndex": 1,
+      "mint": "mint111",
+      "owner": "owner111",
+      "programId": "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA",
+      "uiTokenAmount": {"amount": "1500", "decimals": 3, "uiAmount": 1.5, "uiAmountString": "1.5"}
+    }
+  ],
+  "innerInstructions": [
+    {"index": 0, "instructions": [{"programIdIndex": 2, "accounts": [0, 1], "data": "3Bxs", "stackHeight": 2}]}
+  ],
+  "logMessages": ["Program 11111111111111111111111111111111 invoke [1]", "Program 11111111111111111111111111111111 success"],
+  "loadedAddresses": {"writable": ["writable111"], "readonly": []},
+  "computeUnitsConsumed": 1500
+}`
+	wantMeta := &TransactionMeta{
+		Fee:              5000,
+		PreBalances:      []uint64{100000, 0, 1},
+		PostBalances:     []uint64{94000, 1000, 1},
+		PreTokenBalances: []TokenBalance{},
+		PostTokenBalances: []TokenBalance{
+			{
+				AccountIndex: 1,
+				Mint:         "mint111",
+				Owner:        "owner111",
+				ProgramID:    "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA",
+				UITokenAmount: TokenAmount{
+					Amount:         "1500",
+					Decimals:       3,
+					UIAmountString: "1.5",
+				},
+			},
+		},
+		InnerInstructions: []InnerInstructions{
+			{
+				Index: 0,
+				Instructions: []CompiledInstructionJSON{
+					{
+						ProgramIDIndex: 2,
+						Accounts:       []int{0, 1},
+						Data:           "3Bxs",
+						StackHeight:    &stackHeight,
+					},
+				},
+			},
+		},
+		LogMessages: []string{
+			"Program 11111111111111111111111111111111 invoke [1]",
+			"Program 11111111111111111111111111111111 success",
+		},
+		LoadedAddresses: &LoadedAddresses{
+			Writable: []string{"writable111"},
+			Readonly: []string{},
+		},
+		ComputeUnitsConsumed: &computeUnitsConsumed,
+	}
+
+	type fields struct {
+		jsonRPCClient *jsonrpc.MockClient
+		config        *jsonrpcConnectionConfig
+	}
+	type args struct {
+		ctx     context.Context
+		request GetTransactionRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *GetTransactionResponse
+		wantErr bool
+	}{
+		{
+			name: "error performing json rpc call",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						require.Equalf(t, "getTransaction", method, "method not as expected")
+						require.Equalf(
+							t,
+							[]interface{}{
+								"sig1",
+								map[string]interface{}{
+									"commitment":                     MaxCommitmentLevel,
+									"encoding":                       JSONEncoding,
+									"maxSupportedTransactionVersion": 0,
+								},
+							},
+							params,
+							"params not as expected",
+						)
+
+						return nil, errors.New("some err")
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				request: GetTransactionRequest{
+					Signature:                      "sig1",
+					MaxSupportedTransactionVersion: &v0,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error set on rpc response",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						return &jsonrpc.RPCResponse{
+							Error: &jsonrpc.RPCError{Message: "bad things happened"},
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				request: GetTransactionRequest{Signature: "sig1"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "transaction not found",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						return &jsonrpc.RPCResponse{
+							Result: json.RawMessage("null"),
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				request: GetTransactionRequest{Signature: "sig1"},
+			},
+			want:    &GetTransactionResponse{},
+			wantErr: false,
+		},
+		{
+			name: "success - json encoding",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						return &jsonrpc.RPCResponse{
+							Result: json.RawMessage(`{
+  "slot": 42,
+  "blockTime": 1700000000,
+  "version": 0,
+  "transaction": {
+    "signatures": ["sig1"],
+    "message": {
+      "header": {"numRequiredSignatures": 1, "numReadonlySignedAccounts": 0, "numReadonlyUnsignedAccounts": 1},
+      "accountKeys": ["payer111", "11111111111111111111111111111111"],
+      "recentBlockhash": "hash111",
+      "instructions": [{"programIdIndex": 1, "accounts": [0, 2], "data": "3Bxs", "stackHeight": null}],
+      "addressTableLookups": [{"accountKey": "table111", "writableIndexes": [3], "readonlyIndexes": []}]
+    }
+  },
+  "meta": ` + metaJSON + `
+}`),
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				request: GetTransactionRequest{
+					Signature:                      "sig1",
+					MaxSupportedTransactionVersion: &v0,
+				},
+			},
+			want: &GetTransactionResponse{
+				ConfirmedTransaction: &ConfirmedTransaction{
+					Slot:      42,
+					BlockTime: &blockTime,
+					Version:   V0TransactionVersion,
+					Transaction: EncodedTransaction{
+						JSON: &TransactionJSON{
+							Signatures: []string{"sig1"},
+							Message: MessageJSON{
+								Header: MessageHeader{
+									NumRequiredSignatures:       1,
+									NumReadonlySignedAccounts:   0,
+									NumReadonlyUnsignedAccounts: 1,
+								},
+								AccountKeys:     []string{"payer111", "11111111111111111111111111111111"},
+								RecentBlockHash: "hash111",
+								Instructions: []CompiledInstructionJSON{
+									{
+										ProgramIDIndex: 1,
+										Accounts:       []int{0, 2},
+										Data:           "3Bxs",
+									},
+								},
+								AddressTableLookups: []AddressTableLookup{
+									{
+										AccountKey:      "table111",
+										WritableIndexes: []int{3},
+										ReadonlyIndexes: []int{},
+									},
+								},
+							},
+						},
+					},
+					Meta: wantMeta,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success - base64 encoding",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						return &jsonrpc.RPCResponse{
+							Result: json.RawMessage(`{"slot": 42, "blockTime": null, "transaction": ["AQID", "base64"], "meta": null}`),
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				request: GetTransactionRequest{
+					Signature: "sig1",
+					Encoding:  Base64Encoding,
+				},
+			},
+			want: &GetTransactionResponse{
+				ConfirmedTransaction: &ConfirmedTransaction{
+					Slot:    42,
+					Version: LegacyTransactionVersion,
+					Transaction: EncodedTransaction{
+						Data: []byte{1, 2, 3},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success - json parsed encoding",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						return &jsonrpc.RPCResponse{
+							Result: json.RawMessage(`{
+  "slot": 42,
+  "version": "legacy",
+  "transaction": {
+    "signatures": ["sig1"],
+    "message": {
+      "accountKeys": [{"pubkey": "payer111", "signer": true, "writable": true, "source": "transaction"}],
+      "recentBlockhash": "hash111",
+      "instructions": [{"programId": "11111111111111111111111111111111", "program": "system", "parsed": {"type": "transfer"}, "stackHeight": null}]
+    }
+  },
+  "meta": {
+    "err": {"InstructionError": [0, {"Custom": 1}]},
+    "fee": 5000,
+    "innerInstructions": [
+      {"index": 0, "instructions": [{"programId": "prog111", "accounts": ["payer111"], "data": "3Bxs", "stackHeight": 2}]}
+    ]
+  }
+}`),
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				request: GetTransactionRequest{
+					Signature: "sig1",
+					Encoding:  JSONParsedEncoding,
+				},
+			},
+			want: &GetTransactionResponse{
+				ConfirmedTransaction: &ConfirmedTransaction{
+					Slot:    42,
+					Version: LegacyTransactionVersion,
+					Transaction: EncodedTransaction{
+						Parsed: &ParsedTransactionJSON{
+							Signatures: []string{"sig1"},
+							Message: ParsedMessageJSON{
+								AccountKeys: []ParsedAccountKey{
+									{
+										PubKey:   "payer111",
+										Signer:   true,
+										Writable: true,
+										Source:   "transaction",
+									},
+								},
+								RecentBlockHash: "hash111",
+								Instructions: []ParsedInstructionJSON{
+									{
+										ProgramID: "11111111111111111111111111111111",
+										Program:   "system",
+										Parsed:    json.RawMessage(`{"type": "transfer"}`),
+									},
+								},
+							},
+						},
+					},
+					Meta: &TransactionMeta{
+						Err: &TransactionError{
+							Type: InstructionErrorTransactionError,
+							InstructionError: &InstructionError{
+								Index: 0,
+								Err:   CustomInstructionError(1),
+							},
+						},
+						Fee: 5000,
+						InnerInstructions: []InnerInstructions{
+							{
+								Index: 0,
+								ParsedInstructions: []ParsedInstructionJSON{
+									{
+										ProgramID:   "prog111",
+										Accounts:    []string{"payer111"},
+										Data:        "3Bxs",
+										StackHeight: &stackHeight,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.fields.jsonRPCClient != nil {
+				tt.fields.jsonRPCClient.T = t
+			}
+			j := &JSONRPCConnection{
+				jsonRPCClient: tt.fields.jsonRPCClient,
+				config:        tt.fields.config,
+			}
+			got, err := j.GetTransaction(tt.args.ctx, tt.args.request)
+			require.Equalf(t, tt.wantErr, err != nil, "error is nil")
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestJSONRPCConnection_GetBlock(t *testing.T) {
+	v0 := V0TransactionVersion
+	blockHeight := uint64(90)
+	blockTime := int64(1700000000)
+	commission := uint8(5)
+
+	type fields struct {
+		jsonRPCClient *jsonrpc.MockClient
+		config        *jsonrpcConnectionConfig
+	}
+	type args struct {
+		ctx     context.Context
+		request GetBlockRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *GetBlockResponse
+		wantErr bool
+	}{
+		{
+			name: "error performing json rpc call",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						require.Equalf(t, "getBlock", method, "method not as expected")
+						require.Equalf(
+							t,
+							[]interface{}{
+								uint64(100),
+								map[string]interface{}{
+									"commitment":                     FinalizedCommitmentLevel,
+									"encoding":                       Base64Encoding,
+									"transactionDetails":             SignaturesTransactionDetails,
+									"rewards":                        false,
+									"maxSupportedTransactionVersion": 0,
+								},
+							},
+							params,
+							"params not as expected",
+						)
+
+						return nil, errors.New("some err")
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				request: GetBlockRequest{
+					Slot:                           100,
+					CommitmentLevel:                FinalizedCommitmentLevel,
+					Encoding:                       Base64Encoding,
+					TransactionDetails:             SignaturesTransactionDetails,
+					ExcludeRewards:                 true,
+					MaxSupportedTransactionVersion: &v0,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error set on rpc response",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						require.Equalf(
+							t,
+							[]interface{}{
+								uint64(100),
+								map[string]interface{}{
+									"commitment":         MaxCommitmentLevel,
+									"encoding":           JSONEncoding,
+									"transactionDetails": FullTransactionDetails,
+									"rewards":            true,
+								},
+							},
+							params,
+							"params not as expected",
+						)
+
+						return &jsonrpc.RPCResponse{
+							Error: &jsonrpc.RPCError{Message: "bad things happened"},
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				request: GetBlockRequest{Slot: 100},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "block not confirmed",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						return &jsonrpc.RPCResponse{
+							Result: json.RawMessage("null"),
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				request: GetBlockRequest{Slot: 100},
+			},
+			want:    &GetBlockResponse{},
+			wantErr: false,
+		},
+		{
+			name: "success - full transaction details",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						return &jsonrpc.RPCResponse{
+							Result: json.RawMessage(`{
+  "blockHeight": 90,
+  "blockTime": 1700000000,
+  "blockhash": "hash100",
+  "previousBlockhash": "hash99",
+  "parentSlot": 99,
+  "transactions": [
+    {"transaction": ["AQID", "base64"], "meta": {"err": null, "fee": 5000}, "version": 0},
+    {"transaction": ["BAU=", "base64"], "meta": null}
+  ],
+  "rewards": [
+    {"pubkey": "validator111", "lamports": 2500, "postBalance": 1002500, "rewardType": "fee", "commission": null},
+    {"pubkey": "vote111", "lamports": -10, "postBalance": 90, "rewardType": "voting", "commission": 5}
+  ]
+}`),
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				request: GetBlockRequest{
+					Slot:     100,
+					Encoding: Base64Encoding,
+				},
+			},
+			want: &GetBlockResponse{
+				Block: &Block{
+					BlockHeight:       &blockHeight,
+					BlockTime:         &blockTime,
+					BlockHash:         "hash100",
+					PreviousBlockHash: "hash99",
+					ParentSlot:        99,
+					Transactions: []BlockTransaction{
+						{
+							Version:     V0TransactionVersion,
+							Transaction: EncodedTransaction{Data: []byte{1, 2, 3}},
+							Meta: &TransactionMeta{
+								Fee: 5000,
+							},
+						},
+						{
+							Version:     LegacyTransactionVersion,
+							Transaction: EncodedTransaction{Data: []byte{4, 5}},
+						},
+					},
+					Rewards: []Reward{
+						{
+							PubKey:      "validator111",
+							Lamports:    2500,
+							PostBalance: 1002500,
+							RewardType:  "fee",
+						},
+						{
+							PubKey:      "vote111",
+							Lamports:    -10,
+							PostBalance: 90,
+							RewardType:  "voting",
+							Commission:  &commission,
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success - signatures transaction details",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						return &jsonrpc.RPCResponse{
+							Result: json.RawMessage(`{
+  "blockHeight": null,
+  "blockTime": null,
+  "blockhash": "hash100",
+  "previousBlockhash": "hash99",
+  "parentSlot": 99,
+  "signatures": ["sig1", "sig2"]
+}`),
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				request: GetBlockRequest{
+					Slot:               100,
+					TransactionDetails: SignaturesTransactionDetails,
+					ExcludeRewards:     true,
+				},
+			},
+			want: &GetBlockResponse{
+				Block: &Block{
+					BlockHash:         "hash100",
+					PreviousBlockHash: "hash99",
+					ParentSlot:        99,
+					Signatures:        []string{"sig1", "sig2"},
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.fields.jsonRPCClient != nil {
+				tt.fields.jsonRPCClient.T = t
+			}
+			j := &JSONRPCConnection{
+				jsonRPCClient: tt.fields.jsonRPCClient,
+				config:        tt.fields.config,
+			}
+			got, err := j.GetBlock(tt.args.ctx, tt.args.request)
+			require.Equalf(t, tt.wantErr, err != nil, "error is nil")
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestJSONRPCConnection_GetBlocks(t *testing.T) {
+	endSlot := uint64(105)
+
+	type fields struct {
+		jsonRPCClient *jsonrpc.MockClient
+		config        *jsonrpcConnectionConfig
+	}
+	type args struct {
+		ctx     context.Context
+		request GetBlocksRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *GetBlocksResponse
+		wantErr bool
+	}{
+		{
+			name: "error performing json rpc call",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						return nil, errors.New("some err")
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				request: GetBlocksRequest{StartSlot: 100},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "success - without end slot",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						require.Equalf(t, "getBlocks", method, "method not as expected")
+						require.Equalf(
+							t,
+							[]interface{}{
+								uint64(100),
+								map[string]interface{}{
+									"commitment": MaxCommitmentLevel,
+								},
+							},
+							params,
+							"params not as expected",
+						)
+
+						return &jsonrpc.RPCResponse{
+							Result: json.RawMessage(`[100, 101, 103]`),
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				request: GetBlocksRequest{StartSlot: 100},
+			},
+			want: &GetBlocksResponse{
+				Slots: []uint64{100, 101, 103},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success - with end slot",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						require.Equalf(
+							t,
+							[]interface{}{
+								uint64(100),
+								uint64(105),
+								map[string]interface{}{
+									"commitment": FinalizedCommitmentLevel,
+								},
+							},
+							params,
+							"params not as expected",
+						)
+
+						return &jsonrpc.RPCResponse{
+							Result: json.RawMessage(`[100, 105]`),
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				request: GetBlocksRequest{
+					StartSlot:       100,
+					EndSlot:         &endSlot,
+					CommitmentLevel: FinalizedCommitmentLevel,
+				},
+			},
+			want: &GetBlocksResponse{
+				Slots: []uint64{100, 105},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.fields.jsonRPCClient != nil {
+				tt.fields.jsonRPCClient.T = t
+			}
+			j := &JSONRPCConnection{
+				jsonRPCClient: tt.fields.jsonRPCClient,
+				config:        tt.fields.config,
+			}
+			got, err := j.GetBlocks(tt.args.ctx, tt.args.request)
+			require.Equalf(t, tt.wantErr, err != nil, "error is nil")
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestJSONRPCConnection_GetBlockTime(t *testing.T) {
+	blockTime := int64(1700000000)
+
+	tests := []struct {
+		name    string
+		result  string
+		want    *GetBlockTimeResponse
+		wantErr bool
+	}{
+		{
+			name:   "block time available",
+			result: "1700000000",
+			want:   &GetBlockTimeResponse{BlockTime: &blockTime},
+		},
+		{
+			name:   "block time not available",
+			result: "null",
+			want:   &GetBlockTimeResponse{},
+		},
+		{
+			name:    "invalid result",
+			result:  `"invalid"`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &JSONRPCConnection{
+				jsonRPCClient: &jsonrpc.MockClient{
+					T: t,
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						require.Equalf(t, "getBlockTime", method, "method not as expected")
+						require.Equalf(t, []interface{}{uint64(100)}, params, "params not as expected")
+
+						return &jsonrpc.RPCResponse{
+							Result: json.RawMessage(tt.result),
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			}
+			got, err := j.GetBlockTime(context.Background(), GetBlockTimeRequest{Slot: 100})
+			require.Equalf(t, tt.wantErr, err != nil, "error is nil")
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestJSONRPCConnection_GetHealth(t *testing.T) {
+	numSlotsBehind := uint64(42)
+
+	tests := []struct {
+		name        string
+		rpcResponse *jsonrpc.RPCResponse
+		wantErr     error
+	}{
+		{
+			name:        "healthy",
+			rpcResponse: &jsonrpc.RPCResponse{Result: json.RawMessage(`"ok"`)},
+		},
+		{
+			name: "unhealthy",
+			rpcResponse: &jsonrpc.RPCResponse{
+				Error: &jsonrpc.RPCError{
+					Code:    -32005,
+					Message: "Node is behind by 42 slots",
+					Data:    map[string]interface{}{"numSlotsBehind": 42},
+				},
+			},
+			wantErr: &NodeUnhealthyError{
+				Message:        "Node is behind by 42 slots",
+				NumSlotsBehind: &numSlotsBehind,
+			},
+		},
+		{
+			name:        "unexpected result",
+			rpcResponse: &jsonrpc.RPCResponse{Result: json.RawMessage(`"behind"`)},
+			wantErr:     ErrUnexpectedResponse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &JSONRPCConnection{
+				jsonRPCClient: &jsonrpc.MockClient{
+					T: t,
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						require.Equalf(t, "getHealth", method, "method not as expected")
+						require.Emptyf(t, params, "params not as expected")
+
+						return tt.rpcResponse, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			}
+			err := j.GetHealth(context.Background())
+			switch wantErr := tt.wantErr.(type) {
+			case nil:
+				require.NoError(t, err)
+			case *NodeUnhealthyError:
+				var nodeUnhealthyError *NodeUnhealthyError
+				require.ErrorAs(t, err, &nodeUnhealthyError)
+				require.Equal(t, wantErr, nodeUnhealthyError)
+			default:
+				require.ErrorIs(t, err, wantErr)
+			}
+		})
+	}
+}
+
+func TestJSONRPCConnection_GetLatestBlockhash(t *testing.T) {
+	type fields struct {
+		jsonRPCClient *jsonrpc.MockClient
+		config        *jsonrpcConnectionConfig
+	}
+	type args struct {
+		ctx     context.Context
+		request GetLatestBlockhashRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *GetLatestBlockhashResponse
+		wantErr bool
+	}{
+		{
+			name: "error performing json rpc call",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						require.Equalf(t, "getLatestBlockhash", method, "method not as expected")
+						require.Equalf(
+							t,
+							[]interface{}{
+								map[string]interface{}{
+									"commitment":     ProcessedCommitmentLevel,
+									"minContextSlot": uint64(10),
+								},
+							},
+							params,
+							"params not as expected",
+						)
+
+						return nil, errors.New("some err")
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				request: GetLatestBlockhashRequest{
+					CommitmentLevel: ProcessedCommitmentLevel,
+					MinContextSlot:  10,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error set on rpc response",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						return &jsonrpc.RPCResponse{
+							Error: &jsonrpc.RPCError{Message: "bad things happened"},
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				request: GetLatestBlockhashRequest{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "success",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						require.Equalf(
+							t,
+							[]interface{}{
+								map[string]interface{}{
+									"commitment": MaxCommitmentLevel,
+								},
+							},
+							params,
+							"params not as expected",
+						)
+
+						return &jsonrpc.RPCResponse{
+							Result: json.RawMessage(`{"context": {"slot": 2792}, "value": {"blockhash": "EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N", "lastValidBlockHeight": 3090}}`),
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				request: GetLatestBlockhashRequest{},
+			},
+			want: &GetLatestBlockhashResponse{
+				Context:              Context{Slot: 2792},
+				BlockHash:            "EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N",
+				LastValidBlockHeight: 3090,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.fields.jsonRPCClient != nil {
+				tt.fields.jsonRPCClient.T = t
+			}
+			j := &JSONRPCConnection{
+				jsonRPCClient: tt.fields.jsonRPCClient,
+				config:        tt.fields.config,
+			}
+			got, err := j.GetLatestBlockhash(tt.args.ctx, tt.args.request)
+			require.Equalf(t, tt.wantErr, err != nil, "error is nil")
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestJSONRPCConnection_IsBlockhashValid(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  string
+		want    *IsBlockhashValidResponse
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			result: `{"context": {"slot": 2483}, "value": true}`,
+			want: &IsBlockhashValidResponse{
+				Context: Context{Slot: 2483},
+				Valid:   true,
+			},
+		},
+		{
+			name:   "not valid",
+			result: `{"context": {"slot": 2483}, "value": false}`,
+			want: &IsBlockhashValidResponse{
+				Context: Context{Slot: 2483},
+			},
+		},
+		{
+			name:    "invalid result",
+			result:  `"invalid"`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &JSONRPCConnection{
+				jsonRPCClient: &jsonrpc.MockClient{
+					T: t,
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						require.Equalf(t, "isBlockhashValid", method, "method not as expected")
+						require.Equalf(
+							t,
+							[]interface{}{
+								"J7rBdM6AecPDEZp8aPq5iPSNKVkU5Q76F3oAV4eW5wsW",
+								map[string]interface{}{
+									"commitment": MaxCommitmentLevel,
+								},
+							},
+							params,
+							"params not as expected",
+						)
+
+						return &jsonrpc.RPCResponse{
+							Result: json.RawMessage(tt.result),
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			}
+			got, err := j.IsBlockhashValid(
+				context.Background(),
+				IsBlockhashValidRequest{BlockHash: "J7rBdM6AecPDEZp8aPq5iPSNKVkU5Q76F3oAV4eW5wsW"},
+			)
+			require.Equalf(t, tt.wantErr, err != nil, "error is nil")
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestJSONRPCConnection_GetFeeForMessage(t *testing.T) {
+	feePayer := MustNewRandomKeypair().PublicKey
+	message := Message{
+		Header:          MessageHeader{NumRequiredSignatures: 1},
+		AccountKeys:     []PublicKey{feePayer},
+		RecentBlockHash: "CSymwgTNX1j3E4qhKfJAUE41nBWEwXufoYryPbkde5RR",
+	}
+	messageData, err := message.ToBytes()
+	require.Nil(t, err)
+	fee := uint64(5000)
+
+	tests := []struct {
+		name    string
+		message Message
+		result  string
+		want    *GetFeeForMessageResponse
+		wantErr bool
+	}{
+		{
+			name:    "invalid message",
+			message: Message{RecentBlockHash: "invalid"},
+			wantErr: true,
+		},
+		{
+			name:    "block hash valid",
+			message: message,
+			result:  `{"context": {"slot": 5068}, "value": 5000}`,
+			want: &GetFeeForMessageResponse{
+				Context: Context{Slot: 5068},
+				Fee:     &fee,
+			},
+		},
+		{
+			name:    "block hash expired",
+			message: message,
+			result:  `{"context": {"slot": 5068}, "value": null}`,
+			want: &GetFeeForMessageResponse{
+				Context: Context{Slot: 5068},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &JSONRPCConnection{
+				jsonRPCClient: &jsonrpc.MockClient{
+					T: t,
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						require.Equalf(t, "getFeeForMessage", method, "method not as expected")
+						require.Equalf(
+							t,
+							[]interface{}{
+								base64.StdEncoding.EncodeToString(messageData),
+								map[string]interface{}{
+									"commitment": MaxCommitmentLevel,
+								},
+							},
+							params,
+							"params not as expected",
+						)
+
+						return &jsonrpc.RPCResponse{
+							Result: json.RawMessage(tt.result),
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			}
+			got, err := j.GetFeeForMessage(context.Background(), GetFeeForMessageRequest{Message: tt.message})
+			require.Equalf(t, tt.wantErr, err != nil, "error is nil")
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestJSONRPCConnection_SimulateTransaction(t *testing.T) {
+	payer := MustNewRandomKeypair()
+	account := MustNewRandomKeypair().PublicKey
+	programID := MustNewRandomKeypair().PublicKey
+	txn := NewTransaction()
+	require.Nil(t, txn.AddInstructions(Instruction{
+		InstructionAccountMeta: []InstructionAccountMeta{
+			{PubKey: account, IsWritable: true},
+		},
+		ProgramIDPubKey: programID,
+		Data:            []byte{1},
+	}))
+	require.Nil(t, txn.SetFeePayer(payer.PublicKey))
+	require.Nil(t, txn.SetRecentBlockHash("CSymwgTNX1j3E4qhKfJAUE41nBWEwXufoYryPbkde5RR"))
+	txnData, err := txn.ToBase64()
+	require.Nil(t, err)
+	unitsConsumed := uint64(2366)
+	stackHeight := 2
+
+	type fields struct {
+		jsonRPCClient *jsonrpc.MockClient
+		config        *jsonrpcConnectionConfig
+	}
+	type args struct {
+		ctx     context.Context
+		request SimulateTransactionRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *SimulateTransactionResponse
+		wantErr bool
+	}{
+		{
+			name: "error performing json rpc call",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						require.Equalf(t, "simulateTransaction", method, "method not as expected")
+						require.Equalf(
+							t,
+							[]interface{}{
+								txnData,
+								map[string]interface{}{
+									"commitment":             ProcessedCommitmentLevel,
+									"sigVerify":              false,
+									"encoding":               Base64Encoding,
+									"replaceRecentBlockhash": true,
+									"accounts": map[string]interface{}{
+										"addresses": []string{account.ToBase58()},
+										"encoding":  Base64Encoding,
+									},
+									"innerInstructions": true,
+									"minContextSlot":    uint64(10),
+								},
+							},
+							params,
+							"params not as expected",
+						)
+
+						return nil, errors.New("some err")
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				request: SimulateTransactionRequest{
+					Transaction:            *txn,
+					ReplaceRecentBlockHash: true,
+					CommitmentLevel:        ProcessedCommitmentLevel,
+					Accounts: &SimulateTransactionAccounts{
+						PublicKeys: []PublicKey{account},
+					},
+					InnerInstructions: true,
+					MinContextSlot:    10,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "error set on rpc response",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						return &jsonrpc.RPCResponse{
+							Error: &jsonrpc.RPCError{Message: "bad things happened"},
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				request: SimulateTransactionRequest{Transaction: *txn},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "success",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						require.Equalf(
+							t,
+							[]interface{}{
+								txnData,
+								map[string]interface{}{
+									"commitment": MaxCommitmentLevel,
+									"sigVerify":  true,
+									"encoding":   Base64Encoding,
+								},
+							},
+							params,
+							"params not as expected",
+						)
+
+						return &jsonrpc.RPCResponse{
+							Result: json.RawMessage(fmt.Sprintf(`{
+  "context": {"slot": 218},
+  "value": {
+    "err": null,
+    "logs": ["Program %[1]s invoke [1]", "Program return: %[1]s AQID", "Program %[1]s success"],
+    "accounts": [{"data": ["AQ==", "base64"], "executable": false, "lamports": 10, "owner": "%[1]s", "rentEpoch": 2}, null],
+    "unitsConsumed": 2366,
+    "returnData": {"programId": "%[1]s", "data": ["AQID", "base64"]},
+    "innerInstructions": null,
+    "replacementBlockhash": null
+  }
+}`, programID.ToBase58())),
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				request: SimulateTransactionRequest{
+					Transaction: *txn,
+					SigVerify:   true,
+				},
+			},
+			want: &SimulateTransactionResponse{
+				Context: Context{Slot: 218},
+				Logs: []string{
+					fmt.Sprintf("Program %s invoke [1]", programID.ToBase58()),
+					fmt.Sprintf("Program return: %s AQID", programID.ToBase58()),
+					fmt.Sprintf("Program %s success", programID.ToBase58()),
+				},
+				Accounts: []AccountInfo{
+					AccountInfoEncodedData{
+						Lamports:  10,
+						Data:      []string{"AQ==", "base64"},
+						Owner:     programID.ToBase58(),
+						RentEpoch: 2,
+					},
+					nil,
+				},
+				UnitsConsumed: &unitsConsumed,
+				ReturnData: &ReturnData{
+					ProgramID: programID.ToBase58(),
+					Data:      []byte{1, 2, 3},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success - transaction failed",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						return &jsonrpc.RPCResponse{
+							Result: json.RawMessage(`{
+  "context": {"slot": 218},
+  "value": {
+    "err": "BlockhashNotFound",
+    "logs": [],
+    "accounts": null,
+    "unitsConsumed": 0,
+    "returnData": null,
+    "innerInstructions": [{"index": 0, "instructions": [{"programIdIndex": 2, "accounts": [1], "data": "2", "stackHeight": 2}]}],
+    "replacementBlockhash": {"blockhash": "EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N", "lastValidBlockHeight": 3090}
+  }
+}`),
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				request: SimulateTransactionRequest{
+					Transaction:            *txn,
+					ReplaceRecentBlockHash: true,
+					InnerInstructions:      true,
+				},
+			},
+			want: &SimulateTransactionResponse{
+				Context:       Context{Slot: 218},
+				Err:           &TransactionError{Type: BlockhashNotFoundTransactionError},
+				Logs:          []string{},
+				UnitsConsumed: new(uint64),
+				InnerInstructions: []InnerInstructions{
+					{
+						Index: 0,
+						Instructions: []CompiledInstructionJSON{
+							{ProgramIDIndex: 2, Accounts: []int{1}, Data: "2", StackHeight: &stackHeight},
+						},
+					},
+				},
+				ReplacementBlockHash: &ReplacementBlockHash{
+					BlockHash:            "EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N",
+					LastValidBlockHeight: 3090,
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.fields.jsonRPCClient != nil {
+				tt.fields.jsonRPCClient.T = t
+			}
+			j := &JSONRPCConnection{
+				jsonRPCClient: tt.fields.jsonRPCClient,
+				config:        tt.fields.config,
+			}
+			got, err := j.SimulateTransaction(tt.args.ctx, tt.args.request)
+			require.Equalf(t, tt.wantErr, err != nil, "error is nil")
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestJSONRPCConnection_GetSignatureStatuses(t *testing.T) {
+	// prepare 300 signatures so that the request is split across 2 calls
+	signatures := make([]string, 300)
+	for i := range signatures {
+		signatures[i] = fmt.Sprintf("sig%d", i)
+	}
+	confirmations := uint64(10)
+
+	type fields struct {
+		jsonRPCClient *jsonrpc.MockClient
+		config        *jsonrpcConnectionConfig
+	}
+	type args struct {
+		ctx     context.Context
+		request GetSignatureStatusesRequest
+	}
+	tests := []struct {
+		name            string
+		fields          fields
+		args            args
+		want            *GetSignatureStatusesResponse
+		wantErr         bool
+		wantInvocations int
+	}{
+		{
+			name: "error performing json rpc call",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						require.Equalf(t, "getSignatureStatuses", method, "method not as expected")
+						require.Equalf(
+							t,
+							[]interface{}{
+								signatures[:1],
+								map[string]interface{}{
+									"searchTransactionHistory": true,
+								},
+							},
+							params,
+							"params not as expected",
+						)
+
+						return nil, errors.New("some err")
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				request: GetSignatureStatusesRequest{
+					Signatures:               signatures[:1],
+					SearchTransactionHistory: true,
+				},
+			},
+			want:            nil,
+			wantErr:         true,
+			wantInvocations: 1,
+		},
+		{
+			name: "error set on rpc response",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						return &jsonrpc.RPCResponse{
+							Error: &jsonrpc.RPCError{Message: "bad things happened"},
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				request: GetSignatureStatusesRequest{Signatures: signatures[:1]},
+			},
+			want:            nil,
+			wantErr:         true,
+			wantInvocations: 1,
+		},
+		{
+			name: "unexpected number of statuses",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						return &jsonrpc.RPCResponse{
+							Result: json.RawMessage(`{"context": {"slot": 82}, "value": []}`),
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				request: GetSignatureStatusesRequest{Signatures: signatures[:1]},
+			},
+			want:            nil,
+			wantErr:         true,
+			wantInvocations: 1,
+		},
+		{
+			name: "success - split across calls",
+			fields: fields{
+				jsonRPCClient: &jsonrpc.MockClient{
+					CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+						requestSignatures := params[0].([]string)
+						values := make([]json.RawMessage, len(requestSignatures))
+						for i := range values {
+							values[i] = json.RawMessage("null")
+						}
+						slot := 82
+						if m.CallParamArrayFuncInvocations == 1 {
+							require.Len(t, requestSignatures, MaxGetSignatureStatusesSignatures)
+							values[0] = json.RawMessage(`{"slot": 72, "confirmations": 10, "err": null, "status": {"Ok": null}, "confirmationStatus": "confirmed"}`)
+						} else {
+							require.Equal(t, signatures[MaxGetSignatureStatusesSignatures:], requestSignatures)
+							values[len(values)-1] = json.RawMessage(`{"slot": 48, "confirmations": null, "err": "AccountInUse", "status": {"Err": "AccountInUse"}, "confirmationStatus": "finalized"}`)
+							slot = 81
+						}
+						result, err := json.Marshal(map[string]interface{}{
+							"context": map[string]interface{}{"slot": slot},
+							"value":   values,
+						})
+						require.Nil(t, err)
+
+						return &jsonrpc.RPCResponse{
+							Result: result,
+						}, nil
+					},
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: MaxCommitmentLevel,
+				},
+			},
+			args: args{
+				ctx:     context.Background(),
+				request: GetSignatureStatusesRequest{Signatures: signatures},
+			},
+			want: func() *GetSignatureStatusesResponse {
+				statuses := make([]*SignatureStatus, len(signatures))
+				statuses[0] = &SignatureStatus{
+					Slot:               72,
+					Confirmations:      &confirmations,
+					ConfirmationStatus: ConfirmedCommitmentLevel,
+				}
+				statuses[len(statuses)-1] = &SignatureStatus{
+					Slot:               48,
+					Err:                &TransactionError{Type: AccountInUseTransactionError},
+					ConfirmationStatus: FinalizedCommitmentLevel,
+				}
+				return &GetSignatureStatusesResponse{
+					Context:  Context{Slot: 81},
+					Statuses: statuses,
+				}
+			}(),
+			wantErr:         false,
+			wantInvocations: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.fields.jsonRPCClient != nil {
+				tt.fields.jsonRPCClient.T = t
+			}
+			j := &JSONRPCConnection{
+				jsonRPCClient: tt.fields.jsonRPCClient,
+				config:        tt.fields.config,
+			}
+			got, err := j.GetSignatureStatuses(tt.args.ctx, tt.args.request)
+			require.Equalf(t, tt.wantErr, err != nil, "error is nil")
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantInvocations, tt.fields.jsonRPCClient.CallParamArrayFuncInvocations)
+		})
+	}
+}
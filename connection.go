@@ -13,13 +13,84 @@ type Connection interface {
 	// GetAccountInfo returns all the account info for the specified PublicKey
 	GetAccountInfo(ctx context.Context, request GetAccountInfoRequest) (*GetAccountInfoResponse, error)
 
+	// GetMultipleAccounts returns the account info for each of the specified PublicKeys,
+	// in the order given. Requests for more than MaxGetMultipleAccountsPublicKeys
+	// PublicKeys are split across multiple rpc calls.
+	GetMultipleAccounts(ctx context.Context, request GetMultipleAccountsRequest) (*GetMultipleAccountsResponse, error)
+
+	// GetProgramAccounts returns the accounts owned by the specified program,
+	// optionally filtered by ProgramAccountsFilters.
+	GetProgramAccounts(ctx context.Context, request GetProgramAccountsRequest) (*GetProgramAccountsResponse, error)
+
 	// GetBalance returns the balance of the account of provided PublicKey
 	GetBalance(ctx context.Context, request GetBalanceRequest) (*GetBalanceResponse, error)
 
 	// GetRecentBlockHash returns a recent block hash from the ledger, and a FeeCalculator that
 	// can be used to calculate the cost of submitting a Transaction.
+	//
+	// Deprecated: getRecentBlockhash has been removed from current validators.
+	// Use GetLatestBlockhash, and GetFeeCalculator or GetFeeForMessage to determine fees.
 	GetRecentBlockHash(ctx context.Context, request GetRecentBlockHashRequest) (*GetRecentBlockHashResponse, error)
 
+	// GetLatestBlockhash returns the latest block hash from the ledger
+	// and the last block height at which it is valid.
+	GetLatestBlockhash(ctx context.Context, request GetLatestBlockhashRequest) (*GetLatestBlockhashResponse, error)
+
+	// IsBlockhashValid returns whether the given block hash is still valid
+	IsBlockhashValid(ctx context.Context, request IsBlockhashValidRequest) (*IsBlockhashValidResponse, error)
+
+	// GetFeeForMessage returns the fee the network will charge to process the given Message
+	GetFeeForMessage(ctx context.Context, request GetFeeForMessageRequest) (*GetFeeForMessageResponse, error)
+
+	// GetMinimumBalanceForRentExemption returns the minimum balance required
+	// to make an account with the given data length rent exempt.
+	GetMinimumBalanceForRentExemption(ctx context.Context, request GetMinimumBalanceForRentExemptionRequest) (*GetMinimumBalanceForRentExemptionResponse, error)
+
+	// GetSignaturesForAddress returns signatures of confirmed transactions that include the
+	// given address in their accounts, newest first. Use a SignaturesForAddressIterator
+	// to page backwards through the entire transaction history of an address.
+	GetSignaturesForAddress(ctx context.Context, request GetSignaturesForAddressRequest) (*GetSignaturesForAddressResponse, error)
+
+	// GetTransaction returns the details of a confirmed transaction
+	GetTransaction(ctx context.Context, request GetTransactionRequest) (*GetTransactionResponse, error)
+
+	// GetHealth returns nil if the node is healthy. If the node is behind
+	// the cluster then a NodeUnhealthyError is returned.
+	GetHealth(ctx context.Context) error
+
+	// GetSlot returns the slot that has reached the given or default CommitmentLevel
+	GetSlot(ctx context.Context, request GetSlotRequest) (*GetSlotResponse, error)
+
+	// GetBlockHeight returns the current block height of the node
+	GetBlockHeight(ctx context.Context, request GetBlockHeightRequest) (*GetBlockHeightResponse, error)
+
+	// GetBlock returns identity and transaction information about a confirmed block
+	GetBlock(ctx context.Context, request GetBlockRequest) (*GetBlockResponse, error)
+
+	// GetBlocks returns the slots of confirmed blocks between two slots
+	GetBlocks(ctx context.Context, request GetBlocksRequest) (*GetBlocksResponse, error)
+
+	// GetBlocksWithLimit returns the slots of a limited number of confirmed blocks starting at a slot
+	GetBlocksWithLimit(ctx context.Context, request GetBlocksWithLimitRequest) (*GetBlocksWithLimitResponse, error)
+
+	// GetBlockTime returns the estimated production time of a block
+	GetBlockTime(ctx context.Context, request GetBlockTimeRequest) (*GetBlockTimeResponse, error)
+
+	// GetFirstAvailableBlock returns the slot of the lowest
+	// confirmed block that has not been purged from the ledger
+	GetFirstAvailableBlock(ctx context.Context) (*GetFirstAvailableBlockResponse, error)
+
+	// MinimumLedgerSlot returns the lowest slot that the node has information about in its ledger
+	MinimumLedgerSlot(ctx context.Context) (*MinimumLedgerSlotResponse, error)
+
+	// GetSignatureStatuses returns the statuses of the given transaction signatures. Requests
+	// for more than MaxGetSignatureStatusesSignatures signatures are split across multiple rpc calls.
+	GetSignatureStatuses(ctx context.Context, request GetSignatureStatusesRequest) (*GetSignatureStatusesResponse, error)
+
+	// SimulateTransaction simulates sending a transaction, returning the logs, compute units
+	// consumed, return data and optionally the resulting state of the requested accounts.
+	SimulateTransaction(ctx context.Context, request SimulateTransactionRequest) (*SimulateTransactionResponse, error)
+
 	// SendTransaction submits a signed transaction to the cluster for processing.
 	// This method does not alter the transaction in any way, it relays the
 	// transaction created by clients to the node as-is.
@@ -37,6 +108,72 @@ type GetAccountInfoResponse struct {
 	AccountInfo AccountInfo
 }
 
+// MaxGetMultipleAccountsPublicKeys is the maximum number of PublicKeys
+// for which account info can be retrieved in a single getMultipleAccounts call
+const MaxGetMultipleAccountsPublicKeys = 100
+
+// DataSlice limits the account data returned to Length bytes from Offset.
+// Only available for Base58Encoding, Base64Encoding and Base64PlusZSTDEncoding.
+type DataSlice struct {
+	Offset uint64 `json:"offset"`
+	Length uint64 `json:"length"`
+}
+
+type GetMultipleAccountsRequest struct {
+	PublicKeys      []PublicKey
+	CommitmentLevel CommitmentLevel
+	Encoding        Encoding
+
+	// DataSlice optionally limits the account data returned
+	DataSlice *DataSlice
+
+	// MinContextSlot optionally sets the minimum slot at which the request may be evaluated
+	MinContextSlot uint64
+}
+
+type GetMultipleAccountsResponse struct {
+	// Context is the context of the earliest evaluated rpc call
+	// if the request was split across multiple calls
+	Context Context
+
+	// AccountInfos holds the AccountInfo of each requested PublicKey, in the
+	// order requested. The AccountInfo of accounts that do not exist is nil.
+	AccountInfos []AccountInfo
+}
+
+type GetProgramAccountsRequest struct {
+	// ProgramID is the program for which owned accounts are returned
+	ProgramID       PublicKey
+	CommitmentLevel CommitmentLevel
+	Encoding        Encoding
+
+	// DataSlice optionally limits the account data returned
+	DataSlice *DataSlice
+
+	// Filters optionally filter the accounts returned.
+	// Only accounts that pass all filters are returned.
+	Filters []ProgramAccountsFilter
+
+	// MinContextSlot optionally sets the minimum slot at which the request may be evaluated
+	MinContextSlot uint64
+
+	// WithContext requests that the Context of the response be returned
+	WithContext bool
+}
+
+type GetProgramAccountsResponse struct {
+	// Context is only set if WithContext was requested
+	Context Context
+
+	ProgramAccounts []ProgramAccount
+}
+
+// ProgramAccount is an account owned by a program
+type ProgramAccount struct {
+	PublicKey   PublicKey
+	AccountInfo AccountInfo
+}
+
 type GetBalanceRequest struct {
 	PublicKey       PublicKey
 	CommitmentLevel CommitmentLevel
@@ -61,6 +198,64 @@ type GetRecentBlockHashResponse struct {
 	FeeCalculator FeeCalculator
 }
 
+type GetLatestBlockhashRequest struct {
+	CommitmentLevel CommitmentLevel
+
+	// MinContextSlot optionally sets the minimum slot at which the request may be evaluated
+	MinContextSlot uint64
+}
+
+type GetLatestBlockhashResponse struct {
+	Context Context
+	// BlockHash is a base58 encoded string
+	BlockHash string
+	// LastValidBlockHeight is the last block height at
+	// which a transaction using BlockHash will be processed
+	LastValidBlockHeight uint64
+}
+
+type IsBlockhashValidRequest struct {
+	// BlockHash is a base58 encoded string
+	BlockHash       string
+	CommitmentLevel CommitmentLevel
+
+	// MinContextSlot optionally sets the minimum slot at which the request may be evaluated
+	MinContextSlot uint64
+}
+
+type IsBlockhashValidResponse struct {
+	Context Context
+	Valid   bool
+}
+
+type GetFeeForMessageRequest struct {
+	// Message is the compiled message for which the fee is determined.
+	// Its RecentBlockHash must still be valid.
+	Message         Message
+	CommitmentLevel CommitmentLevel
+
+	// MinContextSlot optionally sets the minimum slot at which the request may be evaluated
+	MinContextSlot uint64
+}
+
+type GetFeeForMessageResponse struct {
+	Context Context
+	// Fee is the fee in lamports for the Message. It is nil if
+	// the RecentBlockHash of the Message is no longer valid.
+	Fee *uint64
+}
+
+type GetMinimumBalanceForRentExemptionRequest struct {
+	// DataLength is the length in bytes of the account data
+	DataLength      uint64
+	CommitmentLevel CommitmentLevel
+}
+
+type GetMinimumBalanceForRentExemptionResponse struct {
+	// Lamports is the minimum balance required for rent exemption
+	Lamports uint64
+}
+
 type SendTransactionRequest struct {
 	// Transaction is the transaction being sent.
 	// Note: it should already be signed.
@@ -93,3 +288,290 @@ type SendTransactionResponse struct {
 	// in the transaction, as base58 encoded string - aka. transaction id
 	TransactionID string
 }
+
+// MaxGetSignaturesForAddressLimit is the maximum number of signatures
+// that can be returned by a single getSignaturesForAddress call
+const MaxGetSignaturesForAddressLimit = 1000
+
+type GetSignaturesForAddressRequest struct {
+	// Address is the account for which transaction signatures are returned
+	Address         PublicKey
+	CommitmentLevel CommitmentLevel
+
+	// Before optionally starts the search backwards from before this base58 encoded signature.
+	// If not provided the search starts from the latest confirmed block.
+	Before string
+
+	// Until optionally stops the search at this base58 encoded signature,
+	// which is not included in the result
+	Until string
+
+	// Limit is the maximum number of signatures to return.
+	// Default value if not specified is MaxGetSignaturesForAddressLimit.
+	Limit uint
+
+	// MinContextSlot optionally sets the minimum slot at which the request may be evaluated
+	MinContextSlot uint64
+}
+
+type GetSignaturesForAddressResponse struct {
+	// Signatures are ordered from newest to oldest transaction
+	Signatures []SignatureInfo
+}
+
+// SignatureInfo is the signature and status of a confirmed transaction
+type SignatureInfo struct {
+	// Signature is the base58 encoded transaction signature
+	Signature string `json:"signature"`
+
+	// Slot is the slot in which the transaction was processed
+	Slot uint64 `json:"slot"`
+
+	// Err is the error with which the transaction failed. nil if it succeeded.
+	Err *TransactionError `json:"err"`
+
+	// Memo is the memo associated with the transaction, nil if there is none
+	Memo *string `json:"memo"`
+
+	// BlockTime is the estimated production time of the block in which the transaction
+	// was processed, as a unix timestamp. nil if not available.
+	BlockTime *int64 `json:"blockTime"`
+
+	// ConfirmationStatus is the cluster confirmation status of the transaction
+	ConfirmationStatus CommitmentLevel `json:"confirmationStatus"`
+}
+
+type GetTransactionRequest struct {
+	// Signature is the base58 encoded signature of the transaction
+	Signature       string
+	CommitmentLevel CommitmentLevel
+
+	// Encoding is the Encoding of the returned transaction. One of JSONEncoding,
+	// JSONParsedEncoding, Base58Encoding or Base64Encoding.
+	// Default value if not specified is JSONEncoding.
+	Encoding Encoding
+
+	// MaxSupportedTransactionVersion is the highest TransactionVersion to return.
+	// If not provided only legacy transactions are returned, and the request
+	// fails if the transaction has a higher version.
+	MaxSupportedTransactionVersion *TransactionVersion
+}
+
+type GetTransactionResponse struct {
+	// ConfirmedTransaction is nil if the transaction
+	// was not found or is not yet confirmed
+	ConfirmedTransaction *ConfirmedTransaction
+}
+
+type GetSlotRequest struct {
+	CommitmentLevel CommitmentLevel
+
+	// MinContextSlot optionally sets the minimum slot at which the request may be evaluated
+	MinContextSlot uint64
+}
+
+type GetSlotResponse struct {
+	Slot uint64
+}
+
+type GetBlockHeightRequest struct {
+	CommitmentLevel CommitmentLevel
+
+	// MinContextSlot optionally sets the minimum slot at which the request may be evaluated
+	MinContextSlot uint64
+}
+
+type GetBlockHeightResponse struct {
+	BlockHeight uint64
+}
+
+type GetBlockRequest struct {
+	// Slot is the slot of the block
+	Slot            uint64
+	CommitmentLevel CommitmentLevel
+
+	// Encoding is the Encoding of the returned transactions. One of JSONEncoding,
+	// JSONParsedEncoding, Base58Encoding or Base64Encoding.
+	// Default value if not specified is JSONEncoding.
+	Encoding Encoding
+
+	// TransactionDetails is the level of transaction detail to return.
+	// Default value if not specified is FullTransactionDetails.
+	TransactionDetails TransactionDetails
+
+	// ExcludeRewards can be set to true to exclude rewards from the returned block
+	ExcludeRewards bool
+
+	// MaxSupportedTransactionVersion is the highest TransactionVersion to return.
+	// If not provided only legacy transactions are returned, and the request
+	// fails if the block contains a transaction with a higher version.
+	MaxSupportedTransactionVersion *TransactionVersion
+}
+
+type GetBlockResponse struct {
+	// Block is nil if the block is not yet confirmed
+	Block *Block
+}
+
+type GetBlocksRequest struct {
+	StartSlot uint64
+
+	// EndSlot is optional. If not provided blocks up
+	// to the latest confirmed block are returned.
+	EndSlot         *uint64
+	CommitmentLevel CommitmentLevel
+}
+
+type GetBlocksResponse struct {
+	// Slots are the slots of the confirmed blocks in ascending order
+	Slots []uint64
+}
+
+type GetBlocksWithLimitRequest struct {
+	StartSlot uint64
+
+	// Limit is the maximum number of blocks to return
+	Limit           uint64
+	CommitmentLevel CommitmentLevel
+}
+
+type GetBlocksWithLimitResponse struct {
+	// Slots are the slots of the confirmed blocks in ascending order
+	Slots []uint64
+}
+
+type GetBlockTimeRequest struct {
+	// Slot is the slot of the block
+	Slot uint64
+}
+
+type GetBlockTimeResponse struct {
+	// BlockTime is the estimated production time of the block
+	// as a unix timestamp. nil if not available.
+	BlockTime *int64
+}
+
+type GetFirstAvailableBlockResponse struct {
+	Slot uint64
+}
+
+type MinimumLedgerSlotResponse struct {
+	Slot uint64
+}
+
+type SimulateTransactionRequest struct {
+	// Transaction is the transaction being simulated.
+	// It must be signed if SigVerify is set.
+	Transaction Transaction
+
+	// SigVerify can be set to true to verify the transaction signatures.
+	// Conflicts with ReplaceRecentBlockHash.
+	SigVerify bool
+
+	// ReplaceRecentBlockHash can be set to true to replace the recent block hash
+	// of the transaction with the latest block hash. Conflicts with SigVerify.
+	ReplaceRecentBlockHash bool
+
+	CommitmentLevel CommitmentLevel
+
+	// Encoding is the Encoding used for the transaction data.
+	// Either Base58Encoding (slow, DEPRECATED), or Base64Encoding.
+	// Default value if not specified is Base64Encoding.
+	Encoding Encoding
+
+	// Accounts optionally requests the state of accounts after the simulation
+	Accounts *SimulateTransactionAccounts
+
+	// InnerInstructions can be set to true to return
+	// the inner instructions invoked during the simulation
+	InnerInstructions bool
+
+	// MinContextSlot optionally sets the minimum slot at which the request may be evaluated
+	MinContextSlot uint64
+}
+
+// SimulateTransactionAccounts are the accounts for
+// which state is returned after a simulation
+type SimulateTransactionAccounts struct {
+	PublicKeys []PublicKey
+
+	// Encoding is the Encoding of the returned account data.
+	// Default value if not specified is Base64Encoding.
+	Encoding Encoding
+}
+
+type SimulateTransactionResponse struct {
+	Context Context
+
+	// Err is the error with which the transaction failed. nil if it succeeded.
+	Err *TransactionError
+
+	// Logs are the messages logged during the simulation.
+	// nil if the transaction failed before execution.
+	Logs []string
+
+	// Accounts holds the AccountInfo of each requested account after the
+	// simulation, in the order requested. The AccountInfo of accounts that do not exist is nil.
+	Accounts []AccountInfo
+
+	// UnitsConsumed is the number of compute units consumed during the simulation, if available
+	UnitsConsumed *uint64
+
+	// ReturnData is the most recent return data set by a program during the simulation, if any
+	ReturnData *ReturnData
+
+	// InnerInstructions are the instructions invoked by programs during
+	// the simulation, set if InnerInstructions was requested
+	InnerInstructions []InnerInstructions
+
+	// ReplacementBlockHash is the block hash used for the simulation,
+	// set if ReplaceRecentBlockHash was requested
+	ReplacementBlockHash *ReplacementBlockHash
+}
+
+// ReplacementBlockHash is the block hash used in place
+// of the recent block hash of a simulated transaction
+type ReplacementBlockHash struct {
+	// BlockHash is a base58 encoded string
+	BlockHash            string `json:"blockhash"`
+	LastValidBlockHeight uint64 `json:"lastValidBlockHeight"`
+}
+
+// MaxGetSignatureStatusesSignatures is the maximum number of signatures
+// for which statuses can be retrieved in a single getSignatureStatuses call
+const MaxGetSignatureStatusesSignatures = 256
+
+type GetSignatureStatusesRequest struct {
+	// Signatures are the base58 encoded transaction signatures
+	Signatures []string
+
+	// SearchTransactionHistory can be set to true to search the ledger for signatures
+	// not found in the recent status cache. Only the recent status cache is searched otherwise.
+	SearchTransactionHistory bool
+}
+
+type GetSignatureStatusesResponse struct {
+	// Context is the context of the earliest evaluated rpc call
+	// if the request was split across multiple calls
+	Context Context
+
+	// Statuses holds the SignatureStatus of each requested signature, in
+	// the order requested. The status of unknown signatures is nil.
+	Statuses []*SignatureStatus
+}
+
+// SignatureStatus is the status of a transaction
+type SignatureStatus struct {
+	// Slot is the slot in which the transaction was processed
+	Slot uint64 `json:"slot"`
+
+	// Confirmations is the number of blocks since confirmation of the
+	// transaction, nil if the transaction has been finalized
+	Confirmations *uint64 `json:"confirmations"`
+
+	// Err is the error with which the transaction failed. nil if it succeeded.
+	Err *TransactionError `json:"err"`
+
+	// ConfirmationStatus is the cluster confirmation status of the transaction
+	ConfirmationStatus CommitmentLevel `json:"confirmationStatus"`
+}
@@ -0,0 +1,11 @@
+package ed25519Program
+
+import "github.com/BRBussy/solgo/internal/pkg/precompile"
+
+var (
+	ErrNoSignedMessages        = precompile.ErrNoSignedMessages
+	ErrTooManySignedMessages   = precompile.ErrTooManySignedMessages
+	ErrUnexpectedProgramID     = precompile.ErrUnexpectedProgramID
+	ErrInvalidInstructionData  = precompile.ErrInvalidInstructionData
+	ErrInvalidInstructionIndex = precompile.ErrInvalidInstructionIndex
+)
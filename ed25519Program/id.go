@@ -0,0 +1,10 @@
+// Package ed25519Program provides functions for constructing and parsing instructions
+// for the Solana ed25519 signature verification precompile.
+// See program definition here:
+// https://github.com/solana-labs/solana/blob/v1.16.0/sdk/src/ed25519_instruction.rs
+package ed25519Program
+
+import solana "github.com/BRBussy/solgo"
+
+// ID is the Solana ed25519 signature verification program ID
+var ID = solana.NewPublicKeyFromBase58String("Ed25519SigVerify111111111111111111111111111")
@@ -0,0 +1,137 @@
+package ed25519Program
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"github.com/BRBussy/solgo"
+	"github.com/BRBussy/solgo/internal/pkg/precompile"
+	"math"
+)
+
+// CurrentInstructionIndex is the instruction index used in SignatureOffsets
+// to refer to data held in the verify instruction itself.
+const CurrentInstructionIndex = math.MaxUint16
+
+// layout is the instruction data layout of the ed25519 program, in which the number
+// of signed messages is followed by a byte of padding and then the SignatureOffsets
+var layout = precompile.Layout{
+	ProgramID:    ID,
+	OffsetsStart: 2,
+	OffsetsSize:  14,
+}
+
+// SignatureOffsets locates the signature, public key and message of a signed message
+// in the data of an instruction in the transaction.
+type SignatureOffsets struct {
+	SignatureOffset           uint16
+	SignatureInstructionIndex uint16
+	PublicKeyOffset           uint16
+	PublicKeyInstructionIndex uint16
+	MessageDataOffset         uint16
+	MessageDataSize           uint16
+	MessageInstructionIndex   uint16
+}
+
+// SignedMessage is a message along with its ed25519 signature and the
+// PublicKey of the signer.
+type SignedMessage struct {
+	PublicKey solana.PublicKey
+	Message   []byte
+	Signature solana.Signature
+}
+
+// NewSignedMessage signs the given message with the given PrivateKey
+func NewSignedMessage(privateKey solana.PrivateKey, message []byte) SignedMessage {
+	var signature solana.Signature
+	copy(signature[:], ed25519.Sign(privateKey.PrivateKey, message))
+	return SignedMessage{
+		PublicKey: privateKey.PublicKey(),
+		Message:   message,
+		Signature: signature,
+	}
+}
+
+// Verify returns true if the Signature is a valid signature of Message by PublicKey
+func (s SignedMessage) Verify() bool {
+	return len(s.PublicKey.PublicKey) == ed25519.PublicKeySize &&
+		ed25519.Verify(s.PublicKey.PublicKey, s.Message, s.Signature.Bytes())
+}
+
+type VerifySignaturesParams struct {
+	// SignedMessages are the signed messages to be verified
+	SignedMessages []SignedMessage
+}
+
+// VerifySignatures creates a Solana ed25519 program Instruction which will cause the
+// transaction containing it to fail unless all of the SignedMessages are valid.
+// The public keys, signatures and messages are all held in the data of the Instruction.
+func VerifySignatures(params VerifySignaturesParams) ([]solana.Instruction, error) {
+	signedMessages := make([][][]byte, len(params.SignedMessages))
+	for i, signedMessage := range params.SignedMessages {
+		if len(signedMessage.PublicKey.PublicKey) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("public key of %d bytes: %w", len(signedMessage.PublicKey.PublicKey), ErrInvalidInstructionData)
+		}
+		signedMessages[i] = [][]byte{signedMessage.PublicKey.PublicKey, signedMessage.Signature.Bytes(), signedMessage.Message}
+	}
+	return layout.NewInstruction(signedMessages, func(i int, partOffsets []uint16) interface{} {
+		return SignatureOffsets{
+			SignatureOffset:           partOffsets[1],
+			SignatureInstructionIndex: CurrentInstructionIndex,
+			PublicKeyOffset:           partOffsets[0],
+			PublicKeyInstructionIndex: CurrentInstructionIndex,
+			MessageDataOffset:         partOffsets[2],
+			MessageDataSize:           uint16(len(signedMessages[i][2])),
+			MessageInstructionIndex:   CurrentInstructionIndex,
+		}
+	})
+}
+
+// ParseVerifySignatures extracts the SignedMessages from the ed25519 program Instruction at
+// the given index in the given Instructions of a transaction. SignatureOffsets may refer to data
+// held in the verify instruction itself or in any other instruction in the transaction.
+//
+// Note that the SignedMessages are not verified. SignedMessage.Verify can be used to do so.
+func ParseVerifySignatures(instructions []solana.Instruction, index int) ([]SignedMessage, error) {
+	// read signature offsets
+	var offsets []SignatureOffsets
+	if err := layout.ReadOffsets(instructions, index, func(n int) interface{} {
+		offsets = make([]SignatureOffsets, n)
+		return offsets
+	}); err != nil {
+		return nil, err
+	}
+
+	// getData gets the data at the given offset in the referenced instruction
+	getData := func(instructionIndex uint16, offset uint16, size int) ([]byte, error) {
+		if instructionIndex == CurrentInstructionIndex {
+			return precompile.Data(instructions, index, offset, size)
+		}
+		return precompile.Data(instructions, int(instructionIndex), offset, size)
+	}
+
+	// extract signed messages
+	signedMessages := make([]SignedMessage, 0, len(offsets))
+	for _, offset := range offsets {
+		publicKey, err := getData(offset.PublicKeyInstructionIndex, offset.PublicKeyOffset, ed25519.PublicKeySize)
+		if err != nil {
+			return nil, fmt.Errorf("error getting public key: %w", err)
+		}
+		signature, err := getData(offset.SignatureInstructionIndex, offset.SignatureOffset, ed25519.SignatureSize)
+		if err != nil {
+			return nil, fmt.Errorf("error getting signature: %w", err)
+		}
+		message, err := getData(offset.MessageInstructionIndex, offset.MessageDataOffset, int(offset.MessageDataSize))
+		if err != nil {
+			return nil, fmt.Errorf("error getting message: %w", err)
+		}
+
+		signedMessage := SignedMessage{
+			PublicKey: solana.PublicKey{PublicKey: append(ed25519.PublicKey{}, publicKey...)},
+			Message:   append([]byte{}, message...),
+		}
+		copy(signedMessage.Signature[:], signature)
+		signedMessages = append(signedMessages, signedMessage)
+	}
+
+	return signedMessages, nil
+}
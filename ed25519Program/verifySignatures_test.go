@@ -0,0 +1,129 @@
+package ed25519Program
+
+import (
+	"github.com/BRBussy/solgo"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestVerifySignatures(t *testing.T) {
+	signedMessages := []SignedMessage{
+		NewSignedMessage(solana.MustNewRandomKeypair().PrivateKey, []byte("first message")),
+		NewSignedMessage(solana.MustNewRandomKeypair().PrivateKey, []byte("second")),
+	}
+
+	instructions, err := VerifySignatures(VerifySignaturesParams{SignedMessages: signedMessages})
+	require.Nil(t, err)
+	require.Len(t, instructions, 1)
+
+	// check header and first signature offsets
+	data := instructions[0].Data
+	require.Equal(t, []byte{2, 0}, data[:2])
+	require.Equal(
+		t,
+		[]byte{
+			62, 0, 0xff, 0xff, // signature
+			30, 0, 0xff, 0xff, // public key
+			126, 0, 13, 0, 0xff, 0xff, // message
+		},
+		data[2:16],
+	)
+	require.Equal(t, signedMessages[0].PublicKey.PublicKey, solana.PublicKey{PublicKey: data[30:62]}.PublicKey)
+
+	// parse signed messages back out
+	parsed, err := ParseVerifySignatures(instructions, 0)
+	require.Nil(t, err)
+	require.Equal(t, signedMessages, parsed)
+	for _, signedMessage := range parsed {
+		require.True(t, signedMessage.Verify())
+	}
+
+	// tampered message should fail verification
+	parsed[1].Message[0] = 'S'
+	require.False(t, parsed[1].Verify())
+
+	_, err = VerifySignatures(VerifySignaturesParams{})
+	require.ErrorIs(t, err, ErrNoSignedMessages)
+}
+
+func TestParseVerifySignatures(t *testing.T) {
+	signedMessage := NewSignedMessage(solana.MustNewRandomKeypair().PrivateKey, []byte("message"))
+
+	// data held in another instruction
+	otherInstruction := solana.Instruction{
+		ProgramIDPubKey: solana.MustNewRandomKeypair().PublicKey,
+		Data: append(
+			append(append([]byte{0xaa}, signedMessage.PublicKey.PublicKey...), signedMessage.Signature.Bytes()...),
+			signedMessage.Message...,
+		),
+	}
+	verifyInstruction := solana.Instruction{
+		ProgramIDPubKey: ID,
+		Data: []byte{
+			1, 0,
+			33, 0, 0, 0, // signature
+			1, 0, 0, 0, // public key
+			97, 0, 7, 0, 0, 0, // message
+		},
+	}
+
+	tests := []struct {
+		name         string
+		instructions []solana.Instruction
+		index        int
+		want         []SignedMessage
+		wantErr      error
+	}{
+		{
+			name:         "data in other instruction",
+			instructions: []solana.Instruction{otherInstruction, verifyInstruction},
+			index:        1,
+			want:         []SignedMessage{signedMessage},
+		},
+		{
+			name: "referenced instruction does not exist",
+			instructions: []solana.Instruction{
+				{
+					ProgramIDPubKey: ID,
+					Data:            append(append([]byte{}, verifyInstruction.Data[:8]...), append([]byte{5, 0}, verifyInstruction.Data[10:]...)...),
+				},
+			},
+			index:   0,
+			wantErr: ErrInvalidInstructionIndex,
+		},
+		{
+			name:         "referenced data out of range",
+			instructions: []solana.Instruction{{ProgramIDPubKey: ID}, verifyInstruction},
+			index:        1,
+			wantErr:      ErrInvalidInstructionData,
+		},
+		{
+			name:         "not an ed25519 program instruction",
+			instructions: []solana.Instruction{otherInstruction},
+			index:        0,
+			wantErr:      ErrUnexpectedProgramID,
+		},
+		{
+			name:         "index out of range",
+			instructions: []solana.Instruction{verifyInstruction},
+			index:        1,
+			wantErr:      ErrInvalidInstructionIndex,
+		},
+		{
+			name:         "truncated offsets",
+			instructions: []solana.Instruction{{ProgramIDPubKey: ID, Data: verifyInstruction.Data[:10]}},
+			index:        0,
+			wantErr:      ErrInvalidInstructionData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseVerifySignatures(tt.instructions, tt.index)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, got)
+			for _, signedMessage := range got {
+				require.True(t, signedMessage.Verify())
+			}
+		})
+	}
+}
@@ -16,6 +16,10 @@ var (
 	// Zstandard and base64 encodes the result.
 	Base64PlusZSTDEncoding Encoding = "base64+zstd"
 
+	// JSONEncoding returns transactions as JSON with instructions
+	// in their compiled form. Only applicable to transactions.
+	JSONEncoding Encoding = "json"
+
 	// JSONParsedEncoding encoding attempts to use program-specific state
 	// parsers to return more human-readable and explicit account state data.
 	// If "jsonParsed" is requested but a parser cannot be found, the field
@@ -1,8 +1,33 @@
 package solana
 
-import "errors"
+import (
+	"errors"
+	"github.com/BRBussy/solgo/internal/pkg/jsonrpc"
+)
 
 var (
-	ErrUnexpectedNetwork        = errors.New("unexpected network")
-	ErrTransactionAlreadySigned = errors.New("transaction already signed")
+	ErrUnexpectedNetwork          = errors.New("unexpected network")
+	ErrTransactionAlreadySigned   = errors.New("transaction already signed")
+	ErrInvalidSeeds               = errors.New("invalid seeds")
+	ErrInvalidBlockHash           = errors.New("invalid block hash")
+	ErrNoFeePayer                 = errors.New("no fee payer")
+	ErrUnexpectedSigner           = errors.New("unexpected signer")
+	ErrTooManyAccounts            = errors.New("too many accounts")
+	ErrTransactionTooLarge        = errors.New("transaction too large")
+	ErrUnsupportedEncoding        = errors.New("unsupported encoding")
+	ErrUnexpectedResponse         = errors.New("unexpected response")
+	ErrBlockHashExpired           = errors.New("block hash expired")
+	ErrTransactionExpired         = errors.New("transaction expired")
+	ErrUnexpectedInstructionError = errors.New("unexpected instruction error")
+	ErrPubSubConnectionClosed     = errors.New("pubsub connection closed")
+	ErrPubSubDisconnected         = errors.New("pubsub connection disconnected")
+	ErrPubSubNotificationOverflow = errors.New("pubsub notification buffer overflow")
+	ErrBatchNotExecuted           = errors.New("batch not executed")
+	ErrNoConnections              = errors.New("no connections")
+	ErrNoHealthyEndpoints         = errors.New("no healthy endpoints")
+	ErrEndpointBehind             = errors.New("endpoint behind")
 )
+
+// ErrUnmatchedCall is returned by a JSONRPCConnection configured WithCassetteReplay
+// for a call that does not match any remaining recorded call
+var ErrUnmatchedCall = jsonrpc.ErrUnmatchedCall
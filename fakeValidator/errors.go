@@ -0,0 +1,7 @@
+package fakeValidator
+
+import "errors"
+
+var (
+	ErrInvalidTransaction = errors.New("invalid transaction")
+)
@@ -0,0 +1,213 @@
+package fakeValidator
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"github.com/BRBussy/solgo"
+	"github.com/BRBussy/solgo/internal/pkg/jsonrpc"
+	"github.com/btcsuite/btcutil/base58"
+	"math"
+)
+
+const (
+	// maxBase58AccountDataLength is the maximum length of account data returned with solana.Base58Encoding
+	maxBase58AccountDataLength = 128
+
+	// maxSignatureStatuses is the maximum number of signatures of a getSignatureStatuses call
+	maxSignatureStatuses = 256
+)
+
+// contextResult is the result of a call that is returned with the Context at which it was evaluated
+type contextResult struct {
+	Context solana.Context `json:"context"`
+	Value   interface{}    `json:"value"`
+}
+
+// getAccountInfo returns the account at the given public key, or nil if there is no such account
+func (s *Server) getAccountInfo(params json.RawMessage) (interface{}, *jsonrpc.RPCError) {
+	// parse params
+	var publicKey string
+	config := new(
+		struct {
+			Encoding solana.Encoding `json:"encoding"`
+		},
+	)
+	if rpcErr := parseParams(params, 1, &publicKey, config); rpcErr != nil {
+		return nil, rpcErr
+	}
+	if rpcErr := validatePublicKey(publicKey); rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := contextResult{Context: solana.Context{Slot: s.slot}}
+	account, found := s.accounts[publicKey]
+	if !found {
+		return result, nil
+	}
+
+	// encode account data
+	var data []string
+	switch config.Encoding {
+	case "", solana.Base58Encoding:
+		if len(account.Data) > maxBase58AccountDataLength {
+			return nil, invalidParamsError(fmt.Sprintf(
+				"encoded binary (base 58) data should be less than %d bytes, please use Base64 encoding",
+				maxBase58AccountDataLength,
+			))
+		}
+		data = []string{base58.Encode(account.Data), string(solana.Base58Encoding)}
+
+	case solana.Base64Encoding, solana.JSONParsedEncoding:
+		// no parsers are available, so jsonParsed falls back to base64
+		data = []string{base64.StdEncoding.EncodeToString(account.Data), string(solana.Base64Encoding)}
+
+	default:
+		return nil, invalidParamsError(fmt.Sprintf("unsupported encoding: %s", config.Encoding))
+	}
+	result.Value = solana.AccountInfoEncodedData{
+		Executable: account.Executable,
+		Lamports:   account.Lamports,
+		Data:       data,
+		Owner:      account.Owner.ToBase58(),
+	}
+
+	return result, nil
+}
+
+// getBalance returns the lamports of the account at the given public key, or 0 if there is no such account
+func (s *Server) getBalance(params json.RawMessage) (interface{}, *jsonrpc.RPCError) {
+	// parse params
+	var publicKey string
+	var config json.RawMessage
+	if rpcErr := parseParams(params, 1, &publicKey, &config); rpcErr != nil {
+		return nil, rpcErr
+	}
+	if rpcErr := validatePublicKey(publicKey); rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return contextResult{
+		Context: solana.Context{Slot: s.slot},
+		Value:   s.accounts[publicKey].Lamports,
+	}, nil
+}
+
+// getBlockHeight returns the block height of the current slot, which is equal to the slot
+func (s *Server) getBlockHeight(params json.RawMessage) (interface{}, *jsonrpc.RPCError) {
+	// parse params
+	var config json.RawMessage
+	if rpcErr := parseParams(params, 0, &config); rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.slot, nil
+}
+
+// getLatestBlockhash returns the block hash of the current slot. Block heights are equal to slots.
+func (s *Server) getLatestBlockhash(params json.RawMessage) (interface{}, *jsonrpc.RPCError) {
+	// parse params
+	var config json.RawMessage
+	if rpcErr := parseParams(params, 0, &config); rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return contextResult{
+		Context: solana.Context{Slot: s.slot},
+		Value: struct {
+			BlockHash            string `json:"blockhash"`
+			LastValidBlockHeight uint64 `json:"lastValidBlockHeight"`
+		}{
+			BlockHash:            blockhash(s.slot),
+			LastValidBlockHeight: s.lastValidBlockHeight(),
+		},
+	}, nil
+}
+
+// getSignatureStatuses returns the status of each of the given signatures,
+// or nil for signatures of transactions that have not been processed
+func (s *Server) getSignatureStatuses(params json.RawMessage) (interface{}, *jsonrpc.RPCError) {
+	// parse params
+	var signatures []string
+	var config json.RawMessage
+	if rpcErr := parseParams(params, 1, &signatures, &config); rpcErr != nil {
+		return nil, rpcErr
+	}
+	if len(signatures) > maxSignatureStatuses {
+		return nil, invalidParamsError(fmt.Sprintf("too many inputs provided; max %d", maxSignatureStatuses))
+	}
+	for _, signature := range signatures {
+		if len(base58.Decode(signature)) != 64 {
+			return nil, invalidParamsError(fmt.Sprintf("invalid signature: %s", signature))
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	statuses := make([]*solana.SignatureStatus, len(signatures))
+	for i, signature := range signatures {
+		statuses[i] = s.statuses[signature]
+	}
+	return contextResult{
+		Context: solana.Context{Slot: s.slot},
+		Value:   statuses,
+	}, nil
+}
+
+// requestAirdrop credits the given lamports to the account at the given public key in a new
+// slot, creating the account if it does not exist, and returns the signature of the airdrop
+func (s *Server) requestAirdrop(params json.RawMessage) (interface{}, *jsonrpc.RPCError) {
+	// parse params
+	var publicKey string
+	var lamports uint64
+	var config json.RawMessage
+	if rpcErr := parseParams(params, 2, &publicKey, &lamports, &config); rpcErr != nil {
+		return nil, rpcErr
+	}
+	if rpcErr := validatePublicKey(publicKey); rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// credit account
+	account := s.accounts[publicKey]
+	if account.Lamports > math.MaxUint64-lamports {
+		return nil, invalidParamsError(fmt.Sprintf("airdrop of %d lamports overflows balance", lamports))
+	}
+	account.Lamports += lamports
+	s.setAccount(publicKey, account)
+
+	// process airdrop in a new slot
+	s.airdrops++
+	counter := make([]byte, 8)
+	binary.LittleEndian.PutUint64(counter, s.airdrops)
+	hash := sha512.Sum512(append([]byte("airdrop"), counter...))
+	signature := base58.Encode(hash[:])
+	s.advanceSlot()
+	s.statuses[signature] = &solana.SignatureStatus{
+		Slot:               s.slot,
+		ConfirmationStatus: solana.FinalizedCommitmentLevel,
+	}
+
+	return signature, nil
+}
+
+// validatePublicKey returns an error if the given string is not a base58 encoded public key
+func validatePublicKey(publicKey string) *jsonrpc.RPCError {
+	if len(base58.Decode(publicKey)) != 32 {
+		return invalidParamsError(fmt.Sprintf("invalid public key: %s", publicKey))
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package fakeValidator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/BRBussy/solgo/internal/pkg/jsonrpc"
+	"io"
+	"net/http"
+)
+
+const (
+	parseErrorCode     = -32700
+	invalidRequestCode = -32600
+	methodNotFoundCode = -32601
+	invalidParamsCode  = -32602
+	internalErrorCode  = -32603
+)
+
+// rpcRequest is a json-rpc request with params that are decoded by the method handler
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+	ID      int             `json:"id"`
+}
+
+// ServeHTTP responds to a json-rpc request, or batch of requests
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+
+	// respond to batch of requests
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var requests []rpcRequest
+		if err := json.Unmarshal(body, &requests); err != nil {
+			writeResponse(w, errorResponse(0, parseErrorCode, "Parse error"))
+			return
+		}
+		if len(requests) == 0 {
+			writeResponse(w, errorResponse(0, invalidRequestCode, "Invalid request"))
+			return
+		}
+		responses := make([]*jsonrpc.RPCResponse, len(requests))
+		for i, request := range requests {
+			responses[i] = s.handle(request)
+		}
+		writeResponse(w, responses)
+		return
+	}
+
+	// respond to request
+	var request rpcRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		writeResponse(w, errorResponse(0, parseErrorCode, "Parse error"))
+		return
+	}
+	writeResponse(w, s.handle(request))
+}
+
+// handle returns the response to the given request
+func (s *Server) handle(request rpcRequest) *jsonrpc.RPCResponse {
+	if request.JSONRPC != "2.0" {
+		return errorResponse(request.ID, invalidRequestCode, "Invalid request")
+	}
+
+	// call method handler
+	var result interface{}
+	var rpcErr *jsonrpc.RPCError
+	switch request.Method {
+	case "getAccountInfo":
+		result, rpcErr = s.getAccountInfo(request.Params)
+	case "getBalance":
+		result, rpcErr = s.getBalance(request.Params)
+	case "getBlockHeight":
+		result, rpcErr = s.getBlockHeight(request.Params)
+	case "getLatestBlockhash":
+		result, rpcErr = s.getLatestBlockhash(request.Params)
+	case "sendTransaction":
+		result, rpcErr = s.sendTransaction(request.Params)
+	case "getSignatureStatuses":
+		result, rpcErr = s.getSignatureStatuses(request.Params)
+	case "requestAirdrop":
+		result, rpcErr = s.requestAirdrop(request.Params)
+	default:
+		return errorResponse(request.ID, methodNotFoundCode, "Method not found")
+	}
+	if rpcErr != nil {
+		return &jsonrpc.RPCResponse{JSONRPC: "2.0", Error: rpcErr, ID: request.ID}
+	}
+
+	// prepare response
+	resultData, err := json.Marshal(result)
+	if err != nil {
+		return errorResponse(request.ID, internalErrorCode, fmt.Sprintf("error marshalling result: %v", err))
+	}
+	return &jsonrpc.RPCResponse{JSONRPC: "2.0", Result: resultData, ID: request.ID}
+}
+
+// parseParams decodes the given params array into the given values, in order.
+// The first required values must be present, the remaining values are optional.
+func parseParams(params json.RawMessage, required int, values ...interface{}) *jsonrpc.RPCError {
+	var array []json.RawMessage
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &array); err != nil {
+			return invalidParamsError("`params` should be an array")
+		}
+	}
+	if len(array) < required {
+		return invalidParamsError(fmt.Sprintf("expected %d params, got %d", required, len(array)))
+	}
+	if len(array) > len(values) {
+		return invalidParamsError(fmt.Sprintf("expected at most %d params, got %d", len(values), len(array)))
+	}
+	for i, param := range array {
+		if err := json.Unmarshal(param, values[i]); err != nil {
+			return invalidParamsError(err.Error())
+		}
+	}
+	return nil
+}
+
+// invalidParamsError returns an invalid params error with the given detail
+func invalidParamsError(detail string) *jsonrpc.RPCError {
+	return &jsonrpc.RPCError{
+		Code:    invalidParamsCode,
+		Message: "Invalid params: " + detail,
+	}
+}
+
+// errorResponse returns a response holding an error with the given code and message
+func errorResponse(id, code int, message string) *jsonrpc.RPCResponse {
+	return &jsonrpc.RPCResponse{
+		JSONRPC: "2.0",
+		Error:   &jsonrpc.RPCError{Code: code, Message: message},
+		ID:      id,
+	}
+}
+
+// writeResponse writes the given response, or batch of responses
+func writeResponse(w http.ResponseWriter, response interface{}) {
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,204 @@
+// Package fakeValidator provides an in-process fake of the json-rpc api of a Solana validator
+// for testing code that uses a solana.Connection end to end, without a real validator.
+//
+// The fake keeps an in-memory account store, a slot counter and a queue of recent block hashes,
+// and implements the getAccountInfo, getBalance, getBlockHeight, getLatestBlockhash, sendTransaction,
+// getSignatureStatuses and requestAirdrop methods. Sent transactions have their signatures and
+// recent block hash verified, pay fees, and may hold system program transfer and create account
+// instructions, which are executed against the account store. Instructions of other programs fail.
+//
+// There is a single fork: each processed transaction or airdrop is processed in its own new slot,
+// which is immediately finalized, and all commitment levels observe the same state. Block heights
+// are equal to slots. Rent is not collected.
+package fakeValidator
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"github.com/BRBussy/solgo"
+	"github.com/BRBussy/solgo/systemProgram"
+	"github.com/btcsuite/btcutil/base58"
+	"net/http/httptest"
+	"sync"
+)
+
+const (
+	// DefaultLamportsPerSignature is the default fee charged per signature of a transaction
+	DefaultLamportsPerSignature uint64 = 5000
+
+	// DefaultMaxBlockhashAge is the default number of slots for which a
+	// block hash may be used as the recent block hash of a transaction
+	DefaultMaxBlockhashAge uint64 = 150
+)
+
+// Account is the state of an account held by a Server
+type Account struct {
+	// Lamports is the number of lamports assigned to the account
+	Lamports uint64
+
+	// Data is the data held by the account
+	Data []byte
+
+	// Owner is the program that owns the account. The zero value is the system program.
+	Owner solana.PublicKey
+
+	// Executable is true if the account holds a loaded program
+	Executable bool
+}
+
+// Server is a fake Solana validator json-rpc server, listening on a local port.
+// Use its URL WithEndpoint to connect to it with a solana.JSONRPCConnection.
+type Server struct {
+	// URL is the endpoint of the Server
+	URL string
+
+	server *httptest.Server
+	config *serverConfig
+
+	mu          sync.Mutex
+	slot        uint64
+	accounts    map[string]Account
+	blockhashes map[string]uint64
+	statuses    map[string]*solana.SignatureStatus
+	airdrops    uint64
+}
+
+// serverConfig is the configuration for a Server
+type serverConfig struct {
+	lamportsPerSignature uint64
+	maxBlockhashAge      uint64
+}
+
+// ServerOption makes a change to the serverConfig
+type ServerOption interface {
+	apply(*serverConfig)
+}
+
+type serverOptionFunc func(*serverConfig)
+
+func (fn serverOptionFunc) apply(cfg *serverConfig) {
+	fn(cfg)
+}
+
+// WithLamportsPerSignature sets the fee charged per signature of a transaction
+func WithLamportsPerSignature(l uint64) ServerOption {
+	return serverOptionFunc(func(config *serverConfig) {
+		config.lamportsPerSignature = l
+	})
+}
+
+// WithMaxBlockhashAge sets the number of slots for which a
+// block hash may be used as the recent block hash of a transaction
+func WithMaxBlockhashAge(a uint64) ServerOption {
+	return serverOptionFunc(func(config *serverConfig) {
+		config.maxBlockhashAge = a
+	})
+}
+
+// NewServer starts and returns a new and configured Server, with no accounts, at slot 0.
+// The Server should be closed with Close when it is no longer needed.
+//
+// The default returned Server is configured with:
+//   - lamportsPerSignature: DefaultLamportsPerSignature
+//   - maxBlockhashAge: DefaultMaxBlockhashAge
+//
+// The passed opts are used to override these default values and configure the
+// returned Server as desired.
+func NewServer(opts ...ServerOption) *Server {
+	// prepare default configuration
+	config := &serverConfig{
+		lamportsPerSignature: DefaultLamportsPerSignature,
+		maxBlockhashAge:      DefaultMaxBlockhashAge,
+	}
+
+	// apply any provided options
+	for _, opt := range opts {
+		opt.apply(config)
+	}
+
+	// prepare and start server
+	s := &Server{
+		config:      config,
+		accounts:    make(map[string]Account),
+		blockhashes: map[string]uint64{blockhash(0): 0},
+		statuses:    make(map[string]*solana.SignatureStatus),
+	}
+	s.server = httptest.NewServer(s)
+	s.URL = s.server.URL
+
+	return s
+}
+
+// Close shuts down the Server
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// SetAccount sets the state of the account with the given public key.
+// Setting an account with no lamports removes it.
+func (s *Server) SetAccount(publicKey solana.PublicKey, account Account) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.setAccount(publicKey.ToBase58(), account)
+}
+
+// Account returns the state of the account with the given public key,
+// and false if there is no such account
+func (s *Server) Account(publicKey solana.PublicKey) (Account, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	account, found := s.accounts[publicKey.ToBase58()]
+	return account, found
+}
+
+// Slot returns the current slot of the Server
+func (s *Server) Slot() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.slot
+}
+
+// AdvanceSlot advances the Server to the next slot, with a new block hash,
+// expiring the oldest block hash that could be used in a transaction
+func (s *Server) AdvanceSlot() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.advanceSlot()
+}
+
+// setAccount sets the state of the account with the given base58 encoded public key.
+// Must be called with the lock held.
+func (s *Server) setAccount(publicKey string, account Account) {
+	if account.Lamports == 0 {
+		delete(s.accounts, publicKey)
+		return
+	}
+	if len(account.Owner.PublicKey) == 0 {
+		account.Owner = systemProgram.ID
+	}
+	s.accounts[publicKey] = account
+}
+
+// advanceSlot advances to the next slot. Must be called with the lock held.
+func (s *Server) advanceSlot() {
+	s.slot++
+	s.blockhashes[blockhash(s.slot)] = s.slot
+	if s.slot > s.config.maxBlockhashAge {
+		delete(s.blockhashes, blockhash(s.slot-s.config.maxBlockhashAge-1))
+	}
+}
+
+// lastValidBlockHeight returns the last block height at which the
+// block hash of the current slot may be used in a transaction.
+// Must be called with the lock held.
+func (s *Server) lastValidBlockHeight() uint64 {
+	return s.slot + s.config.maxBlockhashAge
+}
+
+// blockhash returns the base58 encoded block hash of the given slot
+func blockhash(slot uint64) string {
+	data := make([]byte, 8)
+	binary.LittleEndian.PutUint64(data, slot)
+	hash := sha256.Sum256(append([]byte("fakeValidator"), data...))
+	return base58.Encode(hash[:])
+}
@@ -0,0 +1,357 @@
+package fakeValidator
+
+import (
+	"context"
+	"errors"
+	"github.com/BRBussy/solgo"
+	"github.com/BRBussy/solgo/internal/pkg/jsonrpc"
+	"github.com/BRBussy/solgo/systemProgram"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// newTransaction returns a transaction of the given instructions with the
+// given recent block hash, signed by the given signers, the first of which pays fees
+func newTransaction(t *testing.T, recentBlockHash string, instructions []solana.Instruction, signers ...*solana.KeyPair) *solana.Transaction {
+	txn := solana.NewTransaction()
+	require.NoError(t, txn.AddInstructions(instructions...))
+	require.NoError(t, txn.SetFeePayer(signers[0].PublicKey))
+	require.NoError(t, txn.SetRecentBlockHash(recentBlockHash))
+	for _, signer := range signers {
+		require.NoError(t, txn.Sign(signer.PrivateKey))
+	}
+	return txn
+}
+
+// transfer returns system program transfer instructions
+func transfer(t *testing.T, from, to solana.PublicKey, lamports uint64) []solana.Instruction {
+	instructions, err := systemProgram.Transfer(systemProgram.TransferParams{
+		FromPubkey: from,
+		ToPubkey:   to,
+		Lamports:   lamports,
+	})
+	require.NoError(t, err)
+	return instructions
+}
+
+func TestServer(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	connection := solana.NewJSONRPCConnection(solana.WithEndpoint(server.URL))
+	ctx := context.Background()
+	payer := solana.MustNewRandomKeypair()
+	recipient := solana.MustNewRandomKeypair()
+
+	// request airdrop
+	rpcResponse, err := jsonrpc.NewHTTPClient(server.URL).CallParamArray(ctx, "requestAirdrop", nil, payer.PublicKey.ToBase58(), 1000000000)
+	require.NoError(t, err)
+	require.Nil(t, rpcResponse.Error)
+	var airdropSignature string
+	require.NoError(t, rpcResponse.GetObject(&airdropSignature))
+	getSignatureStatusesResponse, err := connection.GetSignatureStatuses(ctx, solana.GetSignatureStatusesRequest{
+		Signatures: []string{airdropSignature, base58.Encode(make([]byte, 64))},
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), getSignatureStatusesResponse.Context.Slot)
+	require.Equal(
+		t,
+		[]*solana.SignatureStatus{{Slot: 1, ConfirmationStatus: solana.FinalizedCommitmentLevel}, nil},
+		getSignatureStatusesResponse.Statuses,
+	)
+	getBalanceResponse, err := connection.GetBalance(ctx, solana.GetBalanceRequest{PublicKey: payer.PublicKey})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000000000), getBalanceResponse.Value)
+
+	// transfer lamports
+	getLatestBlockhashResponse, err := connection.GetLatestBlockhash(ctx, solana.GetLatestBlockhashRequest{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1)+DefaultMaxBlockhashAge, getLatestBlockhashResponse.LastValidBlockHeight)
+	sendAndConfirmTransactionResponse, err := solana.SendAndConfirmTransaction(
+		ctx,
+		connection,
+		solana.SendAndConfirmTransactionRequest{
+			SendTransactionRequest: solana.SendTransactionRequest{
+				Transaction: *newTransaction(
+					t,
+					getLatestBlockhashResponse.BlockHash,
+					transfer(t, payer.PublicKey, recipient.PublicKey, 1000),
+					payer,
+				),
+			},
+			LastValidBlockHeight: getLatestBlockhashResponse.LastValidBlockHeight,
+			PollInterval:         time.Millisecond,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), sendAndConfirmTransactionResponse.Status.Slot)
+	batch := connection.NewBatch()
+	payerBalance := batch.GetBalance(solana.GetBalanceRequest{PublicKey: payer.PublicKey})
+	recipientBalance := batch.GetBalance(solana.GetBalanceRequest{PublicKey: recipient.PublicKey})
+	require.NoError(t, batch.Execute(ctx))
+	getBalanceResponse, err = payerBalance.Response()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000000000-DefaultLamportsPerSignature-1000), getBalanceResponse.Value)
+	getBalanceResponse, err = recipientBalance.Response()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000), getBalanceResponse.Value)
+
+	// create account
+	newAccount := solana.MustNewRandomKeypair()
+	programID := solana.MustNewRandomKeypair().PublicKey
+	createAccountInstructions, err := systemProgram.CreateAccount(systemProgram.CreateAccountParams{
+		FromPubkey:       payer.PublicKey,
+		NewAccountPubkey: newAccount.PublicKey,
+		Lamports:         2000,
+		Space:            10,
+		ProgramID:        programID,
+	})
+	require.NoError(t, err)
+	getLatestBlockhashResponse, err = connection.GetLatestBlockhash(ctx, solana.GetLatestBlockhashRequest{})
+	require.NoError(t, err)
+	_, err = connection.SendTransaction(ctx, solana.SendTransactionRequest{
+		Transaction: *newTransaction(t, getLatestBlockhashResponse.BlockHash, createAccountInstructions, payer, newAccount),
+		Encoding:    solana.Base64Encoding,
+	})
+	require.NoError(t, err)
+	getAccountInfoResponse, err := connection.GetAccountInfo(ctx, solana.GetAccountInfoRequest{PublicKey: newAccount.PublicKey})
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), getAccountInfoResponse.Context.Slot)
+	require.Equal(t, uint64(2000), getAccountInfoResponse.AccountInfo.GetLamports())
+	require.Equal(t, programID.ToBase58(), getAccountInfoResponse.AccountInfo.GetOwner())
+	data, err := getAccountInfoResponse.AccountInfo.(solana.AccountInfoEncodedData).DecodeData()
+	require.NoError(t, err)
+	require.Equal(t, make([]byte, 10), data)
+	payerAccount, found := server.Account(payer.PublicKey)
+	require.True(t, found)
+	require.Equal(t, uint64(1000000000-3*DefaultLamportsPerSignature-3000), payerAccount.Lamports)
+
+	// accounts that do not exist are not returned
+	getAccountInfoResponse, err = connection.GetAccountInfo(ctx, solana.GetAccountInfoRequest{PublicKey: solana.MustNewRandomKeypair().PublicKey})
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), getAccountInfoResponse.AccountInfo.GetLamports())
+}
+
+func TestServer_SendTransaction(t *testing.T) {
+	payer := solana.MustNewRandomKeypair()
+	recipient := solana.MustNewRandomKeypair()
+
+	tests := []struct {
+		name            string
+		payerLamports   uint64
+		instructions    func(t *testing.T) []solana.Instruction
+		recentBlockHash string
+		skipPreflight   bool
+		wantErr         *solana.TransactionError
+		wantStatusErr   *solana.TransactionError
+		wantLamports    uint64
+	}{
+		{
+			name:          "transfer succeeds",
+			payerLamports: 100000,
+			instructions: func(t *testing.T) []solana.Instruction {
+				return transfer(t, payer.PublicKey, recipient.PublicKey, 1000)
+			},
+			wantLamports: 100000 - DefaultLamportsPerSignature - 1000,
+		},
+		{
+			name:          "insufficient lamports fails preflight",
+			payerLamports: 100000,
+			instructions: func(t *testing.T) []solana.Instruction {
+				return transfer(t, payer.PublicKey, recipient.PublicKey, 100000)
+			},
+			wantErr: &solana.TransactionError{
+				Type:             solana.InstructionErrorTransactionError,
+				InstructionError: &solana.InstructionError{Index: 0, Err: solana.CustomInstructionError(1)},
+			},
+			wantLamports: 100000,
+		},
+		{
+			name:          "insufficient lamports with preflight skipped fails after paying fee",
+			payerLamports: 100000,
+			instructions: func(t *testing.T) []solana.Instruction {
+				return transfer(t, payer.PublicKey, recipient.PublicKey, 100000)
+			},
+			skipPreflight: true,
+			wantStatusErr: &solana.TransactionError{
+				Type:             solana.InstructionErrorTransactionError,
+				InstructionError: &solana.InstructionError{Index: 0, Err: solana.CustomInstructionError(1)},
+			},
+			wantLamports: 100000 - DefaultLamportsPerSignature,
+		},
+		{
+			name:          "insufficient lamports for fee",
+			payerLamports: 1000,
+			instructions: func(t *testing.T) []solana.Instruction {
+				return transfer(t, payer.PublicKey, recipient.PublicKey, 0)
+			},
+			wantErr:      &solana.TransactionError{Type: solana.InsufficientFundsForFeeTransactionError},
+			wantLamports: 1000,
+		},
+		{
+			name: "fee payer not found",
+			instructions: func(t *testing.T) []solana.Instruction {
+				return transfer(t, payer.PublicKey, recipient.PublicKey, 0)
+			},
+			wantErr: &solana.TransactionError{Type: solana.AccountNotFoundTransactionError},
+		},
+		{
+			name:          "block hash not found",
+			payerLamports: 100000,
+			instructions: func(t *testing.T) []solana.Instruction {
+				return transfer(t, payer.PublicKey, recipient.PublicKey, 1000)
+			},
+			recentBlockHash: base58.Encode(make([]byte, 32)),
+			wantErr:         &solana.TransactionError{Type: solana.BlockhashNotFoundTransactionError},
+			wantLamports:    100000,
+		},
+		{
+			name:          "unsupported program",
+			payerLamports: 100000,
+			instructions: func(t *testing.T) []solana.Instruction {
+				return []solana.Instruction{{
+					InstructionAccountMeta: []solana.InstructionAccountMeta{{PubKey: payer.PublicKey, IsSigner: true, IsWritable: true}},
+					ProgramIDPubKey:        solana.MustNewRandomKeypair().PublicKey,
+				}}
+			},
+			wantErr: &solana.TransactionError{
+				Type:             solana.InstructionErrorTransactionError,
+				InstructionError: &solana.InstructionError{Index: 0, Err: solana.UnsupportedProgramIDInstructionErrorType},
+			},
+			wantLamports: 100000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := NewServer()
+			defer server.Close()
+			server.SetAccount(payer.PublicKey, Account{Lamports: tt.payerLamports})
+			connection := solana.NewJSONRPCConnection(solana.WithEndpoint(server.URL))
+			ctx := context.Background()
+
+			// send transaction
+			recentBlockHash := tt.recentBlockHash
+			if recentBlockHash == "" {
+				getLatestBlockhashResponse, err := connection.GetLatestBlockhash(ctx, solana.GetLatestBlockhashRequest{})
+				require.NoError(t, err)
+				recentBlockHash = getLatestBlockhashResponse.BlockHash
+			}
+			sendTransactionResponse, err := connection.SendTransaction(ctx, solana.SendTransactionRequest{
+				Transaction:   *newTransaction(t, recentBlockHash, tt.instructions(t), payer),
+				SkipPreflight: tt.skipPreflight,
+			})
+			if tt.wantErr != nil {
+				var preflightErr *solana.PreflightFailureError
+				require.True(t, errors.As(err, &preflightErr))
+				require.Equal(t, tt.wantErr, preflightErr.Err)
+			} else {
+				require.NoError(t, err)
+				getSignatureStatusesResponse, err := connection.GetSignatureStatuses(ctx, solana.GetSignatureStatusesRequest{
+					Signatures: []string{sendTransactionResponse.TransactionID},
+				})
+				require.NoError(t, err)
+				require.Equal(t, tt.wantStatusErr, getSignatureStatusesResponse.Statuses[0].Err)
+			}
+
+			// check fee payer balance
+			getBalanceResponse, err := connection.GetBalance(ctx, solana.GetBalanceRequest{PublicKey: payer.PublicKey})
+			require.NoError(t, err)
+			require.Equal(t, tt.wantLamports, getBalanceResponse.Value)
+		})
+	}
+}
+
+func TestServer_SendTransaction_Replay(t *testing.T) {
+	server := NewServer(WithMaxBlockhashAge(2))
+	defer server.Close()
+	payer := solana.MustNewRandomKeypair()
+	server.SetAccount(payer.PublicKey, Account{Lamports: 100000})
+	connection := solana.NewJSONRPCConnection(solana.WithEndpoint(server.URL))
+	ctx := context.Background()
+
+	// transactions are only processed once
+	getLatestBlockhashResponse, err := connection.GetLatestBlockhash(ctx, solana.GetLatestBlockhashRequest{})
+	require.NoError(t, err)
+	txn := newTransaction(t, getLatestBlockhashResponse.BlockHash, transfer(t, payer.PublicKey, payer.PublicKey, 1000), payer)
+	_, err = connection.SendTransaction(ctx, solana.SendTransactionRequest{Transaction: *txn})
+	require.NoError(t, err)
+	_, err = connection.SendTransaction(ctx, solana.SendTransactionRequest{Transaction: *txn})
+	var preflightErr *solana.PreflightFailureError
+	require.True(t, errors.As(err, &preflightErr))
+	require.Equal(t, solana.AlreadyProcessedTransactionError, preflightErr.Err.Type)
+
+	// block hashes expire after the last valid block height
+	require.Equal(t, uint64(2), getLatestBlockhashResponse.LastValidBlockHeight)
+	server.AdvanceSlot()
+	server.AdvanceSlot()
+	txn = newTransaction(t, getLatestBlockhashResponse.BlockHash, transfer(t, payer.PublicKey, payer.PublicKey, 2000), payer)
+	_, err = connection.SendTransaction(ctx, solana.SendTransactionRequest{Transaction: *txn})
+	require.True(t, errors.As(err, &preflightErr))
+	require.Equal(t, solana.BlockhashNotFoundTransactionError, preflightErr.Err.Type)
+	require.Equal(t, uint64(3), server.Slot())
+}
+
+func TestServer_SendAndConfirmTransaction_Expired(t *testing.T) {
+	server := NewServer(WithMaxBlockhashAge(1))
+	defer server.Close()
+	payer := solana.MustNewRandomKeypair()
+	server.SetAccount(payer.PublicKey, Account{Lamports: 100000})
+	connection := solana.NewJSONRPCConnection(solana.WithEndpoint(server.URL))
+	ctx := context.Background()
+
+	// a transaction with an expired block hash is dropped and never confirmed
+	getLatestBlockhashResponse, err := connection.GetLatestBlockhash(ctx, solana.GetLatestBlockhashRequest{})
+	require.NoError(t, err)
+	server.AdvanceSlot()
+	server.AdvanceSlot()
+	getBlockHeightResponse, err := connection.GetBlockHeight(ctx, solana.GetBlockHeightRequest{})
+	require.NoError(t, err)
+	require.Equal(t, server.Slot(), getBlockHeightResponse.BlockHeight)
+	_, err = solana.SendAndConfirmTransaction(
+		ctx,
+		connection,
+		solana.SendAndConfirmTransactionRequest{
+			SendTransactionRequest: solana.SendTransactionRequest{
+				Transaction:   *newTransaction(t, getLatestBlockhashResponse.BlockHash, transfer(t, payer.PublicKey, payer.PublicKey, 1000), payer),
+				SkipPreflight: true,
+			},
+			LastValidBlockHeight: getLatestBlockhashResponse.LastValidBlockHeight,
+			PollInterval:         time.Millisecond,
+		},
+	)
+	require.ErrorIs(t, err, solana.ErrTransactionExpired)
+	payerAccount, _ := server.Account(payer.PublicKey)
+	require.Equal(t, uint64(100000), payerAccount.Lamports)
+}
+
+func TestDecodeTransaction(t *testing.T) {
+	payer := solana.MustNewRandomKeypair()
+	recipient := solana.MustNewRandomKeypair()
+	txn := newTransaction(t, base58.Encode(make([]byte, 32)), transfer(t, payer.PublicKey, recipient.PublicKey, 1000), payer)
+	data, err := txn.ToBytes()
+	require.NoError(t, err)
+	message, err := txn.Message()
+	require.NoError(t, err)
+
+	// decode transaction
+	decodedTxn, err := decodeTransaction(data)
+	require.NoError(t, err)
+	require.Equal(t, *message, decodedTxn.message)
+	require.Equal(t, txn.Signature(), base58.Encode(decodedTxn.signatures[0][:]))
+	require.True(t, decodedTxn.verifySignatures())
+	require.True(t, decodedTxn.isSigner(0))
+	require.False(t, decodedTxn.isSigner(1))
+	require.True(t, decodedTxn.isWritable(1))
+	require.False(t, decodedTxn.isWritable(2))
+
+	// tampered transactions fail verification
+	data[len(data)-1]++
+	decodedTxn, err = decodeTransaction(data)
+	require.NoError(t, err)
+	require.False(t, decodedTxn.verifySignatures())
+
+	// truncated transactions cannot be decoded
+	_, err = decodeTransaction(data[:len(data)-1])
+	require.ErrorIs(t, err, ErrInvalidTransaction)
+}
@@ -0,0 +1,143 @@
+package fakeValidator
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/BRBussy/solgo"
+	"github.com/BRBussy/solgo/systemProgram"
+	"math"
+)
+
+// systemError is an error returned by the system program, as a custom instruction error.
+// See: https://github.com/solana-labs/solana/blob/v1.16.0/sdk/program/src/system_instruction.rs#L20
+type systemError = solana.CustomInstructionError
+
+const (
+	accountAlreadyInUseSystemError        systemError = 0
+	resultWithNegativeLamportsSystemError systemError = 1
+	invalidAccountDataLengthSystemError   systemError = 3
+)
+
+// maxPermittedDataLength is the maximum size in bytes of the data of an account
+const maxPermittedDataLength = 10 * 1024 * 1024
+
+// executeInstructions executes the instructions of the given transaction, updating the given accounts,
+// which hold any accounts already updated by the transaction. Accounts that are not in the given
+// accounts are read from the account store. Must be called with the lock held.
+func (s *Server) executeInstructions(txn *transaction, accounts map[string]Account) *solana.TransactionError {
+	for i, instruction := range txn.message.Instructions {
+		programID := txn.message.AccountKeys[instruction.ProgramIDIndex]
+		var err error
+		if programID.ToBase58() == systemProgram.ID.ToBase58() {
+			err = s.executeSystemInstruction(txn, instruction, accounts)
+		} else {
+			err = solana.UnsupportedProgramIDInstructionErrorType
+		}
+		if err != nil {
+			return &solana.TransactionError{
+				Type: solana.InstructionErrorTransactionError,
+				InstructionError: &solana.InstructionError{
+					Index: uint8(i),
+					Err:   err,
+				},
+			}
+		}
+	}
+	return nil
+}
+
+// executeSystemInstruction executes the given system program transfer or create account instruction,
+// returning a solana.InstructionErrorType or systemError if it fails. Must be called with the lock held.
+func (s *Server) executeSystemInstruction(txn *transaction, instruction solana.CompiledInstruction, accounts map[string]Account) error {
+	// decode instruction, which is bincode encoded
+	reader := bytes.NewReader(instruction.Data)
+	var systemInstruction systemProgram.Instruction
+	if err := binary.Read(reader, binary.LittleEndian, &systemInstruction); err != nil {
+		return solana.InvalidInstructionDataInstructionErrorType
+	}
+	if len(instruction.AccountIndices) < 2 {
+		return solana.NotEnoughAccountKeysInstructionErrorType
+	}
+	fromIndex, toIndex := instruction.AccountIndices[0], instruction.AccountIndices[1]
+	fromKey := txn.message.AccountKeys[fromIndex].ToBase58()
+	toKey := txn.message.AccountKeys[toIndex].ToBase58()
+	from, to := s.account(fromKey, accounts), s.account(toKey, accounts)
+
+	// prepare new state of to account
+	var lamports uint64
+	switch systemInstruction {
+	case systemProgram.TransferInstruction:
+		if err := binary.Read(reader, binary.LittleEndian, &lamports); err != nil {
+			return solana.InvalidInstructionDataInstructionErrorType
+		}
+
+	case systemProgram.CreateAccountInstruction:
+		data := new(
+			struct {
+				Lamports uint64
+				Space    uint64
+				Owner    [32]byte
+			},
+		)
+		if err := binary.Read(reader, binary.LittleEndian, data); err != nil {
+			return solana.InvalidInstructionDataInstructionErrorType
+		}
+		if !txn.isSigner(toIndex) {
+			return solana.MissingRequiredSignatureInstructionErrorType
+		}
+		if to.Lamports > 0 || len(to.Data) > 0 || to.Owner.ToBase58() != systemProgram.ID.ToBase58() {
+			return accountAlreadyInUseSystemError
+		}
+		if data.Space > maxPermittedDataLength {
+			return invalidAccountDataLengthSystemError
+		}
+		lamports = data.Lamports
+		to.Data = make([]byte, data.Space)
+		to.Owner = solana.NewPublicKeyFromBytes(data.Owner)
+
+	default:
+		return solana.InvalidInstructionDataInstructionErrorType
+	}
+
+	// transfer lamports
+	switch {
+	case !txn.isSigner(fromIndex):
+		return solana.MissingRequiredSignatureInstructionErrorType
+	case !txn.isWritable(fromIndex) || !txn.isWritable(toIndex):
+		return solana.ReadonlyLamportChangeInstructionErrorType
+	case from.Owner.ToBase58() != systemProgram.ID.ToBase58():
+		return solana.ExternalAccountLamportSpendInstructionErrorType
+	case len(from.Data) > 0:
+		return solana.InvalidArgumentInstructionErrorType
+	case from.Lamports < lamports:
+		return resultWithNegativeLamportsSystemError
+	}
+	if fromKey == toKey {
+		// a transfer to the same account does not change its balance
+		accounts[toKey] = to
+		return nil
+	}
+	if to.Lamports > math.MaxUint64-lamports {
+		return solana.ArithmeticOverflowInstructionErrorType
+	}
+	from.Lamports -= lamports
+	to.Lamports += lamports
+	accounts[fromKey] = from
+	accounts[toKey] = to
+
+	return nil
+}
+
+// account returns the account with the given base58 encoded public key from the given accounts,
+// or else from the account store. Accounts that do not exist are returned with no lamports,
+// owned by the system program. Must be called with the lock held.
+func (s *Server) account(publicKey string, accounts map[string]Account) Account {
+	account, found := accounts[publicKey]
+	if !found {
+		account = s.accounts[publicKey]
+	}
+	if len(account.Owner.PublicKey) == 0 {
+		account.Owner = systemProgram.ID
+	}
+	return account
+}
@@ -0,0 +1,308 @@
+package fakeValidator
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/BRBussy/solgo"
+	"github.com/BRBussy/solgo/internal/pkg/encoding"
+	"github.com/BRBussy/solgo/internal/pkg/jsonrpc"
+	"github.com/BRBussy/solgo/systemProgram"
+	"github.com/btcsuite/btcutil/base58"
+)
+
+// transaction is a transaction decoded from the Solana wire format
+type transaction struct {
+	signatures  []solana.Signature
+	message     solana.Message
+	messageData []byte
+}
+
+// decodeTransaction decodes a legacy transaction from the Solana wire format
+func decodeTransaction(data []byte) (*transaction, error) {
+	d := &decoder{data: data}
+	txn := new(transaction)
+
+	// [1.] Compact array of signatures
+	numSignatures, err := d.compactU16()
+	if err != nil {
+		return nil, err
+	}
+	txn.signatures = make([]solana.Signature, numSignatures)
+	for i := range txn.signatures {
+		signature, err := d.bytes(64)
+		if err != nil {
+			return nil, err
+		}
+		copy(txn.signatures[i][:], signature)
+	}
+	txn.messageData = d.data[d.offset:]
+
+	// [2.] Message header
+	header, err := d.bytes(3)
+	if err != nil {
+		return nil, err
+	}
+	if header[0]&0x80 != 0 {
+		return nil, fmt.Errorf("versioned message: %w", ErrInvalidTransaction)
+	}
+	txn.message.Header = solana.MessageHeader{
+		NumRequiredSignatures:       header[0],
+		NumReadonlySignedAccounts:   header[1],
+		NumReadonlyUnsignedAccounts: header[2],
+	}
+
+	// [3.] Compact array of account addresses
+	numAccountKeys, err := d.compactU16()
+	if err != nil {
+		return nil, err
+	}
+	txn.message.AccountKeys = make([]solana.PublicKey, numAccountKeys)
+	for i := range txn.message.AccountKeys {
+		accountKey, err := d.bytes(32)
+		if err != nil {
+			return nil, err
+		}
+		txn.message.AccountKeys[i] = solana.PublicKey{PublicKey: accountKey}
+	}
+
+	// [4.] Recent blockhash
+	recentBlockHash, err := d.bytes(32)
+	if err != nil {
+		return nil, err
+	}
+	txn.message.RecentBlockHash = base58.Encode(recentBlockHash)
+
+	// [5.] Compact array of instructions
+	numInstructions, err := d.compactU16()
+	if err != nil {
+		return nil, err
+	}
+	txn.message.Instructions = make([]solana.CompiledInstruction, numInstructions)
+	for i := range txn.message.Instructions {
+		programIDIndex, err := d.bytes(1)
+		if err != nil {
+			return nil, err
+		}
+		numAccountIndices, err := d.compactU16()
+		if err != nil {
+			return nil, err
+		}
+		accountIndices, err := d.bytes(int(numAccountIndices))
+		if err != nil {
+			return nil, err
+		}
+		dataLength, err := d.compactU16()
+		if err != nil {
+			return nil, err
+		}
+		instructionData, err := d.bytes(int(dataLength))
+		if err != nil {
+			return nil, err
+		}
+		txn.message.Instructions[i] = solana.CompiledInstruction{
+			ProgramIDIndex: programIDIndex[0],
+			AccountIndices: accountIndices,
+			Data:           instructionData,
+		}
+	}
+	if d.offset != len(d.data) {
+		return nil, fmt.Errorf("%d trailing bytes: %w", len(d.data)-d.offset, ErrInvalidTransaction)
+	}
+
+	return txn, txn.sanitize()
+}
+
+// sanitize checks that the header, account indices and signatures of the transaction are consistent
+func (t *transaction) sanitize() error {
+	header := t.message.Header
+	numAccountKeys := len(t.message.AccountKeys)
+	if header.NumRequiredSignatures == 0 ||
+		int(header.NumRequiredSignatures)+int(header.NumReadonlyUnsignedAccounts) > numAccountKeys ||
+		header.NumReadonlySignedAccounts >= header.NumRequiredSignatures {
+		return fmt.Errorf("inconsistent message header: %w", ErrInvalidTransaction)
+	}
+	if len(t.signatures) != int(header.NumRequiredSignatures) {
+		return fmt.Errorf(
+			"%d signatures for %d required: %w",
+			len(t.signatures), header.NumRequiredSignatures, ErrInvalidTransaction,
+		)
+	}
+	for i, instruction := range t.message.Instructions {
+		if int(instruction.ProgramIDIndex) >= numAccountKeys || instruction.ProgramIDIndex == 0 {
+			return fmt.Errorf("invalid program id index of instruction %d: %w", i, ErrInvalidTransaction)
+		}
+		for _, accountIndex := range instruction.AccountIndices {
+			if int(accountIndex) >= numAccountKeys {
+				return fmt.Errorf("invalid account index of instruction %d: %w", i, ErrInvalidTransaction)
+			}
+		}
+	}
+	return nil
+}
+
+// verifySignatures reports whether each signature of the transaction
+// is a valid signature of the message by the corresponding account
+func (t *transaction) verifySignatures() bool {
+	for i, signature := range t.signatures {
+		if !ed25519.Verify(t.message.AccountKeys[i].PublicKey, t.messageData, signature[:]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isSigner reports whether the account at the given index signed the transaction
+func (t *transaction) isSigner(index uint8) bool {
+	return index < t.message.Header.NumRequiredSignatures
+}
+
+// isWritable reports whether the account at the given index is writable by the transaction
+func (t *transaction) isWritable(index uint8) bool {
+	header := t.message.Header
+	if index < header.NumRequiredSignatures {
+		return index < header.NumRequiredSignatures-header.NumReadonlySignedAccounts
+	}
+	return int(index) < len(t.message.AccountKeys)-int(header.NumReadonlyUnsignedAccounts)
+}
+
+// sendTransaction processes the given transaction in a new slot and returns its signature. Unless preflight
+// is skipped, transactions that would fail are rejected with a preflight failure error and are not processed.
+// Otherwise transactions that fail while executing instructions are processed with the error, paying fees.
+func (s *Server) sendTransaction(params json.RawMessage) (interface{}, *jsonrpc.RPCError) {
+	// parse params
+	var txnData string
+	config := new(
+		struct {
+			Encoding      solana.Encoding `json:"encoding"`
+			SkipPreflight bool            `json:"skipPreflight"`
+		},
+	)
+	if rpcErr := parseParams(params, 1, &txnData, config); rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	// decode transaction
+	var data []byte
+	switch config.Encoding {
+	case "", solana.Base58Encoding:
+		data = base58.Decode(txnData)
+	case solana.Base64Encoding:
+		var err error
+		if data, err = base64.StdEncoding.DecodeString(txnData); err != nil {
+			return nil, invalidParamsError(fmt.Sprintf("invalid base64 encoding: %v", err))
+		}
+	default:
+		return nil, invalidParamsError(fmt.Sprintf("unsupported encoding: %s", config.Encoding))
+	}
+	txn, err := decodeTransaction(data)
+	if err != nil {
+		return nil, invalidParamsError(fmt.Sprintf("failed to deserialize transaction: %v", err))
+	}
+	if !txn.verifySignatures() {
+		return nil, &jsonrpc.RPCError{
+			Code:    int(solana.TransactionSignatureVerificationFailureRPCErrorCode),
+			Message: "Transaction signature verification failure",
+		}
+	}
+	signature := base58.Encode(txn.signatures[0][:])
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// check that the transaction can be processed, dropping it if it cannot
+	fee := s.config.lamportsPerSignature * uint64(len(txn.signatures))
+	if txnErr := s.checkTransaction(txn, signature, fee); txnErr != nil {
+		if config.SkipPreflight {
+			return signature, nil
+		}
+		return nil, preflightFailureError(txnErr)
+	}
+
+	// pay fee and execute instructions
+	feePayerKey := txn.message.AccountKeys[0].ToBase58()
+	feePayer := s.accounts[feePayerKey]
+	feePayer.Lamports -= fee
+	accounts := map[string]Account{feePayerKey: feePayer}
+	txnErr := s.executeInstructions(txn, accounts)
+	if txnErr != nil {
+		if !config.SkipPreflight {
+			return nil, preflightFailureError(txnErr)
+		}
+		// only the fee is paid by a failed transaction
+		accounts = map[string]Account{feePayerKey: feePayer}
+	}
+
+	// process transaction in a new slot
+	for publicKey, account := range accounts {
+		s.setAccount(publicKey, account)
+	}
+	s.advanceSlot()
+	s.statuses[signature] = &solana.SignatureStatus{
+		Slot:               s.slot,
+		Err:                txnErr,
+		ConfirmationStatus: solana.FinalizedCommitmentLevel,
+	}
+
+	return signature, nil
+}
+
+// checkTransaction returns the error with which the given transaction would be dropped
+// before executing its instructions, if any. Must be called with the lock held.
+func (s *Server) checkTransaction(txn *transaction, signature string, fee uint64) *solana.TransactionError {
+	if _, found := s.statuses[signature]; found {
+		return &solana.TransactionError{Type: solana.AlreadyProcessedTransactionError}
+	}
+	if _, found := s.blockhashes[txn.message.RecentBlockHash]; !found {
+		return &solana.TransactionError{Type: solana.BlockhashNotFoundTransactionError}
+	}
+	feePayer, found := s.accounts[txn.message.AccountKeys[0].ToBase58()]
+	switch {
+	case !found:
+		return &solana.TransactionError{Type: solana.AccountNotFoundTransactionError}
+	case feePayer.Owner.ToBase58() != systemProgram.ID.ToBase58():
+		return &solana.TransactionError{Type: solana.InvalidAccountForFeeTransactionError}
+	case feePayer.Lamports < fee:
+		return &solana.TransactionError{Type: solana.InsufficientFundsForFeeTransactionError}
+	}
+	return nil
+}
+
+// preflightFailureError returns the error with which sending a transaction that would fail with the given error is rejected
+func preflightFailureError(txnErr *solana.TransactionError) *jsonrpc.RPCError {
+	return &jsonrpc.RPCError{
+		Code:    int(solana.SendTransactionPreflightFailureRPCErrorCode),
+		Message: fmt.Sprintf("Transaction simulation failed: %s", txnErr),
+		Data: map[string]interface{}{
+			"err":  txnErr,
+			"logs": []string{},
+		},
+	}
+}
+
+// decoder reads the fields of a serialised transaction
+type decoder struct {
+	data   []byte
+	offset int
+}
+
+// bytes reads the next n bytes
+func (d *decoder) bytes(n int) ([]byte, error) {
+	if len(d.data)-d.offset < n {
+		return nil, fmt.Errorf("data ended at byte %d: %w", len(d.data), ErrInvalidTransaction)
+	}
+	b := d.data[d.offset : d.offset+n]
+	d.offset += n
+	return b, nil
+}
+
+// compactU16 reads the next compact-u16 encoded value
+func (d *decoder) compactU16() (uint16, error) {
+	value, n, err := encoding.DecodeCompactU16(d.data[d.offset:])
+	if err != nil {
+		return 0, fmt.Errorf("%v: %w", err, ErrInvalidTransaction)
+	}
+	d.offset += n
+	return value, nil
+}
@@ -1,5 +1,10 @@
 package solana
 
+import (
+	"context"
+	"fmt"
+)
+
 // FeeCalculator can be used to CalculateTransactionFee to send a given
 // Transaction according to the fee schedule at this FeeScheduleBlockHash.
 //
@@ -43,3 +48,52 @@ func (f *FeeCalculator) FeeScheduleBlockHash() string {
 func (f *FeeCalculator) CalculateTransactionFee(transaction Transaction) int64 {
 	return f.lamportsPerSignature * int64(len(transaction.signatures))
 }
+
+// NewFeeCalculator returns a FeeCalculator for the fee schedule at the given block hash
+func NewFeeCalculator(blockHash string, lamportsPerSignature int64) FeeCalculator {
+	return FeeCalculator{
+		blockHash:            blockHash,
+		lamportsPerSignature: lamportsPerSignature,
+	}
+}
+
+// GetFeeCalculator returns a FeeCalculator for the fee schedule at the latest block hash.
+//
+// Current validators no longer serve the fee schedule with a recent block hash,
+// so the fee schedule is determined by getting the fee for a message with a
+// single signature at the latest block hash with GetFeeForMessage.
+func GetFeeCalculator(ctx context.Context, connection Connection, commitmentLevel CommitmentLevel) (*FeeCalculator, error) {
+	// get latest block hash
+	getLatestBlockhashResponse, err := connection.GetLatestBlockhash(
+		ctx,
+		GetLatestBlockhashRequest{
+			CommitmentLevel: commitmentLevel,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest block hash: %w", err)
+	}
+
+	// get fee for a message with a single signature
+	getFeeForMessageResponse, err := connection.GetFeeForMessage(
+		ctx,
+		GetFeeForMessageRequest{
+			Message: Message{
+				Header:          MessageHeader{NumRequiredSignatures: 1},
+				AccountKeys:     []PublicKey{NewPublicKeyFromBytes([32]byte{})},
+				RecentBlockHash: getLatestBlockhashResponse.BlockHash,
+			},
+			CommitmentLevel: commitmentLevel,
+			MinContextSlot:  getLatestBlockhashResponse.Context.Slot,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error getting fee for message: %w", err)
+	}
+	if getFeeForMessageResponse.Fee == nil {
+		return nil, fmt.Errorf("'%s': %w", getLatestBlockhashResponse.BlockHash, ErrBlockHashExpired)
+	}
+
+	feeCalculator := NewFeeCalculator(getLatestBlockhashResponse.BlockHash, int64(*getFeeForMessageResponse.Fee))
+	return &feeCalculator, nil
+}
@@ -0,0 +1,99 @@
+package solana
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// feeConnection is a Connection that serves
+// GetLatestBlockhash and GetFeeForMessage
+type feeConnection struct {
+	Connection
+	getLatestBlockhashErr error
+	fee                   *uint64
+	getFeeForMessageErr   error
+	feeForMessageRequests []GetFeeForMessageRequest
+}
+
+func (c *feeConnection) GetLatestBlockhash(_ context.Context, _ GetLatestBlockhashRequest) (*GetLatestBlockhashResponse, error) {
+	if c.getLatestBlockhashErr != nil {
+		return nil, c.getLatestBlockhashErr
+	}
+	return &GetLatestBlockhashResponse{
+		Context:              Context{Slot: 100},
+		BlockHash:            "CSymwgTNX1j3E4qhKfJAUE41nBWEwXufoYryPbkde5RR",
+		LastValidBlockHeight: 250,
+	}, nil
+}
+
+func (c *feeConnection) GetFeeForMessage(_ context.Context, request GetFeeForMessageRequest) (*GetFeeForMessageResponse, error) {
+	c.feeForMessageRequests = append(c.feeForMessageRequests, request)
+	if c.getFeeForMessageErr != nil {
+		return nil, c.getFeeForMessageErr
+	}
+	return &GetFeeForMessageResponse{
+		Context: Context{Slot: 100},
+		Fee:     c.fee,
+	}, nil
+}
+
+func TestGetFeeCalculator(t *testing.T) {
+	fee := uint64(5000)
+
+	tests := []struct {
+		name       string
+		connection *feeConnection
+		want       *FeeCalculator
+		wantErr    bool
+		wantErrIs  error
+	}{
+		{
+			name:       "error getting latest block hash",
+			connection: &feeConnection{getLatestBlockhashErr: errors.New("some err")},
+			wantErr:    true,
+		},
+		{
+			name:       "error getting fee for message",
+			connection: &feeConnection{getFeeForMessageErr: errors.New("some err")},
+			wantErr:    true,
+		},
+		{
+			name:       "block hash expired",
+			connection: &feeConnection{},
+			wantErr:    true,
+			wantErrIs:  ErrBlockHashExpired,
+		},
+		{
+			name:       "success",
+			connection: &feeConnection{fee: &fee},
+			want: &FeeCalculator{
+				blockHash:            "CSymwgTNX1j3E4qhKfJAUE41nBWEwXufoYryPbkde5RR",
+				lamportsPerSignature: 5000,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetFeeCalculator(context.Background(), tt.connection, FinalizedCommitmentLevel)
+			require.Equalf(t, tt.wantErr, err != nil, "error is nil")
+			if tt.wantErrIs != nil {
+				require.ErrorIs(t, err, tt.wantErrIs)
+			}
+			require.Equal(t, tt.want, got)
+			if tt.wantErr {
+				return
+			}
+
+			// fee is requested for a single signature message at the latest block hash
+			require.Len(t, tt.connection.feeForMessageRequests, 1)
+			request := tt.connection.feeForMessageRequests[0]
+			require.Equal(t, uint8(1), request.Message.Header.NumRequiredSignatures)
+			require.Equal(t, "CSymwgTNX1j3E4qhKfJAUE41nBWEwXufoYryPbkde5RR", request.Message.RecentBlockHash)
+			require.Equal(t, uint64(100), request.MinContextSlot)
+			_, err = request.Message.ToBytes()
+			require.Nil(t, err)
+		})
+	}
+}
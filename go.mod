@@ -4,5 +4,6 @@ go 1.16
 
 require (
 	github.com/btcsuite/btcutil v1.0.3-0.20201208143702-a53e38424cce
+	github.com/gorilla/websocket v1.5.0
 	github.com/stretchr/testify v1.7.0
 )
@@ -0,0 +1,66 @@
+package instrumentation
+
+import (
+	"context"
+	"errors"
+	"github.com/BRBussy/solgo"
+	"strconv"
+)
+
+const (
+	// HTTPErrorClass is the class of calls that failed with an http error status code
+	HTTPErrorClass = "http"
+
+	// RPCErrorClass is the class of requests to which the node responded with an RPCError
+	RPCErrorClass = "rpc"
+
+	// ConnectionRefusedErrorClass is the class of calls of which the connection was refused
+	ConnectionRefusedErrorClass = "connection_refused"
+
+	// CanceledErrorClass is the class of calls of which the context was canceled
+	CanceledErrorClass = "canceled"
+
+	// DeadlineExceededErrorClass is the class of calls of which the context deadline was exceeded
+	DeadlineExceededErrorClass = "deadline_exceeded"
+
+	// OtherErrorClass is the class of calls that failed with any other error
+	OtherErrorClass = "other"
+)
+
+// classifyCallError returns the error class of a call that failed with the given
+// error, and the code of the error, i.e. the http status code of an HTTPError
+func classifyCallError(err error) (class string, code string) {
+	var httpError *solana.HTTPError
+	switch {
+	case errors.As(err, &httpError):
+		return HTTPErrorClass, strconv.Itoa(httpError.Code)
+	case errors.Is(err, solana.ErrConnectionRefused):
+		return ConnectionRefusedErrorClass, ""
+	case errors.Is(err, context.Canceled):
+		return CanceledErrorClass, ""
+	case errors.Is(err, context.DeadlineExceeded):
+		return DeadlineExceededErrorClass, ""
+	default:
+		return OtherErrorClass, ""
+	}
+}
+
+// requestErrors returns the error class and code of each request of a call that returned
+// the given responses and error, or an empty class for requests that succeeded
+func requestErrors(call *solana.JSONRPCCall, responses []*solana.JSONRPCResponse, err error) (classes []string, codes []string) {
+	classes = make([]string, len(call.Requests))
+	codes = make([]string, len(call.Requests))
+	if err != nil {
+		class, code := classifyCallError(err)
+		for i := range classes {
+			classes[i], codes[i] = class, code
+		}
+		return classes, codes
+	}
+	for i := range classes {
+		if i < len(responses) && responses[i] != nil && responses[i].Error != nil {
+			classes[i], codes[i] = RPCErrorClass, strconv.Itoa(int(responses[i].Error.Code))
+		}
+	}
+	return classes, codes
+}
@@ -0,0 +1,72 @@
+package instrumentation
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/BRBussy/solgo"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTestServer returns a server that responds to getBalance and getSlot, fails getHealth
+// with an http error status code, and responds to any other method with an rpc error
+func newTestServer(t *testing.T) *httptest.Server {
+	// response returns the response to a request of the given method and id
+	response := func(method string, id int) string {
+		switch method {
+		case "getBalance":
+			return fmt.Sprintf(`{"jsonrpc":"2.0","result":{"context":{"slot":42},"value":10},"id":%d}`, id)
+		case "getSlot":
+			return fmt.Sprintf(`{"jsonrpc":"2.0","result":42,"id":%d}`, id)
+		default:
+			return fmt.Sprintf(`{"jsonrpc":"2.0","error":{"code":-32601,"message":"Method not found"},"id":%d}`, id)
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			Method string `json:"method"`
+			ID     int    `json:"id"`
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("error reading request: %v", err)
+			return
+		}
+
+		// respond to batch request
+		if body[0] == '[' {
+			var requests []request
+			if err := json.Unmarshal(body, &requests); err != nil {
+				t.Errorf("error decoding batch request: %v", err)
+				return
+			}
+			responses := make([]string, len(requests))
+			for i, req := range requests {
+				responses[i] = response(req.Method, req.ID)
+			}
+			_, _ = io.WriteString(w, "["+strings.Join(responses, ",")+"]")
+			return
+		}
+
+		// respond to request
+		var req request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Errorf("error decoding request: %v", err)
+			return
+		}
+		if req.Method == "getHealth" {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = io.WriteString(w, response(req.Method, req.ID))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// testPublicKey is a public key used in requests to the test server
+var testPublicKey = solana.NewPublicKeyFromBase58String("9B5XszUGdMaxCZ7uSQhPzdks5ZQSmWxrmzCSvtJ6Ns6g")
@@ -0,0 +1,170 @@
+// Package instrumentation provides prometheus metrics and OpenTelemetry tracing of the json-rpc
+// calls made by solana.JSONRPCConnections, installed as solana.JSONRPCInterceptors:
+//
+//	metrics, err := instrumentation.NewMetrics(prometheus.DefaultRegisterer)
+//	if err != nil {
+//		return err
+//	}
+//	connection := solana.NewJSONRPCConnection(
+//		solana.WithInterceptors(metrics.Interceptor(), instrumentation.TracingInterceptor(nil)),
+//	)
+//
+// It is a separate module so that its dependencies are only required by users of it.
+package instrumentation
+
+import (
+	"context"
+	"fmt"
+	"github.com/BRBussy/solgo"
+	"github.com/prometheus/client_golang/prometheus"
+	"time"
+)
+
+// BatchMethod is the method label of the duration and in-flight metrics of batch calls
+const BatchMethod = "batch"
+
+// Metrics records prometheus metrics of the json-rpc calls made by JSONRPCConnections.
+// Install it on a JSONRPCConnection with solana.WithInterceptors(metrics.Interceptor()).
+//
+// The following metrics are recorded, with names prefixed by the configured namespace:
+//   - rpc_requests_total: the number of requests made, by method.
+//     Each request of a batch call is counted.
+//   - rpc_request_errors_total: the number of failed requests, by method, error
+//     class and code. The code is the http status code of HTTPErrorClass errors,
+//     and the RPCErrorCode of RPCErrorClass errors.
+//   - rpc_request_duration_seconds: a histogram of the duration of calls, by method.
+//     Batch calls are observed with the BatchMethod label.
+//   - rpc_requests_in_flight: the number of calls in flight, by method.
+//     Batch calls are counted with the BatchMethod label.
+type Metrics struct {
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	inFlight *prometheus.GaugeVec
+}
+
+// metricsConfig is the configuration for Metrics
+type metricsConfig struct {
+	namespace string
+	buckets   []float64
+}
+
+// MetricsOption makes a change to the metricsConfig
+type MetricsOption interface {
+	apply(*metricsConfig)
+}
+
+type metricsOptionFunc func(*metricsConfig)
+
+func (fn metricsOptionFunc) apply(cfg *metricsConfig) {
+	fn(cfg)
+}
+
+// WithMetricsNamespace sets the namespace with which the names of the metrics are prefixed
+func WithMetricsNamespace(namespace string) MetricsOption {
+	return metricsOptionFunc(func(config *metricsConfig) {
+		config.namespace = namespace
+	})
+}
+
+// WithDurationBuckets sets the buckets, in seconds, of the call duration histogram
+func WithDurationBuckets(buckets []float64) MetricsOption {
+	return metricsOptionFunc(func(config *metricsConfig) {
+		config.buckets = buckets
+	})
+}
+
+// NewMetrics returns new Metrics that are registered with the given prometheus.Registerer.
+//
+// The default returned Metrics are configured with:
+//   - namespace: solana
+//   - buckets: prometheus.DefBuckets
+//
+// The passed opts are used to override these default values and configure the
+// returned Metrics as desired.
+func NewMetrics(registerer prometheus.Registerer, opts ...MetricsOption) (*Metrics, error) {
+	// prepare default configuration
+	config := &metricsConfig{
+		namespace: "solana",
+		buckets:   prometheus.DefBuckets,
+	}
+
+	// apply any provided options
+	for _, opt := range opts {
+		opt.apply(config)
+	}
+
+	m := &Metrics{
+		requests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: config.namespace,
+				Name:      "rpc_requests_total",
+				Help:      "Number of json-rpc requests made.",
+			},
+			[]string{"method"},
+		),
+		errors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: config.namespace,
+				Name:      "rpc_request_errors_total",
+				Help:      "Number of json-rpc requests that failed.",
+			},
+			[]string{"method", "class", "code"},
+		),
+		duration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: config.namespace,
+				Name:      "rpc_request_duration_seconds",
+				Help:      "Duration of json-rpc calls.",
+				Buckets:   config.buckets,
+			},
+			[]string{"method"},
+		),
+		inFlight: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: config.namespace,
+				Name:      "rpc_requests_in_flight",
+				Help:      "Number of json-rpc calls in flight.",
+			},
+			[]string{"method"},
+		),
+	}
+
+	// register metrics
+	for _, collector := range []prometheus.Collector{m.requests, m.errors, m.duration, m.inFlight} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, fmt.Errorf("error registering metric: %w", err)
+		}
+	}
+
+	return m, nil
+}
+
+// Interceptor returns a JSONRPCInterceptor that records the metrics of each call
+func (m *Metrics) Interceptor() solana.JSONRPCInterceptor {
+	return func(ctx context.Context, call *solana.JSONRPCCall, invoke solana.JSONRPCInvoker) ([]*solana.JSONRPCResponse, error) {
+		callMethod := BatchMethod
+		if !call.Batch && len(call.Requests) == 1 {
+			callMethod = call.Requests[0].Method
+		}
+
+		// perform call
+		inFlight := m.inFlight.WithLabelValues(callMethod)
+		inFlight.Inc()
+		start := time.Now()
+		responses, err := invoke(ctx, call)
+		m.duration.WithLabelValues(callMethod).Observe(time.Since(start).Seconds())
+		inFlight.Dec()
+
+		// record requests and errors
+		classes, codes := requestErrors(call, responses, err)
+		for i, request := range call.Requests {
+			m.requests.WithLabelValues(request.Method).Inc()
+			if classes[i] != "" {
+				m.errors.WithLabelValues(request.Method, classes[i], codes[i]).Inc()
+			}
+		}
+
+		return responses, err
+	}
+}
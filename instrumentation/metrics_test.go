@@ -0,0 +1,101 @@
+package instrumentation
+
+import (
+	"context"
+	"github.com/BRBussy/solgo"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestMetrics_Interceptor(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	metrics, err := NewMetrics(registry, WithMetricsNamespace("test"))
+	require.NoError(t, err)
+
+	// metrics can only be registered once
+	_, err = NewMetrics(registry, WithMetricsNamespace("test"))
+	require.Error(t, err)
+
+	server := newTestServer(t)
+	connection := solana.NewJSONRPCConnection(
+		solana.WithEndpoint(server.URL),
+		solana.WithInterceptors(metrics.Interceptor()),
+	)
+
+	// perform calls
+	_, err = connection.GetBalance(context.Background(), solana.GetBalanceRequest{PublicKey: testPublicKey})
+	require.NoError(t, err)
+	_, err = connection.GetBalance(context.Background(), solana.GetBalanceRequest{PublicKey: testPublicKey})
+	require.NoError(t, err)
+	require.Error(t, connection.GetHealth(context.Background()))
+	_, err = connection.GetFirstAvailableBlock(context.Background())
+	require.Error(t, err)
+	batch := connection.NewBatch()
+	batch.GetBalance(solana.GetBalanceRequest{PublicKey: testPublicKey})
+	batch.GetAccountInfo(solana.GetAccountInfoRequest{PublicKey: testPublicKey})
+	require.NoError(t, batch.Execute(context.Background()))
+
+	// check metrics
+	require.NoError(t, testutil.CollectAndCompare(
+		metrics.requests,
+		strings.NewReader(`
+# HELP test_rpc_requests_total Number of json-rpc requests made.
+# TYPE test_rpc_requests_total counter
+test_rpc_requests_total{method="getAccountInfo"} 1
+test_rpc_requests_total{method="getBalance"} 3
+test_rpc_requests_total{method="getFirstAvailableBlock"} 1
+test_rpc_requests_total{method="getHealth"} 1
+`),
+	))
+	require.NoError(t, testutil.CollectAndCompare(
+		metrics.errors,
+		strings.NewReader(`
+# HELP test_rpc_request_errors_total Number of json-rpc requests that failed.
+# TYPE test_rpc_request_errors_total counter
+test_rpc_request_errors_total{class="http",code="429",method="getHealth"} 1
+test_rpc_request_errors_total{class="rpc",code="-32601",method="getAccountInfo"} 1
+test_rpc_request_errors_total{class="rpc",code="-32601",method="getFirstAvailableBlock"} 1
+`),
+	))
+	require.Equal(t, 4, testutil.CollectAndCount(metrics.duration))
+	require.Equal(t, float64(0), testutil.ToFloat64(metrics.inFlight.WithLabelValues("getBalance")))
+}
+
+func TestClassifyCallError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantClass string
+	}{
+		{
+			name:      "connection refused",
+			err:       solana.ErrConnectionRefused,
+			wantClass: ConnectionRefusedErrorClass,
+		},
+		{
+			name:      "canceled",
+			err:       context.Canceled,
+			wantClass: CanceledErrorClass,
+		},
+		{
+			name:      "deadline exceeded",
+			err:       context.DeadlineExceeded,
+			wantClass: DeadlineExceededErrorClass,
+		},
+		{
+			name:      "other",
+			err:       solana.ErrUnexpectedResponse,
+			wantClass: OtherErrorClass,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			class, code := classifyCallError(tt.err)
+			require.Equal(t, tt.wantClass, class)
+			require.Empty(t, code)
+		})
+	}
+}
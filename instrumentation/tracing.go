@@ -0,0 +1,106 @@
+package instrumentation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/BRBussy/solgo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the name of the tracer with which spans are started
+const tracerName = "github.com/BRBussy/solgo/instrumentation"
+
+const (
+	// SlotAttributeKey is the span attribute key of the slot of the Context of a response
+	SlotAttributeKey = attribute.Key("solana.slot")
+
+	// BatchSizeAttributeKey is the span attribute key of the number of requests in a batch call
+	BatchSizeAttributeKey = attribute.Key("solana.rpc.batch_size")
+
+	// ErrorClassAttributeKey is the span attribute key of the error class of a failed call
+	ErrorClassAttributeKey = attribute.Key("solana.rpc.error_class")
+)
+
+// TracingInterceptor returns a JSONRPCInterceptor that records an OpenTelemetry client span of each
+// call with the given trace.TracerProvider, or the global TracerProvider if nil is given.
+// Install it on a JSONRPCConnection with solana.WithInterceptors(TracingInterceptor(nil)).
+//
+// Spans are named after the method of the call, or "batch" for batch calls, and record the
+// method, the slot of the Context of the response, if any, and the error, if any. The span
+// of a call to which the node responds with an RPCError has the code of the error.
+func TracingInterceptor(tracerProvider trace.TracerProvider) solana.JSONRPCInterceptor {
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+	tracer := tracerProvider.Tracer(tracerName)
+
+	return func(ctx context.Context, call *solana.JSONRPCCall, invoke solana.JSONRPCInvoker) ([]*solana.JSONRPCResponse, error) {
+		// start span
+		spanName := BatchMethod
+		attributes := []attribute.KeyValue{attribute.String("rpc.system", "jsonrpc")}
+		if !call.Batch && len(call.Requests) == 1 {
+			spanName = call.Requests[0].Method
+			attributes = append(attributes, attribute.String("rpc.method", call.Requests[0].Method))
+		} else {
+			attributes = append(attributes, BatchSizeAttributeKey.Int(len(call.Requests)))
+		}
+		ctx, span := tracer.Start(
+			ctx,
+			spanName,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attributes...),
+		)
+		defer span.End()
+
+		// perform call
+		responses, err := invoke(ctx, call)
+		if err != nil {
+			class, code := classifyCallError(err)
+			span.SetAttributes(ErrorClassAttributeKey.String(class))
+			if code != "" {
+				span.SetAttributes(attribute.String("http.response.status_code", code))
+			}
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return responses, err
+		}
+
+		// record slot and errors of responses
+		if slot, ok := responsesSlot(responses); ok {
+			span.SetAttributes(SlotAttributeKey.Int64(int64(slot)))
+		}
+		for _, response := range responses {
+			if response != nil && response.Error != nil {
+				span.SetAttributes(
+					ErrorClassAttributeKey.String(RPCErrorClass),
+					attribute.Int("rpc.jsonrpc.error_code", int(response.Error.Code)),
+				)
+				span.SetStatus(codes.Error, fmt.Sprintf("rpc error: %s", response.Error.Error()))
+				break
+			}
+		}
+
+		return responses, nil
+	}
+}
+
+// responsesSlot returns the slot of the Context of the first of the given responses that has one
+func responsesSlot(responses []*solana.JSONRPCResponse) (uint64, bool) {
+	for _, response := range responses {
+		if response == nil || len(response.Result) == 0 || response.Result[0] != '{' {
+			continue
+		}
+		var result struct {
+			Context *solana.Context `json:"context"`
+		}
+		if err := json.Unmarshal(response.Result, &result); err != nil || result.Context == nil {
+			continue
+		}
+		return result.Context.Slot, true
+	}
+	return 0, false
+}
@@ -0,0 +1,98 @@
+package instrumentation
+
+import (
+	"context"
+	"github.com/BRBussy/solgo"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"testing"
+)
+
+func TestTracingInterceptor(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	server := newTestServer(t)
+	connection := solana.NewJSONRPCConnection(
+		solana.WithEndpoint(server.URL),
+		solana.WithInterceptors(TracingInterceptor(tracerProvider)),
+	)
+
+	// perform calls
+	_, err := connection.GetBalance(context.Background(), solana.GetBalanceRequest{PublicKey: testPublicKey})
+	require.NoError(t, err)
+	_, err = connection.GetSlot(context.Background(), solana.GetSlotRequest{})
+	require.NoError(t, err)
+	require.Error(t, connection.GetHealth(context.Background()))
+	_, err = connection.GetFirstAvailableBlock(context.Background())
+	require.Error(t, err)
+	batch := connection.NewBatch()
+	batch.GetBalance(solana.GetBalanceRequest{PublicKey: testPublicKey})
+	batch.GetBalance(solana.GetBalanceRequest{PublicKey: testPublicKey})
+	require.NoError(t, batch.Execute(context.Background()))
+
+	// check spans
+	spans := recorder.Ended()
+	require.Len(t, spans, 5)
+	tests := []struct {
+		name           string
+		wantAttributes []attribute.KeyValue
+		wantStatus     codes.Code
+	}{
+		{
+			name: "getBalance",
+			wantAttributes: []attribute.KeyValue{
+				attribute.String("rpc.system", "jsonrpc"),
+				attribute.String("rpc.method", "getBalance"),
+				SlotAttributeKey.Int64(42),
+			},
+		},
+		{
+			name: "getSlot",
+			wantAttributes: []attribute.KeyValue{
+				attribute.String("rpc.system", "jsonrpc"),
+				attribute.String("rpc.method", "getSlot"),
+			},
+		},
+		{
+			name: "getHealth",
+			wantAttributes: []attribute.KeyValue{
+				attribute.String("rpc.system", "jsonrpc"),
+				attribute.String("rpc.method", "getHealth"),
+				ErrorClassAttributeKey.String(HTTPErrorClass),
+				attribute.String("http.response.status_code", "429"),
+			},
+			wantStatus: codes.Error,
+		},
+		{
+			name: "getFirstAvailableBlock",
+			wantAttributes: []attribute.KeyValue{
+				attribute.String("rpc.system", "jsonrpc"),
+				attribute.String("rpc.method", "getFirstAvailableBlock"),
+				ErrorClassAttributeKey.String(RPCErrorClass),
+				attribute.Int("rpc.jsonrpc.error_code", -32601),
+			},
+			wantStatus: codes.Error,
+		},
+		{
+			name: "batch",
+			wantAttributes: []attribute.KeyValue{
+				attribute.String("rpc.system", "jsonrpc"),
+				BatchSizeAttributeKey.Int(2),
+				SlotAttributeKey.Int64(42),
+			},
+		},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.name, spans[i].Name())
+			require.Equal(t, trace.SpanKindClient, spans[i].SpanKind())
+			require.Equal(t, tt.wantAttributes, spans[i].Attributes())
+			require.Equal(t, tt.wantStatus, spans[i].Status().Code)
+		})
+	}
+}
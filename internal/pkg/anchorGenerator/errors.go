@@ -0,0 +1,11 @@
+package anchorGenerator
+
+import "errors"
+
+var (
+	ErrInvalidIDL          = errors.New("invalid idl")
+	ErrUnsupportedIDLType  = errors.New("unsupported idl type")
+	ErrUndefinedType       = errors.New("undefined type")
+	ErrDuplicateIdentifier = errors.New("duplicate identifier")
+	ErrNoProgramAddress    = errors.New("no program address")
+)
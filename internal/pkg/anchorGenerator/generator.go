@@ -0,0 +1,724 @@
+// Package anchorGenerator generates Go client packages for Anchor programs from their IDL.
+package anchorGenerator
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/BRBussy/solgo/anchor"
+	"go/format"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// Config is the configuration of a generated package
+type Config struct {
+	// PackageName is the name of the generated package.
+	// Defaults to the lower camel case name of the program.
+	PackageName string
+
+	// ProgramAddress is the address of the program.
+	// Defaults to the address in the IDL metadata.
+	ProgramAddress string
+}
+
+// PackageName returns the name of the package generated for the given IDL with the given Config
+func PackageName(idl IDL, config Config) string {
+	if config.PackageName != "" {
+		return config.PackageName
+	}
+	return lowerCamelCase(idl.Name)
+}
+
+// Generate generates the source of a Go client package for the program described by the given IDL
+func Generate(idl IDL, config Config) ([]byte, error) {
+	// build the model of the package
+	m, err := newProgramModel(idl, config)
+	if err != nil {
+		return nil, err
+	}
+
+	// render and format source
+	buf := new(bytes.Buffer)
+	if err := programTemplate.Execute(buf, m); err != nil {
+		return nil, fmt.Errorf("error rendering package: %w", err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error formatting generated package: %w", err)
+	}
+
+	return src, nil
+}
+
+type programModel struct {
+	PackageName   string
+	ProgramName   string
+	Address       string
+	Docs          []string
+	Imports       []string
+	Instructions  []instructionModel
+	Accounts      []structModel
+	Types         []typeModel
+	Events        []structModel
+	Errors        []errorModel
+	usedNames     map[string]bool
+	definedTypes  map[string]bool
+	importsNeeded map[string]bool
+}
+
+type instructionModel struct {
+	Name          string
+	IDLName       string
+	Docs          []string
+	Discriminator string
+	Accounts      []accountMetaModel
+	Args          []fieldModel
+}
+
+type accountMetaModel struct {
+	FieldName string
+	Docs      []string
+	Writable  bool
+	Signer    bool
+	Optional  bool
+}
+
+type fieldModel struct {
+	Name   string
+	GoType string
+	Docs   []string
+}
+
+type structModel struct {
+	Name          string
+	IDLName       string
+	Docs          []string
+	Discriminator string
+	Fields        []fieldModel
+}
+
+type typeModel struct {
+	Name string
+	Docs []string
+
+	// Fields is set for structs
+	Fields []fieldModel
+	IsEnum bool
+
+	// SimpleVariants is set for enums without any fields
+	SimpleVariants []string
+
+	// Variants is set for enums in which some variant has fields
+	Variants []variantModel
+}
+
+type variantModel struct {
+	Name      string
+	FieldType string
+}
+
+type errorModel struct {
+	Name string
+	Code uint32
+	Msg  string
+}
+
+func newProgramModel(idl IDL, config Config) (*programModel, error) {
+	m := &programModel{
+		PackageName:   PackageName(idl, config),
+		ProgramName:   idl.Name,
+		Address:       config.ProgramAddress,
+		Docs:          idl.Docs,
+		usedNames:     make(map[string]bool),
+		definedTypes:  make(map[string]bool),
+		importsNeeded: map[string]bool{"github.com/BRBussy/solgo": true},
+	}
+	if m.Address == "" {
+		m.Address = idl.Metadata.Address
+	}
+	if m.Address == "" {
+		return nil, ErrNoProgramAddress
+	}
+
+	// register user defined types so that references can be checked
+	for _, typeDef := range append(append([]IDLTypeDef{}, idl.Accounts...), idl.Types...) {
+		m.definedTypes[typeDef.Name] = true
+	}
+
+	for _, instruction := range idl.Instructions {
+		if err := m.addInstruction(instruction); err != nil {
+			return nil, fmt.Errorf("error generating instruction %s: %w", instruction.Name, err)
+		}
+	}
+	for _, account := range idl.Accounts {
+		if err := m.addAccount(account); err != nil {
+			return nil, fmt.Errorf("error generating account %s: %w", account.Name, err)
+		}
+	}
+	for _, typeDef := range idl.Types {
+		if err := m.addType(typeDef); err != nil {
+			return nil, fmt.Errorf("error generating type %s: %w", typeDef.Name, err)
+		}
+	}
+	for _, event := range idl.Events {
+		if err := m.addEvent(event); err != nil {
+			return nil, fmt.Errorf("error generating event %s: %w", event.Name, err)
+		}
+	}
+	if len(idl.Events) > 0 {
+		if err := m.declare("RegisterEvents"); err != nil {
+			return nil, err
+		}
+	}
+	if len(idl.Errors) > 0 {
+		if err := m.declare("Error"); err != nil {
+			return nil, err
+		}
+		m.importsNeeded["fmt"] = true
+	}
+	for _, errorCode := range idl.Errors {
+		name := upperCamelCase(errorCode.Name) + "Error"
+		if err := m.declare(name); err != nil {
+			return nil, err
+		}
+		msg := errorCode.Msg
+		if msg == "" {
+			msg = errorCode.Name
+		}
+		m.Errors = append(m.Errors, errorModel{Name: name, Code: errorCode.Code, Msg: msg})
+	}
+
+	for importPath := range m.importsNeeded {
+		m.Imports = append(m.Imports, importPath)
+	}
+	sort.Strings(m.Imports)
+
+	return m, nil
+}
+
+// declare records the declaration of the given top level identifier
+func (m *programModel) declare(names ...string) error {
+	for _, name := range names {
+		if m.usedNames[name] {
+			return fmt.Errorf("%s: %w", name, ErrDuplicateIdentifier)
+		}
+		m.usedNames[name] = true
+	}
+	return nil
+}
+
+func (m *programModel) addInstruction(instruction IDLInstruction) error {
+	name := upperCamelCase(instruction.Name)
+	if err := m.declare(
+		name,
+		name+"Params",
+		lowerCamelCase(instruction.Name)+"InstructionData",
+		name+"InstructionDiscriminator",
+	); err != nil {
+		return err
+	}
+	m.importsNeeded["fmt"] = true
+	m.importsNeeded["github.com/BRBussy/solgo/anchor"] = true
+	m.importsNeeded["github.com/BRBussy/solgo/borsh"] = true
+
+	ix := instructionModel{
+		Name:          name,
+		IDLName:       instruction.Name,
+		Docs:          instruction.Docs,
+		Discriminator: discriminatorLiteral(anchor.NewInstructionDiscriminator(snakeCase(instruction.Name))),
+		Accounts:      flattenAccounts("", instruction.Accounts),
+	}
+
+	// params fields must be unique
+	paramsFields := make(map[string]bool)
+	for _, account := range ix.Accounts {
+		if paramsFields[account.FieldName] {
+			return fmt.Errorf("account %s: %w", account.FieldName, ErrDuplicateIdentifier)
+		}
+		paramsFields[account.FieldName] = true
+	}
+	// the instruction data struct already has a Discriminator field
+	paramsFields["Discriminator"] = true
+	for _, arg := range instruction.Args {
+		field, err := m.newFieldModel(arg)
+		if err != nil {
+			return err
+		}
+		if paramsFields[field.Name] {
+			field.Name += "Arg"
+		}
+		if paramsFields[field.Name] {
+			return fmt.Errorf("arg %s: %w", arg.Name, ErrDuplicateIdentifier)
+		}
+		paramsFields[field.Name] = true
+		ix.Args = append(ix.Args, field)
+	}
+
+	m.Instructions = append(m.Instructions, ix)
+	return nil
+}
+
+// flattenAccounts flattens nested account groups, prefixing
+// the name of each nested account with the name of its group
+func flattenAccounts(prefix string, accounts []IDLAccount) []accountMetaModel {
+	var flattened []accountMetaModel
+	for _, account := range accounts {
+		fieldName := prefix + upperCamelCase(account.Name)
+		if len(account.Accounts) > 0 {
+			flattened = append(flattened, flattenAccounts(fieldName, account.Accounts)...)
+			continue
+		}
+		flattened = append(flattened, accountMetaModel{
+			FieldName: fieldName,
+			Docs:      account.Docs,
+			Writable:  account.IsMut,
+			Signer:    account.IsSigner,
+			Optional:  account.IsOptional,
+		})
+	}
+	return flattened
+}
+
+func (m *programModel) addAccount(account IDLTypeDef) error {
+	if account.Type.Kind != StructIDLTypeDefKind {
+		return fmt.Errorf("account of kind %s: %w", account.Type.Kind, ErrUnsupportedIDLType)
+	}
+	name := upperCamelCase(account.Name)
+	if err := m.declare(name, "Decode"+name, name+"AccountDiscriminator"); err != nil {
+		return err
+	}
+	m.importsNeeded["fmt"] = true
+	m.importsNeeded["github.com/BRBussy/solgo/anchor"] = true
+
+	fields, err := m.newFieldModels(account.Type.Fields)
+	if err != nil {
+		return err
+	}
+	m.Accounts = append(m.Accounts, structModel{
+		Name:          name,
+		Docs:          account.Docs,
+		Discriminator: discriminatorLiteral(anchor.NewAccountDiscriminator(account.Name)),
+		Fields:        fields,
+	})
+	return nil
+}
+
+func (m *programModel) addEvent(event IDLEvent) error {
+	name := upperCamelCase(event.Name)
+	if err := m.declare(name, "Decode"+name, name+"EventDiscriminator"); err != nil {
+		return err
+	}
+	m.importsNeeded["fmt"] = true
+	m.importsNeeded["github.com/BRBussy/solgo/anchor"] = true
+
+	fields, err := m.newFieldModels(event.Fields)
+	if err != nil {
+		return err
+	}
+	m.Events = append(m.Events, structModel{
+		Name:          name,
+		IDLName:       event.Name,
+		Discriminator: discriminatorLiteral(anchor.NewEventDiscriminator(event.Name)),
+		Fields:        fields,
+	})
+	return nil
+}
+
+func (m *programModel) addType(typeDef IDLTypeDef) error {
+	name := upperCamelCase(typeDef.Name)
+	if err := m.declare(name); err != nil {
+		return err
+	}
+
+	switch typeDef.Type.Kind {
+	case StructIDLTypeDefKind:
+		fields, err := m.newFieldModels(typeDef.Type.Fields)
+		if err != nil {
+			return err
+		}
+		m.Types = append(m.Types, typeModel{
+			Name:   name,
+			Docs:   withSummary(fmt.Sprintf("%s is a %s program type", name, m.ProgramName), typeDef.Docs),
+			Fields: fields,
+		})
+		return nil
+
+	case EnumIDLTypeDefKind:
+		return m.addEnum(name, typeDef)
+
+	default:
+		return fmt.Errorf("type of kind %s: %w", typeDef.Type.Kind, ErrUnsupportedIDLType)
+	}
+}
+
+func (m *programModel) addEnum(name string, typeDef IDLTypeDef) error {
+	simple := true
+	for _, variant := range typeDef.Type.Variants {
+		if len(variant.NamedFields) > 0 || len(variant.TupleFields) > 0 {
+			simple = false
+		}
+	}
+
+	// enums without fields are encoded as a uint8
+	if simple {
+		enum := typeModel{
+			Name:   name,
+			Docs:   withSummary(fmt.Sprintf("%s is a %s program enum", name, m.ProgramName), typeDef.Docs),
+			IsEnum: true,
+		}
+		for _, variant := range typeDef.Type.Variants {
+			variantName := upperCamelCase(variant.Name) + name
+			if err := m.declare(variantName); err != nil {
+				return err
+			}
+			enum.SimpleVariants = append(enum.SimpleVariants, variantName)
+		}
+		m.Types = append(m.Types, enum)
+		return nil
+	}
+
+	// enums with fields are encoded as a struct holding a field per variant
+	kindName := name + "Kind"
+	if err := m.declare(kindName); err != nil {
+		return err
+	}
+	kind := typeModel{
+		Name:   kindName,
+		Docs:   []string{fmt.Sprintf("%s is the variant of a %s", kindName, name)},
+		IsEnum: true,
+	}
+	enum := typeModel{
+		Name:   name,
+		Docs:   withSummary(fmt.Sprintf("%s is a %s program enum. Kind selects the variant held.", name, m.ProgramName), typeDef.Docs),
+		Fields: []fieldModel{{Name: "Kind", GoType: kindName + " `borsh:\"enum\"`"}},
+	}
+	var variantTypes []typeModel
+	for _, variant := range typeDef.Type.Variants {
+		variantName := upperCamelCase(variant.Name)
+		if err := m.declare(variantName + kindName); err != nil {
+			return err
+		}
+		kind.SimpleVariants = append(kind.SimpleVariants, variantName+kindName)
+
+		variantModel := variantModel{Name: variantName, FieldType: "struct{}"}
+		if len(variant.NamedFields) > 0 || len(variant.TupleFields) > 0 {
+			variantModel.FieldType = name + variantName
+			if err := m.declare(variantModel.FieldType); err != nil {
+				return err
+			}
+			fields, err := m.newFieldModels(variant.NamedFields)
+			if err != nil {
+				return err
+			}
+			for i, tupleField := range variant.TupleFields {
+				goType, err := m.goType(tupleField)
+				if err != nil {
+					return err
+				}
+				fields = append(fields, fieldModel{Name: fmt.Sprintf("Field%d", i), GoType: goType})
+			}
+			variantTypes = append(variantTypes, typeModel{
+				Name:   variantModel.FieldType,
+				Docs:   []string{fmt.Sprintf("%s is the value of the %s variant of a %s", variantModel.FieldType, variantName, name)},
+				Fields: fields,
+			})
+		}
+		enum.Fields = append(enum.Fields, fieldModel{Name: variantModel.Name, GoType: variantModel.FieldType})
+		enum.Variants = append(enum.Variants, variantModel)
+	}
+	m.Types = append(m.Types, kind, enum)
+	m.Types = append(m.Types, variantTypes...)
+	return nil
+}
+
+func (m *programModel) newFieldModels(fields []IDLField) ([]fieldModel, error) {
+	fieldModels := make([]fieldModel, 0, len(fields))
+	for _, field := range fields {
+		fieldModel, err := m.newFieldModel(field)
+		if err != nil {
+			return nil, err
+		}
+		fieldModels = append(fieldModels, fieldModel)
+	}
+	return fieldModels, nil
+}
+
+func (m *programModel) newFieldModel(field IDLField) (fieldModel, error) {
+	goType, err := m.goType(field.Type)
+	if err != nil {
+		return fieldModel{}, fmt.Errorf("field %s: %w", field.Name, err)
+	}
+	return fieldModel{
+		Name:   upperCamelCase(field.Name),
+		GoType: goType,
+		Docs:   field.Docs,
+	}, nil
+}
+
+// goType returns the Go type to which the given IDLType is mapped
+func (m *programModel) goType(t IDLType) (string, error) {
+	switch {
+	case t.Vec != nil:
+		elemType, err := m.goType(*t.Vec)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elemType, nil
+
+	case t.Option != nil:
+		elemType, err := m.goType(*t.Option)
+		if err != nil {
+			return "", err
+		}
+		return "*" + elemType, nil
+
+	case t.Array != nil:
+		elemType, err := m.goType(*t.Array)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("[%d]%s", t.ArrayLen, elemType), nil
+
+	case t.Defined != "":
+		if !m.definedTypes[t.Defined] {
+			return "", fmt.Errorf("%s: %w", t.Defined, ErrUndefinedType)
+		}
+		return upperCamelCase(t.Defined), nil
+	}
+
+	switch t.Primitive {
+	case "bool", "u8", "i8", "u16", "i16", "u32", "i32", "u64", "i64", "f32", "f64":
+		return strings.NewReplacer("u", "uint", "i", "int", "f", "float").Replace(t.Primitive), nil
+	case "u128":
+		m.importsNeeded["github.com/BRBussy/solgo/borsh"] = true
+		return "borsh.Uint128", nil
+	case "i128":
+		m.importsNeeded["github.com/BRBussy/solgo/borsh"] = true
+		return "borsh.Int128", nil
+	case "string":
+		return "string", nil
+	case "bytes":
+		return "[]byte", nil
+	case "publicKey", "pubkey":
+		return "solana.PublicKey", nil
+	default:
+		return "", fmt.Errorf("%s: %w", t.Primitive, ErrUnsupportedIDLType)
+	}
+}
+
+// withSummary returns the given docs preceded by the given summary
+func withSummary(summary string, docs []string) []string {
+	return append([]string{summary}, docs...)
+}
+
+// discriminatorLiteral returns the Go source of the given discriminator
+func discriminatorLiteral(discriminator anchor.Discriminator) string {
+	elements := make([]string, 0, len(discriminator))
+	for _, b := range discriminator {
+		elements = append(elements, fmt.Sprintf("0x%02x", b))
+	}
+	return "anchor.Discriminator{" + strings.Join(elements, ", ") + "}"
+}
+
+var programTemplate = template.Must(template.New("program").Funcs(template.FuncMap{
+	"lowerFirst": lowerFirst,
+	"comment": func(docs []string) string {
+		var b strings.Builder
+		for _, doc := range docs {
+			b.WriteString("// " + strings.TrimSpace(doc) + "\n")
+		}
+		return b.String()
+	},
+}).Parse(`// Code generated by solgo anchor. DO NOT EDIT.
+
+// Package {{ .PackageName }} is a client for the {{ .ProgramName }} Anchor program.
+{{ comment .Docs -}}
+package {{ .PackageName }}
+
+import (
+{{- range .Imports }}
+	"{{ . }}"
+{{- end }}
+)
+
+// ID is the {{ .ProgramName }} program ID
+var ID = solana.NewPublicKeyFromBase58String("{{ .Address }}")
+{{- if .Instructions }}
+
+var (
+{{- range .Instructions }}
+	{{ .Name }}InstructionDiscriminator = {{ .Discriminator }}
+{{- end }}
+)
+{{- end }}
+{{- range .Instructions }}
+
+// {{ .Name }}Params are the parameters of the {{ .IDLName }} instruction
+type {{ .Name }}Params struct {
+{{- range .Accounts }}
+	{{ comment .Docs -}}
+	// Req: [{{ if .Writable }}writable{{ else }}read-only{{ end }}{{ if .Signer }}, signer{{ end }}{{ if .Optional }}, optional{{ end }}]
+	{{ .FieldName }} {{ if .Optional }}*{{ end }}solana.PublicKey
+{{ end }}
+{{- range .Args }}
+	{{ comment .Docs -}}
+	{{ .Name }} {{ .GoType }}
+{{- end }}
+}
+
+type {{ lowerFirst .Name }}InstructionData struct {
+	Discriminator anchor.Discriminator
+{{- range .Args }}
+	{{ .Name }} {{ .GoType }}
+{{- end }}
+}
+
+// {{ .Name }} creates a {{ $.ProgramName }} program {{ .IDLName }} Instruction.
+{{ comment .Docs -}}
+func {{ .Name }}(params {{ .Name }}Params) ([]solana.Instruction, error) {
+	// encode instruction data
+	data, err := borsh.Marshal(
+		{{ lowerFirst .Name }}InstructionData{
+			Discriminator: {{ .Name }}InstructionDiscriminator,
+{{- range .Args }}
+			{{ .Name }}: params.{{ .Name }},
+{{- end }}
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding {{ .IDLName }} data: %w", err)
+	}
+{{- range .Accounts }}{{ if .Optional }}
+
+	// optional accounts that are not given are replaced with the program ID
+	{{ lowerFirst .FieldName }}PubKey := ID
+	if params.{{ .FieldName }} != nil {
+		{{ lowerFirst .FieldName }}PubKey = *params.{{ .FieldName }}
+	}
+{{- end }}{{ end }}
+
+	// construct and return instruction
+	return []solana.Instruction{
+		{
+			InstructionAccountMeta: []solana.InstructionAccountMeta{
+{{- range .Accounts }}
+				{PubKey: {{ if .Optional }}{{ lowerFirst .FieldName }}PubKey{{ else }}params.{{ .FieldName }}{{ end }}, IsSigner: {{ .Signer }}, IsWritable: {{ .Writable }}},
+{{- end }}
+			},
+			ProgramIDPubKey: ID,
+			Data:            data,
+		},
+	}, nil
+}
+{{- end }}
+{{- if .Accounts }}
+
+var (
+{{- range .Accounts }}
+	{{ .Name }}AccountDiscriminator = {{ .Discriminator }}
+{{- end }}
+)
+{{- end }}
+{{- range .Accounts }}
+
+// {{ .Name }} is the state of a {{ $.ProgramName }} program {{ .Name }} account.
+{{ comment .Docs -}}
+type {{ .Name }} struct {
+{{- range .Fields }}
+	{{ comment .Docs -}}
+	{{ .Name }} {{ .GoType }}
+{{- end }}
+}
+
+// Decode{{ .Name }} decodes the given account data into a {{ .Name }}
+func Decode{{ .Name }}(data []byte) (*{{ .Name }}, error) {
+	var account {{ .Name }}
+	if err := anchor.DecodeAccount(data, {{ .Name }}AccountDiscriminator, &account); err != nil {
+		return nil, fmt.Errorf("error decoding {{ .Name }} account: %w", err)
+	}
+	return &account, nil
+}
+{{- end }}
+{{- range .Types }}
+
+{{ comment .Docs -}}
+{{- if .IsEnum }}{{ $enum := .Name -}}
+type {{ .Name }} uint8
+
+const (
+{{- range $i, $variant := .SimpleVariants }}
+	{{ $variant }}{{ if eq $i 0 }} {{ $enum }} = iota{{ end }}
+{{- end }}
+)
+{{- else -}}
+type {{ .Name }} struct {
+{{- range .Fields }}
+	{{ comment .Docs -}}
+	{{ .Name }} {{ .GoType }}
+{{- end }}
+}
+{{- end }}
+{{- end }}
+{{- if .Events }}
+
+var (
+{{- range .Events }}
+	{{ .Name }}EventDiscriminator = {{ .Discriminator }}
+{{- end }}
+)
+{{- end }}
+{{- range .Events }}
+
+// {{ .Name }} is a {{ $.ProgramName }} program {{ .Name }} event
+type {{ .Name }} struct {
+{{- range .Fields }}
+	{{ .Name }} {{ .GoType }}
+{{- end }}
+}
+
+// Decode{{ .Name }} decodes the given event data into a {{ .Name }}
+func Decode{{ .Name }}(data []byte) (*{{ .Name }}, error) {
+	var event {{ .Name }}
+	if err := anchor.DecodeEvent(data, {{ .Name }}EventDiscriminator, &event); err != nil {
+		return nil, fmt.Errorf("error decoding {{ .Name }} event: %w", err)
+	}
+	return &event, nil
+}
+{{- end }}
+{{- if .Events }}
+
+// RegisterEvents registers the {{ .ProgramName }} program events with the given anchor.EventRegistry
+func RegisterEvents(registry *anchor.EventRegistry) error {
+{{- range .Events }}
+	if err := registry.Register("{{ .IDLName }}", {{ .Name }}{}); err != nil {
+		return err
+	}
+{{- end }}
+	return nil
+}
+{{- end }}
+{{- if .Errors }}
+
+// Error is a {{ .ProgramName }} program custom error code
+type Error uint32
+
+const (
+{{- range .Errors }}
+	{{ .Name }} Error = {{ .Code }}
+{{- end }}
+)
+
+// Error returns the message of the Error
+func (e Error) Error() string {
+	switch e {
+{{- range .Errors }}
+	case {{ .Name }}:
+		return {{ printf "%q" .Msg }}
+{{- end }}
+	default:
+		return fmt.Sprintf("unknown {{ .ProgramName }} error code %d", uint32(e))
+	}
+}
+{{- end }}
+`))
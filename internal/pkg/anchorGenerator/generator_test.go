@@ -0,0 +1,121 @@
+package anchorGenerator
+
+import (
+	"flag"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name       string
+		idlFile    string
+		config     Config
+		goldenFile string
+	}{
+		{
+			name:       "counter program",
+			idlFile:    filepath.Join("testdata", "counter.json"),
+			goldenFile: filepath.Join("internal", "counter", "counter.go"),
+		},
+		{
+			name:       "reserved identifiers",
+			idlFile:    filepath.Join("testdata", "reserved.json"),
+			goldenFile: filepath.Join("internal", "reserved", "reserved.go"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idlData, err := os.ReadFile(tt.idlFile)
+			require.Nil(t, err)
+			idl, err := ParseIDL(idlData)
+			require.Nil(t, err)
+
+			src, err := Generate(*idl, tt.config)
+			require.Nil(t, err)
+
+			if *update {
+				require.Nil(t, os.WriteFile(tt.goldenFile, src, 0644))
+			}
+			golden, err := os.ReadFile(tt.goldenFile)
+			require.Nil(t, err)
+			require.Equal(t, string(golden), string(src))
+		})
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		idl     string
+		wantErr error
+	}{
+		{
+			name:    "no program address",
+			idl:     `{"name": "program"}`,
+			wantErr: ErrNoProgramAddress,
+		},
+		{
+			name:    "undefined type",
+			idl:     `{"name": "program", "metadata": {"address": "11111111111111111111111111111111"}, "instructions": [{"name": "a", "accounts": [], "args": [{"name": "b", "type": {"defined": "C"}}]}]}`,
+			wantErr: ErrUndefinedType,
+		},
+		{
+			name:    "duplicate identifier",
+			idl:     `{"name": "program", "metadata": {"address": "11111111111111111111111111111111"}, "instructions": [{"name": "a", "accounts": [], "args": []}], "types": [{"name": "A", "type": {"kind": "struct", "fields": []}}]}`,
+			wantErr: ErrDuplicateIdentifier,
+		},
+		{
+			name:    "unsupported type",
+			idl:     `{"name": "program", "metadata": {"address": "11111111111111111111111111111111"}, "types": [{"name": "A", "type": {"kind": "struct", "fields": [{"name": "b", "type": "u256"}]}}]}`,
+			wantErr: ErrUnsupportedIDLType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idl, err := ParseIDL([]byte(tt.idl))
+			require.Nil(t, err)
+			_, err = Generate(*idl, Config{})
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	tests := []struct {
+		name   string
+		idl    IDL
+		config Config
+		want   string
+	}{
+		{name: "program name", idl: IDL{Name: "token_vault"}, want: "tokenVault"},
+		{name: "configured name", idl: IDL{Name: "token_vault"}, config: Config{PackageName: "vault"}, want: "vault"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, PackageName(tt.idl, tt.config))
+		})
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "initialize", want: "initialize"},
+		{name: "initializeCounter", want: "initialize_counter"},
+		{name: "initialize_counter", want: "initialize_counter"},
+		{name: "setV2Authority", want: "set_v2_authority"},
+		{name: "createNFTMint", want: "create_nft_mint"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, snakeCase(tt.name))
+		})
+	}
+}
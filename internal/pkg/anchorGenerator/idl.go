@@ -0,0 +1,198 @@
+package anchorGenerator
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// IDL is an Anchor interface definition file, as generated by anchor build.
+// See the rust defs here: https://github.com/coral-xyz/anchor/blob/v0.29.0/lang/syn/src/idl/types.rs
+type IDL struct {
+	Version      string           `json:"version"`
+	Name         string           `json:"name"`
+	Docs         []string         `json:"docs"`
+	Instructions []IDLInstruction `json:"instructions"`
+	Accounts     []IDLTypeDef     `json:"accounts"`
+	Types        []IDLTypeDef     `json:"types"`
+	Events       []IDLEvent       `json:"events"`
+	Errors       []IDLErrorCode   `json:"errors"`
+	Metadata     IDLMetadata      `json:"metadata"`
+}
+
+// IDLMetadata is the metadata of an IDL
+type IDLMetadata struct {
+	Address string `json:"address"`
+}
+
+// IDLInstruction is an instruction of an IDL
+type IDLInstruction struct {
+	Name     string       `json:"name"`
+	Docs     []string     `json:"docs"`
+	Accounts []IDLAccount `json:"accounts"`
+	Args     []IDLField   `json:"args"`
+}
+
+// IDLAccount is an account required by an instruction.
+// Accounts is set if this is a nested group of accounts.
+type IDLAccount struct {
+	Name       string       `json:"name"`
+	Docs       []string     `json:"docs"`
+	IsMut      bool         `json:"isMut"`
+	IsSigner   bool         `json:"isSigner"`
+	IsOptional bool         `json:"isOptional"`
+	Accounts   []IDLAccount `json:"accounts"`
+}
+
+// IDLField is a named and typed field of a struct, instruction or event
+type IDLField struct {
+	Name string   `json:"name"`
+	Docs []string `json:"docs"`
+	Type IDLType  `json:"type"`
+}
+
+// IDLEvent is an event of an IDL
+type IDLEvent struct {
+	Name   string     `json:"name"`
+	Fields []IDLField `json:"fields"`
+}
+
+// IDLErrorCode is a custom error of an IDL
+type IDLErrorCode struct {
+	Code uint32 `json:"code"`
+	Name string `json:"name"`
+	Msg  string `json:"msg"`
+}
+
+// IDLTypeDef is a user defined struct or enum type
+type IDLTypeDef struct {
+	Name string          `json:"name"`
+	Docs []string        `json:"docs"`
+	Type IDLTypeDefInner `json:"type"`
+}
+
+// IDLTypeDefKind is the kind of an IDLTypeDef
+type IDLTypeDefKind string
+
+const (
+	StructIDLTypeDefKind IDLTypeDefKind = "struct"
+	EnumIDLTypeDefKind   IDLTypeDefKind = "enum"
+)
+
+// IDLTypeDefInner is the definition of a struct or enum type
+type IDLTypeDefInner struct {
+	Kind     IDLTypeDefKind   `json:"kind"`
+	Fields   []IDLField       `json:"fields"`
+	Variants []IDLEnumVariant `json:"variants"`
+}
+
+// IDLEnumVariant is a variant of an enum type.
+// Either NamedFields or TupleFields is set if the variant holds a value.
+type IDLEnumVariant struct {
+	Name        string
+	NamedFields []IDLField
+	TupleFields []IDLType
+}
+
+// UnmarshalJSON implements json.Unmarshaler for IDLEnumVariant, which
+// holds either a list of named fields or a list of tuple types.
+func (v *IDLEnumVariant) UnmarshalJSON(data []byte) error {
+	var variant struct {
+		Name   string            `json:"name"`
+		Fields []json.RawMessage `json:"fields"`
+	}
+	if err := json.Unmarshal(data, &variant); err != nil {
+		return err
+	}
+	v.Name = variant.Name
+	for _, rawField := range variant.Fields {
+		var namedField IDLField
+		if err := json.Unmarshal(rawField, &namedField); err == nil && namedField.Name != "" {
+			v.NamedFields = append(v.NamedFields, namedField)
+			continue
+		}
+		var tupleField IDLType
+		if err := json.Unmarshal(rawField, &tupleField); err != nil {
+			return fmt.Errorf("error parsing field of enum variant %s: %w", v.Name, err)
+		}
+		v.TupleFields = append(v.TupleFields, tupleField)
+	}
+	if len(v.NamedFields) > 0 && len(v.TupleFields) > 0 {
+		return fmt.Errorf("enum variant %s has both named and tuple fields: %w", v.Name, ErrInvalidIDL)
+	}
+	return nil
+}
+
+// IDLType is the type of a field.
+// Exactly one of Primitive, Vec, Option, Array or Defined is set.
+type IDLType struct {
+	// Primitive is the name of a primitive type, e.g. "u64" or "publicKey"
+	Primitive string
+
+	// Vec is the element type of a vector
+	Vec *IDLType
+
+	// Option is the type of an optional value
+	Option *IDLType
+
+	// Array is the element type of a fixed length array of length ArrayLen
+	Array    *IDLType
+	ArrayLen int
+
+	// Defined is the name of a user defined type
+	Defined string
+}
+
+// UnmarshalJSON implements json.Unmarshaler for IDLType
+func (t *IDLType) UnmarshalJSON(data []byte) error {
+	// primitive types are given as a string
+	if err := json.Unmarshal(data, &t.Primitive); err == nil {
+		return nil
+	}
+
+	var compound struct {
+		Vec     *IDLType          `json:"vec"`
+		Option  *IDLType          `json:"option"`
+		COption *IDLType          `json:"coption"`
+		Array   []json.RawMessage `json:"array"`
+		Defined string            `json:"defined"`
+	}
+	if err := json.Unmarshal(data, &compound); err != nil {
+		return err
+	}
+	switch {
+	case compound.Vec != nil:
+		t.Vec = compound.Vec
+	case compound.Option != nil:
+		t.Option = compound.Option
+	case compound.COption != nil:
+		return fmt.Errorf("coption: %w", ErrUnsupportedIDLType)
+	case compound.Array != nil:
+		if len(compound.Array) != 2 {
+			return fmt.Errorf("array type with %d elements: %w", len(compound.Array), ErrInvalidIDL)
+		}
+		t.Array = new(IDLType)
+		if err := json.Unmarshal(compound.Array[0], t.Array); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(compound.Array[1], &t.ArrayLen); err != nil {
+			return fmt.Errorf("error parsing array length: %w", err)
+		}
+	case compound.Defined != "":
+		t.Defined = compound.Defined
+	default:
+		return fmt.Errorf("type %s: %w", string(data), ErrUnsupportedIDLType)
+	}
+	return nil
+}
+
+// ParseIDL parses the given Anchor IDL JSON
+func ParseIDL(data []byte) (*IDL, error) {
+	var idl IDL
+	if err := json.Unmarshal(data, &idl); err != nil {
+		return nil, fmt.Errorf("error parsing idl: %w", err)
+	}
+	if idl.Name == "" {
+		return nil, fmt.Errorf("no program name: %w", ErrInvalidIDL)
+	}
+	return &idl, nil
+}
@@ -0,0 +1,58 @@
+package counter
+
+import (
+	"github.com/BRBussy/solgo"
+	"github.com/BRBussy/solgo/anchor"
+	"github.com/BRBussy/solgo/borsh"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestIncrement(t *testing.T) {
+	counter := solana.MustNewRandomKeypair().PublicKey
+	authority := solana.MustNewRandomKeypair().PublicKey
+
+	instructions, err := Increment(IncrementParams{
+		CommonCounter:   counter,
+		CommonAuthority: authority,
+		Step:            Step{Kind: ByStepKind, By: StepBy{Amount: 3}},
+	})
+	require.Nil(t, err)
+	require.Len(t, instructions, 1)
+	require.Equal(t, ID, instructions[0].ProgramIDPubKey)
+	require.Equal(
+		t,
+		[]solana.InstructionAccountMeta{
+			{PubKey: counter, IsSigner: false, IsWritable: true},
+			{PubKey: authority, IsSigner: true, IsWritable: false},
+			{PubKey: ID, IsSigner: false, IsWritable: false},
+		},
+		instructions[0].InstructionAccountMeta,
+	)
+	require.Equal(
+		t,
+		append(anchor.NewInstructionDiscriminator("increment").ToBytes(), 1, 3, 0, 0, 0, 0, 0, 0, 0),
+		instructions[0].Data,
+	)
+}
+
+func TestDecodeCounter(t *testing.T) {
+	label := "label"
+	want := Counter{
+		Authority: solana.MustNewRandomKeypair().PublicKey,
+		Count:     borsh.Uint128{Lo: 10},
+		Label:     &label,
+		Mode:      LockedMode,
+		History:   []Entry{{Slot: 1, Delta: -1}},
+		Seed:      [8]uint8{1, 2, 3, 4, 5, 6, 7, 8},
+	}
+	data, err := borsh.Marshal(want)
+	require.Nil(t, err)
+
+	got, err := DecodeCounter(append(CounterAccountDiscriminator.ToBytes(), data...))
+	require.Nil(t, err)
+	require.Equal(t, want, *got)
+
+	_, err = DecodeCounter(append(CounterIncrementedEventDiscriminator.ToBytes(), data...))
+	require.ErrorIs(t, err, anchor.ErrUnexpectedDiscriminator)
+}
@@ -0,0 +1,63 @@
+// Code generated by solgo anchor. DO NOT EDIT.
+
+// Package reserved is a client for the reserved Anchor program.
+package reserved
+
+import (
+	"fmt"
+	"github.com/BRBussy/solgo"
+	"github.com/BRBussy/solgo/anchor"
+	"github.com/BRBussy/solgo/borsh"
+)
+
+// ID is the reserved program ID
+var ID = solana.NewPublicKeyFromBase58String("Fg6PaFpoGXkYsidMpWTK6W2BeZ7FEfcYkg476zPFsLnS")
+
+var (
+	SetDiscriminatorInstructionDiscriminator = anchor.Discriminator{0x3c, 0x1c, 0xf8, 0xb8, 0x34, 0x15, 0x32, 0xa3}
+)
+
+// SetDiscriminatorParams are the parameters of the setDiscriminator instruction
+type SetDiscriminatorParams struct {
+	// Req: [writable]
+	Target solana.PublicKey
+
+	// Req: [read-only, signer]
+	Authority solana.PublicKey
+
+	DiscriminatorArg uint64
+	TargetArg        uint8
+}
+
+type setDiscriminatorInstructionData struct {
+	Discriminator    anchor.Discriminator
+	DiscriminatorArg uint64
+	TargetArg        uint8
+}
+
+// SetDiscriminator creates a reserved program setDiscriminator Instruction.
+func SetDiscriminator(params SetDiscriminatorParams) ([]solana.Instruction, error) {
+	// encode instruction data
+	data, err := borsh.Marshal(
+		setDiscriminatorInstructionData{
+			Discriminator:    SetDiscriminatorInstructionDiscriminator,
+			DiscriminatorArg: params.DiscriminatorArg,
+			TargetArg:        params.TargetArg,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding setDiscriminator data: %w", err)
+	}
+
+	// construct and return instruction
+	return []solana.Instruction{
+		{
+			InstructionAccountMeta: []solana.InstructionAccountMeta{
+				{PubKey: params.Target, IsSigner: false, IsWritable: true},
+				{PubKey: params.Authority, IsSigner: true, IsWritable: false},
+			},
+			ProgramIDPubKey: ID,
+			Data:            data,
+		},
+	}, nil
+}
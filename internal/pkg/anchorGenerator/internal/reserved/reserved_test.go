@@ -0,0 +1,27 @@
+package reserved
+
+import (
+	"github.com/BRBussy/solgo"
+	"github.com/BRBussy/solgo/anchor"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSetDiscriminator(t *testing.T) {
+	target := solana.MustNewRandomKeypair().PublicKey
+	authority := solana.MustNewRandomKeypair().PublicKey
+
+	instructions, err := SetDiscriminator(SetDiscriminatorParams{
+		Target:           target,
+		Authority:        authority,
+		DiscriminatorArg: 1,
+		TargetArg:        2,
+	})
+	require.Nil(t, err)
+	require.Len(t, instructions, 1)
+	require.Equal(
+		t,
+		append(anchor.NewInstructionDiscriminator("set_discriminator").ToBytes(), 1, 0, 0, 0, 0, 0, 0, 0, 2),
+		instructions[0].Data,
+	)
+}
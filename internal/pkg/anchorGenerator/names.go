@@ -0,0 +1,71 @@
+package anchorGenerator
+
+import (
+	"strings"
+	"unicode"
+)
+
+// words splits the given snake or camel case name into its words
+func words(name string) []string {
+	var (
+		result []string
+		word   []rune
+	)
+	runes := []rune(name)
+	for i, r := range runes {
+		if r == '_' || r == '-' || r == ' ' {
+			if len(word) > 0 {
+				result = append(result, string(word))
+				word = nil
+			}
+			continue
+		}
+		if unicode.IsUpper(r) && len(word) > 0 {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				result = append(result, string(word))
+				word = nil
+			}
+		}
+		word = append(word, r)
+	}
+	if len(word) > 0 {
+		result = append(result, string(word))
+	}
+	return result
+}
+
+// upperCamelCase converts the given name to upper camel case, e.g. "init_counter" to "InitCounter"
+func upperCamelCase(name string) string {
+	var b strings.Builder
+	for _, word := range words(name) {
+		runes := []rune(word)
+		b.WriteString(strings.ToUpper(string(runes[0])) + string(runes[1:]))
+	}
+	return b.String()
+}
+
+// lowerCamelCase converts the given name to lower camel case, e.g. "init_counter" to "initCounter"
+func lowerCamelCase(name string) string {
+	return lowerFirst(upperCamelCase(name))
+}
+
+// lowerFirst converts the first letter of the given name to lower case
+func lowerFirst(name string) string {
+	if name == "" {
+		return name
+	}
+	runes := []rune(name)
+	return strings.ToLower(string(runes[0])) + string(runes[1:])
+}
+
+// snakeCase converts the given name to snake case, e.g. "initCounter" to "init_counter",
+// as is done by Anchor to derive instruction discriminators.
+func snakeCase(name string) string {
+	ws := words(name)
+	for i := range ws {
+		ws[i] = strings.ToLower(ws[i])
+	}
+	return strings.Join(ws, "_")
+}
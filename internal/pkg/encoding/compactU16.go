@@ -0,0 +1,40 @@
+package encoding
+
+import "fmt"
+
+// EncodeCompactU16 encodes the given value in the Solana compact-u16 format.
+// This is a variable length encoding of 1 to 3 bytes in which the lower 7 bits of
+// each byte hold data and the upper bit indicates that another byte follows.
+// It is used to encode the length prefix of arrays in a serialised Transaction.
+// Source: https://docs.solana.com/developing/programming-model/transactions#compact-u16-format
+func EncodeCompactU16(value uint16) []byte {
+	encoded := make([]byte, 0, 3)
+	remaining := value
+	for {
+		b := byte(remaining & 0x7f)
+		remaining >>= 7
+		if remaining == 0 {
+			return append(encoded, b)
+		}
+		encoded = append(encoded, b|0x80)
+	}
+}
+
+// DecodeCompactU16 decodes a compact-u16 value from the start of the given data.
+// It returns the decoded value along with the number of bytes that it consumed.
+func DecodeCompactU16(data []byte) (uint16, int, error) {
+	var value uint32
+	for i := 0; i < 3; i++ {
+		if i >= len(data) {
+			return 0, 0, fmt.Errorf("data ended before compact-u16 was complete: %w", ErrInvalidCompactU16)
+		}
+		value |= uint32(data[i]&0x7f) << (7 * i)
+		if data[i]&0x80 == 0 {
+			if value > 0xffff {
+				return 0, 0, fmt.Errorf("value %d overflows u16: %w", value, ErrInvalidCompactU16)
+			}
+			return uint16(value), i + 1, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("compact-u16 longer than 3 bytes: %w", ErrInvalidCompactU16)
+}
@@ -0,0 +1,40 @@
+package encoding
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCompactU16(t *testing.T) {
+	tests := []struct {
+		name  string
+		value uint16
+		want  []byte
+	}{
+		{name: "zero", value: 0, want: []byte{0x00}},
+		{name: "127", value: 0x7f, want: []byte{0x7f}},
+		{name: "128", value: 0x80, want: []byte{0x80, 0x01}},
+		{name: "255", value: 0xff, want: []byte{0xff, 0x01}},
+		{name: "16383", value: 0x3fff, want: []byte{0xff, 0x7f}},
+		{name: "16384", value: 0x4000, want: []byte{0x80, 0x80, 0x01}},
+		{name: "max", value: 0xffff, want: []byte{0xff, 0xff, 0x03}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EncodeCompactU16(tt.value)
+			require.Equal(t, tt.want, got)
+
+			decoded, n, err := DecodeCompactU16(append(got, 0xaa))
+			require.Nil(t, err)
+			require.Equal(t, tt.value, decoded)
+			require.Equal(t, len(tt.want), n)
+		})
+	}
+
+	_, _, err := DecodeCompactU16([]byte{0x80})
+	require.ErrorIs(t, err, ErrInvalidCompactU16)
+	_, _, err = DecodeCompactU16([]byte{0xff, 0xff, 0x04})
+	require.ErrorIs(t, err, ErrInvalidCompactU16)
+	_, _, err = DecodeCompactU16([]byte{0x80, 0x80, 0x80, 0x01})
+	require.ErrorIs(t, err, ErrInvalidCompactU16)
+}
@@ -4,4 +4,5 @@ import "errors"
 
 var (
 	ErrUnexpectedItemType = errors.New("unexpected item type")
+	ErrInvalidCompactU16  = errors.New("invalid compact-u16")
 )
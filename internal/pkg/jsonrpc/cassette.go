@@ -0,0 +1,309 @@
+package jsonrpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// CassetteRequest is a recorded request
+type CassetteRequest struct {
+	Method string `json:"method"`
+
+	// Params are the params of the request as decoded from json,
+	// i.e. numbers are float64s and objects are map[string]interface{}s
+	Params interface{} `json:"params,omitempty"`
+}
+
+// CassetteEntry is a recorded call and its responses, or the error with which it failed
+type CassetteEntry struct {
+	Requests  []CassetteRequest `json:"requests"`
+	Batch     bool              `json:"batch,omitempty"`
+	Responses []*RPCResponse    `json:"responses,omitempty"`
+	Error     string            `json:"error,omitempty"`
+
+	// ErrorClass and StatusCode identify the type of the error so that it can be rebuilt on replay
+	ErrorClass CassetteErrorClass `json:"errorClass,omitempty"`
+	StatusCode int                `json:"statusCode,omitempty"`
+}
+
+// CassetteErrorClass is the type of the error of a recorded call
+type CassetteErrorClass string
+
+const (
+	// HTTPCassetteErrorClass is an HTTPError, with the status code of the entry
+	HTTPCassetteErrorClass CassetteErrorClass = "http"
+
+	// ConnectionRefusedCassetteErrorClass is an ErrConnectionRefused error
+	ConnectionRefusedCassetteErrorClass CassetteErrorClass = "connectionRefused"
+
+	// DeadlineExceededCassetteErrorClass is a context.DeadlineExceeded error
+	DeadlineExceededCassetteErrorClass CassetteErrorClass = "deadlineExceeded"
+
+	// CanceledCassetteErrorClass is a context.Canceled error
+	CanceledCassetteErrorClass CassetteErrorClass = "canceled"
+)
+
+// Cassette is a sequence of recorded calls that are served by a ReplayClient. Cassettes are
+// recorded by a RecordingClient as JSON lines, with a CassetteEntry on each line.
+type Cassette struct {
+	mu      sync.Mutex
+	entries []CassetteEntry
+	used    []bool
+}
+
+// ReadCassette reads a Cassette of JSON lines from the given reader
+func ReadCassette(r io.Reader) (*Cassette, error) {
+	cassette := new(Cassette)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var entry CassetteEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("error parsing cassette line %d: %w", line, err)
+		}
+		cassette.entries = append(cassette.entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading cassette: %w", err)
+	}
+	cassette.used = make([]bool, len(cassette.entries))
+
+	return cassette, nil
+}
+
+// Remaining returns the number of entries of the Cassette that have not been replayed
+func (c *Cassette) Remaining() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	remaining := 0
+	for _, used := range c.used {
+		if !used {
+			remaining++
+		}
+	}
+	return remaining
+}
+
+// take returns the first entry that has not been replayed and that matches the given call,
+// marking it as replayed. Returns false if there is no such entry.
+func (c *Cassette) take(call *CassetteEntry, matcher Matcher) (CassetteEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+entries:
+	for i, entry := range c.entries {
+		if c.used[i] || entry.Batch != call.Batch || len(entry.Requests) != len(call.Requests) {
+			continue
+		}
+		for j, request := range call.Requests {
+			if !matcher(request, entry.Requests[j]) {
+				continue entries
+			}
+		}
+		c.used[i] = true
+		return entry, true
+	}
+	return CassetteEntry{}, false
+}
+
+// Matcher reports whether a request matches a recorded request.
+// The params of both requests are as decoded from json.
+type Matcher func(request, recorded CassetteRequest) bool
+
+// MatchMethod matches requests of the same method, regardless of params
+func MatchMethod(request, recorded CassetteRequest) bool {
+	return request.Method == recorded.Method
+}
+
+// MatchMethodAndParams returns a Matcher that matches requests of the same method and params. The
+// given keys are removed from all objects in the params before comparison, e.g. to ignore
+// "minContextSlot" or a recent block hash that varies between a recording and a replay.
+func MatchMethodAndParams(ignoredKeys ...string) Matcher {
+	ignored := make(map[string]bool, len(ignoredKeys))
+	for _, key := range ignoredKeys {
+		ignored[key] = true
+	}
+	return func(request, recorded CassetteRequest) bool {
+		return request.Method == recorded.Method &&
+			reflect.DeepEqual(withoutKeys(request.Params, ignored), withoutKeys(recorded.Params, ignored))
+	}
+}
+
+// withoutKeys returns the given decoded json value with the given keys removed from all objects
+func withoutKeys(value interface{}, keys map[string]bool) interface{} {
+	if len(keys) == 0 {
+		return value
+	}
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		object := make(map[string]interface{}, len(typedValue))
+		for key, field := range typedValue {
+			if !keys[key] {
+				object[key] = withoutKeys(field, keys)
+			}
+		}
+		return object
+	case []interface{}:
+		array := make([]interface{}, len(typedValue))
+		for i, element := range typedValue {
+			array[i] = withoutKeys(element, keys)
+		}
+		return array
+	default:
+		return value
+	}
+}
+
+// ErrUnmatchedCall is returned by a ReplayClient for a call that does not match any remaining recorded call
+var ErrUnmatchedCall = errors.New("unmatched call")
+
+// NewRecordingClient returns a Client that performs calls with the given Client, and records each
+// call and its responses to the given writer as a line of JSON that can be read with ReadCassette.
+// Calls of which the context is done are not recorded.
+func NewRecordingClient(client Client, cassette io.Writer) *InterceptedClient {
+	var mu sync.Mutex
+	encoder := json.NewEncoder(cassette)
+	return NewInterceptedClient(
+		client,
+		func(ctx context.Context, call *Call, invoke Invoker) ([]*RPCResponse, error) {
+			// perform call
+			rpcResponses, err := invoke(ctx, call)
+			if ctx.Err() != nil {
+				return rpcResponses, err
+			}
+
+			// record call
+			entry, entryErr := newCassetteEntry(call)
+			if entryErr != nil {
+				return nil, fmt.Errorf("error recording call: %w", entryErr)
+			}
+			if err != nil {
+				entry.Error = err.Error()
+				entry.ErrorClass, entry.StatusCode = classifyCassetteError(err)
+			} else {
+				entry.Responses = rpcResponses
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if encodeErr := encoder.Encode(entry); encodeErr != nil {
+				return nil, fmt.Errorf("error recording call: %w", encodeErr)
+			}
+
+			return rpcResponses, err
+		},
+	)
+}
+
+// NewReplayClient returns a Client that serves calls from the given Cassette instead of performing
+// them. Each call is served by the first recorded call that matches it, according to the given
+// Matcher, that has not already been served. The error of a recorded call that failed is returned
+// with its message, and is an HTTPError, ErrConnectionRefused or context error if the recorded
+// error was. Calls that match no remaining recorded call fail with ErrUnmatchedCall.
+func NewReplayClient(cassette *Cassette, matcher Matcher) *InterceptedClient {
+	return NewInterceptedClient(
+		// the underlying client is never invoked
+		nil,
+		func(ctx context.Context, call *Call, _ Invoker) ([]*RPCResponse, error) {
+			// find recorded call
+			request, err := newCassetteEntry(call)
+			if err != nil {
+				return nil, err
+			}
+			entry, found := cassette.take(request, matcher)
+			if !found {
+				methods := make([]string, len(call.Requests))
+				for i, r := range call.Requests {
+					methods[i] = r.Method
+				}
+				return nil, fmt.Errorf("call of %s: %w", strings.Join(methods, ", "), ErrUnmatchedCall)
+			}
+
+			// replay recorded call
+			if entry.Error != "" {
+				return nil, replayedCassetteError(entry)
+			}
+			return entry.Responses, nil
+		},
+	)
+}
+
+// classifyCassetteError returns the CassetteErrorClass of the given error, and its HTTP status
+// code if it is an HTTPError. The class is empty for errors that are not of a recorded type.
+func classifyCassetteError(err error) (CassetteErrorClass, int) {
+	var httpError *HTTPError
+	switch {
+	case errors.As(err, &httpError):
+		return HTTPCassetteErrorClass, httpError.Code
+	case errors.Is(err, ErrConnectionRefused):
+		return ConnectionRefusedCassetteErrorClass, 0
+	case errors.Is(err, context.DeadlineExceeded):
+		return DeadlineExceededCassetteErrorClass, 0
+	case errors.Is(err, context.Canceled):
+		return CanceledCassetteErrorClass, 0
+	default:
+		return "", 0
+	}
+}
+
+// replayedCassetteError rebuilds the error of the given recorded call with its recorded message
+func replayedCassetteError(entry CassetteEntry) error {
+	message := errors.New(entry.Error)
+	switch entry.ErrorClass {
+	case HTTPCassetteErrorClass:
+		return &HTTPError{Code: entry.StatusCode, err: message}
+	case ConnectionRefusedCassetteErrorClass:
+		return &cassetteError{message: entry.Error, err: ErrConnectionRefused}
+	case DeadlineExceededCassetteErrorClass:
+		return &cassetteError{message: entry.Error, err: context.DeadlineExceeded}
+	case CanceledCassetteErrorClass:
+		return &cassetteError{message: entry.Error, err: context.Canceled}
+	default:
+		return message
+	}
+}
+
+// cassetteError is a replayed error with the recorded message, which wraps the error of its class
+type cassetteError struct {
+	message string
+	err     error
+}
+
+func (e *cassetteError) Error() string {
+	return e.message
+}
+
+func (e *cassetteError) Unwrap() error {
+	return e.err
+}
+
+// newCassetteEntry returns a CassetteEntry of the given call, with the params of
+// each request normalised to their decoded json form so that they can be compared
+func newCassetteEntry(call *Call) (*CassetteEntry, error) {
+	entry := &CassetteEntry{
+		Requests: make([]CassetteRequest, len(call.Requests)),
+		Batch:    call.Batch,
+	}
+	for i, request := range call.Requests {
+		entry.Requests[i].Method = request.Method
+		if request.Params == nil {
+			continue
+		}
+		paramsData, err := json.Marshal(request.Params)
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling params of %s: %w", request.Method, err)
+		}
+		if err := json.Unmarshal(paramsData, &entry.Requests[i].Params); err != nil {
+			return nil, fmt.Errorf("error unmarshalling params of %s: %w", request.Method, err)
+		}
+	}
+	return entry, nil
+}
@@ -0,0 +1,233 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRecordingClient_ReplayClient(t *testing.T) {
+	// record calls
+	var cassetteData bytes.Buffer
+	recordingClient := NewRecordingClient(
+		&MockClient{
+			T: t,
+			CallParamArrayFunc: func(t *testing.T, m *MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*RPCResponse, error) {
+				switch method {
+				case "getBalance":
+					return &RPCResponse{JSONRPC: "2.0", Result: json.RawMessage(`{"context":{"slot":1},"value":10}`), ID: 1}, nil
+				case "getSlot":
+					return &RPCResponse{JSONRPC: "2.0", Result: json.RawMessage(`42`), ID: 1}, nil
+				default:
+					return nil, errors.New("some err")
+				}
+			},
+		},
+		&cassetteData,
+	)
+	_, err := recordingClient.CallParamArray(context.Background(), "getBalance", nil, "address", map[string]interface{}{"commitment": "confirmed"})
+	require.NoError(t, err)
+	_, err = recordingClient.CallParamArray(context.Background(), "getSlot", nil)
+	require.NoError(t, err)
+	_, err = recordingClient.CallParamArray(context.Background(), "getSlot", nil)
+	require.NoError(t, err)
+	_, err = recordingClient.CallParamArray(context.Background(), "getHealth", nil)
+	require.Error(t, err)
+	_, err = recordingClient.CallBatch(context.Background(), nil, []RPCRequest{{Method: "getSlot"}, {Method: "getBalance", Params: []interface{}{"address"}}})
+	require.NoError(t, err)
+	require.Equal(t, 5, strings.Count(cassetteData.String(), "\n"))
+
+	// replay calls
+	cassette, err := ReadCassette(&cassetteData)
+	require.NoError(t, err)
+	require.Equal(t, 5, cassette.Remaining())
+	replayClient := NewReplayClient(cassette, MatchMethodAndParams())
+
+	// calls are matched by method and params
+	_, err = replayClient.CallParamArray(context.Background(), "getBalance", nil, "address", map[string]interface{}{"commitment": "finalized"})
+	require.ErrorIs(t, err, ErrUnmatchedCall)
+	var result struct {
+		Value uint64 `json:"value"`
+	}
+	_, err = replayClient.CallParamArrayWithResultDecoder(
+		context.Background(),
+		"getBalance",
+		nil,
+		func(decoder *json.Decoder) error {
+			return decoder.Decode(&result)
+		},
+		"address",
+		map[string]interface{}{"commitment": "confirmed"},
+	)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), result.Value)
+
+	// repeated calls are served by successive recordings
+	for i := 0; i < 2; i++ {
+		rpcResponse, err := replayClient.CallParamArray(context.Background(), "getSlot", nil)
+		require.NoError(t, err)
+		require.Equal(t, json.RawMessage(`42`), rpcResponse.Result)
+	}
+
+	// recorded errors are replayed
+	_, err = replayClient.CallParamArray(context.Background(), "getHealth", nil)
+	require.EqualError(t, err, "some err")
+
+	// batch calls are replayed
+	rpcResponses, err := replayClient.CallBatch(context.Background(), nil, []RPCRequest{{Method: "getSlot"}, {Method: "getBalance", Params: []interface{}{"address"}}})
+	require.NoError(t, err)
+	require.Len(t, rpcResponses, 2)
+	require.Equal(t, json.RawMessage(`42`), rpcResponses[0].Result)
+
+	// exhausted recordings are not replayed
+	require.Equal(t, 0, cassette.Remaining())
+	_, err = replayClient.CallParamArray(context.Background(), "getSlot", nil)
+	require.ErrorIs(t, err, ErrUnmatchedCall)
+}
+
+func TestReplayClient_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantIs    error
+		wantCode  int
+		wantNotIs error
+	}{
+		{
+			name:     "http error",
+			err:      fmt.Errorf("%w: %s", &HTTPError{Code: http.StatusUnauthorized, err: errors.New("status code 401")}, ErrUnauthorized.Error()),
+			wantIs:   ErrUnauthorized,
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:      "http error of other status code",
+			err:       &HTTPError{Code: http.StatusServiceUnavailable, err: errors.New("status code 503")},
+			wantIs:    ErrHTTPError,
+			wantCode:  http.StatusServiceUnavailable,
+			wantNotIs: ErrBadRequest,
+		},
+		{
+			name:      "connection refused",
+			err:       fmt.Errorf("error performing http request: %w", ErrConnectionRefused),
+			wantIs:    ErrConnectionRefused,
+			wantNotIs: ErrHTTPError,
+		},
+		{
+			name:   "deadline exceeded",
+			err:    fmt.Errorf("error performing http request: %w", context.DeadlineExceeded),
+			wantIs: context.DeadlineExceeded,
+		},
+		{
+			name:      "other error",
+			err:       errors.New("some err"),
+			wantNotIs: ErrConnectionRefused,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// record failed call
+			var cassetteData bytes.Buffer
+			recordingClient := NewRecordingClient(
+				&MockClient{
+					T: t,
+					CallParamArrayFunc: func(t *testing.T, m *MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*RPCResponse, error) {
+						return nil, tt.err
+					},
+				},
+				&cassetteData,
+			)
+			_, err := recordingClient.CallParamArray(context.Background(), "getSlot", nil)
+			require.Equal(t, tt.err, err)
+
+			// replay failed call
+			cassette, err := ReadCassette(&cassetteData)
+			require.NoError(t, err)
+			_, err = NewReplayClient(cassette, MatchMethod).CallParamArray(context.Background(), "getSlot", nil)
+			require.EqualError(t, err, tt.err.Error())
+			if tt.wantIs != nil {
+				require.ErrorIs(t, err, tt.wantIs)
+			}
+			if tt.wantNotIs != nil {
+				require.False(t, errors.Is(err, tt.wantNotIs))
+			}
+			var httpError *HTTPError
+			if tt.wantCode != 0 {
+				require.ErrorAs(t, err, &httpError)
+				require.Equal(t, tt.wantCode, httpError.Code)
+			} else {
+				require.False(t, errors.As(err, &httpError))
+			}
+		})
+	}
+}
+
+func TestMatcher(t *testing.T) {
+	request := CassetteRequest{
+		Method: "getLatestBlockhash",
+		Params: []interface{}{map[string]interface{}{"commitment": "confirmed", "minContextSlot": float64(10)}},
+	}
+
+	tests := []struct {
+		name     string
+		matcher  Matcher
+		recorded CassetteRequest
+		want     bool
+	}{
+		{
+			name:     "method and params match",
+			matcher:  MatchMethodAndParams(),
+			recorded: request,
+			want:     true,
+		},
+		{
+			name:    "params do not match",
+			matcher: MatchMethodAndParams(),
+			recorded: CassetteRequest{
+				Method: "getLatestBlockhash",
+				Params: []interface{}{map[string]interface{}{"commitment": "confirmed", "minContextSlot": float64(20)}},
+			},
+			want: false,
+		},
+		{
+			name:    "ignored params do not need to match",
+			matcher: MatchMethodAndParams("minContextSlot"),
+			recorded: CassetteRequest{
+				Method: "getLatestBlockhash",
+				Params: []interface{}{map[string]interface{}{"commitment": "confirmed"}},
+			},
+			want: true,
+		},
+		{
+			name:     "method matches",
+			matcher:  MatchMethod,
+			recorded: CassetteRequest{Method: "getLatestBlockhash"},
+			want:     true,
+		},
+		{
+			name:     "method does not match",
+			matcher:  MatchMethod,
+			recorded: CassetteRequest{Method: "getSlot"},
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.matcher(request, tt.recorded))
+		})
+	}
+}
+
+func TestReadCassette(t *testing.T) {
+	cassette, err := ReadCassette(strings.NewReader("{\"requests\":[{\"method\":\"getSlot\"}],\"responses\":[{\"result\":1}]}\n\n"))
+	require.NoError(t, err)
+	require.Equal(t, 1, cassette.Remaining())
+
+	_, err = ReadCassette(strings.NewReader("{\"requests\":[{\"method\":\"getSlot\"}]}\nnot json\n"))
+	require.Error(t, err)
+}
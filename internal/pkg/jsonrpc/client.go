@@ -2,9 +2,25 @@ package jsonrpc
 
 import (
 	"context"
+	"encoding/json"
 )
 
 type Client interface {
 	CallParamArray(ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*RPCResponse, error)
 	CallParamStruct(ctx context.Context, method string, additionalHeaders map[string]string, params interface{}) (*RPCResponse, error)
+
+	// CallParamArrayWithResultDecoder performs an rpc call as CallParamArray does, but decodes the
+	// result of the call from the response body as it is read using the given ResultDecoder.
+	// The Result of the returned RPCResponse is not set.
+	CallParamArrayWithResultDecoder(ctx context.Context, method string, additionalHeaders map[string]string, resultDecoder ResultDecoder, params ...interface{}) (*RPCResponse, error)
+
+	// CallBatch performs the given requests in a single batch call, returning their responses in the
+	// order of the requests. The IDs of the requests are assigned by the Client. An RPCError of an
+	// individual request is set on its RPCResponse, while an error is returned if the batch fails.
+	CallBatch(ctx context.Context, additionalHeaders map[string]string, requests []RPCRequest) ([]*RPCResponse, error)
 }
+
+// ResultDecoder decodes the result of an rpc call with the given json.Decoder,
+// which is positioned at the start of the result value.
+// The entire result value must be consumed from the json.Decoder.
+type ResultDecoder func(decoder *json.Decoder) error
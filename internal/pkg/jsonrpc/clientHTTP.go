@@ -5,15 +5,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"strings"
+	"time"
 )
 
 type HTTPClient struct {
 	urlEndpoint   string
 	httpClient    *http.Client
 	customHeaders map[string]string
+	retryPolicy   *RetryPolicy
+	rateLimiter   *RateLimiter
 }
 
 func NewHTTPClient(
@@ -27,9 +29,13 @@ func NewHTTPClient(
 // RPCClientOpts can be provided to NewClientWithOpts() to change configuration of HTTPClient.
 // HTTPClient: provide a custom http.Client (e.g. to set a proxy, or tls options)
 // CustomHeaders: provide custom headers, e.g. to set BasicAuth
+// RetryPolicy: provide a RetryPolicy to retry calls that fail with transient errors
+// RateLimiter: provide a RateLimiter, which may be shared between clients, to limit the rate of calls
 type RPCClientOpts struct {
 	HTTPClient    *http.Client
 	CustomHeaders map[string]string
+	RetryPolicy   *RetryPolicy
+	RateLimiter   *RateLimiter
 }
 
 func NewHTTPClientFromOpts(
@@ -48,35 +54,36 @@ func NewHTTPClientFromOpts(
 		httpClient.httpClient = opts.HTTPClient
 	}
 
+	if opts.RetryPolicy != nil {
+		httpClient.retryPolicy = opts.RetryPolicy
+	}
+
+	if opts.RateLimiter != nil {
+		httpClient.rateLimiter = opts.RateLimiter
+	}
+
 	return httpClient
 }
 
 func (c *HTTPClient) CallParamArray(ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*RPCResponse, error) {
-	resp, err := c.call(ctx, method, additionalHeaders, params)
-	if err != nil {
-		switch typedError := err.(type) {
-		case *HTTPError:
-			switch typedError.Code {
-			case http.StatusBadRequest:
-				return nil, fmt.Errorf("%s: %w", typedError.Error(), ErrBadRequest)
-
-			case http.StatusUnauthorized:
-				return nil, fmt.Errorf("%s: %w", typedError.Error(), ErrUnauthorized)
+	resp, err := c.call(ctx, method, additionalHeaders, params, nil)
+	return handleCallResult(resp, err)
+}
 
-			default:
-				return nil, fmt.Errorf("%d - %s : %w", typedError.Code, typedError.Error(), ErrHTTPError)
-			}
+func (c *HTTPClient) CallParamStruct(ctx context.Context, method string, additionalHeaders map[string]string, params interface{}) (*RPCResponse, error) {
+	resp, err := c.call(ctx, method, additionalHeaders, params, nil)
+	return handleCallResult(resp, err)
+}
 
-		default:
-			// check for connection refused error
-			errStr := err.Error()
-			if strings.Contains(errStr, "dial") &&
-				strings.Contains(errStr, "connection refused") {
-				return nil, ErrConnectionRefused
-			}
+func (c *HTTPClient) CallParamArrayWithResultDecoder(ctx context.Context, method string, additionalHeaders map[string]string, resultDecoder ResultDecoder, params ...interface{}) (*RPCResponse, error) {
+	resp, err := c.call(ctx, method, additionalHeaders, params, resultDecoder)
+	return handleCallResult(resp, err)
+}
 
-			return nil, err
-		}
+// handleCallResult maps the error returned from a call to the errors exposed by this package
+func handleCallResult(resp *RPCResponse, err error) (*RPCResponse, error) {
+	if err != nil {
+		return nil, handleCallError(err)
 	}
 
 	if resp == nil {
@@ -86,44 +93,42 @@ func (c *HTTPClient) CallParamArray(ctx context.Context, method string, addition
 	return resp, nil
 }
 
-func (c *HTTPClient) CallParamStruct(ctx context.Context, method string, additionalHeaders map[string]string, params interface{}) (*RPCResponse, error) {
-	resp, err := c.call(ctx, method, additionalHeaders, params)
-	if err != nil {
-		switch typedError := err.(type) {
-		case *HTTPError:
-			switch typedError.Code {
-			case http.StatusBadRequest:
-				return nil, fmt.Errorf("%s: %w", typedError.Error(), ErrBadRequest)
+// handleCallError maps an error returned from a call to the errors exposed by this package
+func handleCallError(err error) error {
+	switch typedError := err.(type) {
+	case *HTTPError:
+		// the HTTPError is wrapped so that its status code can be inspected,
+		// HTTPError.Is matches the corresponding errors of this package
+		switch typedError.Code {
+		case http.StatusBadRequest:
+			return fmt.Errorf("%w: %s", typedError, ErrBadRequest.Error())
 
-			case http.StatusUnauthorized:
-				return nil, fmt.Errorf("%s: %w", typedError.Error(), ErrUnauthorized)
-
-			default:
-				return nil, fmt.Errorf("%d - %s : %w", typedError.Code, typedError.Error(), ErrHTTPError)
-			}
+		case http.StatusUnauthorized:
+			return fmt.Errorf("%w: %s", typedError, ErrUnauthorized.Error())
 
 		default:
-			// check for connection refused error
-			errStr := err.Error()
-			if strings.Contains(errStr, "dial") &&
-				strings.Contains(errStr, "connection refused") {
-				return nil, ErrConnectionRefused
-			}
+			return fmt.Errorf("%d - %w : %s", typedError.Code, typedError, ErrHTTPError.Error())
+		}
 
-			return nil, err
+	default:
+		// check for connection refused error
+		errStr := err.Error()
+		if strings.Contains(errStr, "dial") &&
+			strings.Contains(errStr, "connection refused") {
+			return ErrConnectionRefused
 		}
-	}
 
-	if resp == nil {
-		return nil, ErrNilResponse
+		return err
 	}
-
-	return resp, nil
 }
 
-func (c *HTTPClient) call(ctx context.Context, method string, additionalHeaders map[string]string, params interface{}) (*RPCResponse, error) {
-	// construct and marshal rpc request
-	rpcRequestData, err := json.Marshal(
+func (c *HTTPClient) call(ctx context.Context, method string, additionalHeaders map[string]string, params interface{}, resultDecoder ResultDecoder) (*RPCResponse, error) {
+	// perform http request
+	httpResponse, err := c.post(
+		ctx,
+		fmt.Sprintf("rpc call %v()", method),
+		[]string{method},
+		additionalHeaders,
 		RPCRequest{
 			Method:  method,
 			Params:  params,
@@ -131,10 +136,128 @@ func (c *HTTPClient) call(ctx context.Context, method string, additionalHeaders
 			JSONRPC: "2.0",
 		},
 	)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+
+	// decode http response body to rpc response
+	rpcResponse, err := DecodeRPCResponse(json.NewDecoder(httpResponse.Body), resultDecoder)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding http response body to rpc response: %w", err)
+	}
+
+	return rpcResponse, nil
+}
+
+// CallBatch performs the given requests in a single http request. Each request is sent with a
+// unique ID, overwriting any ID set, and the responses are matched to the requests by ID so that
+// they are returned in the order of the given requests. An RPCError of an individual request is
+// set on its RPCResponse, while an error is returned if the batch as a whole fails.
+func (c *HTTPClient) CallBatch(ctx context.Context, additionalHeaders map[string]string, requests []RPCRequest) ([]*RPCResponse, error) {
+	if len(requests) == 0 {
+		return []*RPCResponse{}, nil
+	}
+
+	// assign unique ids
+	batch := make([]RPCRequest, len(requests))
+	for i, request := range requests {
+		batch[i] = request
+		batch[i].ID = i + 1
+		batch[i].JSONRPC = "2.0"
+	}
+
+	// perform http request
+	methods := make([]string, len(requests))
+	for i, request := range requests {
+		methods[i] = request.Method
+	}
+	httpResponse, err := c.post(
+		ctx,
+		fmt.Sprintf("rpc batch call of %d requests", len(requests)),
+		methods,
+		additionalHeaders,
+		batch,
+	)
+	if err != nil {
+		return nil, handleCallError(err)
+	}
+	defer httpResponse.Body.Close()
+
+	// decode http response body to rpc responses
+	rpcResponses, err := DecodeRPCBatchResponse(json.NewDecoder(httpResponse.Body))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding http response body to rpc batch response: %w", err)
+	}
+
+	// order responses by id
+	orderedRPCResponses := make([]*RPCResponse, len(requests))
+	for _, rpcResponse := range rpcResponses {
+		if rpcResponse.ID < 1 || rpcResponse.ID > len(requests) {
+			continue
+		}
+		orderedRPCResponses[rpcResponse.ID-1] = rpcResponse
+	}
+	for i, rpcResponse := range orderedRPCResponses {
+		if rpcResponse == nil {
+			return nil, fmt.Errorf("no response to %s() request %d of batch: %w", requests[i].Method, i, ErrNilResponse)
+		}
+	}
+
+	return orderedRPCResponses, nil
+}
+
+// post marshals and posts the given payload, a call of the given methods, to the endpoint.
+// An HTTPError is returned if the status code of the http response is an error code.
+// Failed posts are retried according to the RetryPolicy of the client, if set.
+// Each attempt waits for the RateLimiter of the client, if set.
+// The body of the returned http response must be closed.
+func (c *HTTPClient) post(ctx context.Context, description string, methods []string, additionalHeaders map[string]string, payload interface{}) (*http.Response, error) {
+	// marshal payload
+	rpcRequestData, err := json.Marshal(payload)
 	if err != nil {
 		return nil, fmt.Errorf("error json marshalling rpc request: %w", err)
 	}
 
+	for attempt := 1; ; attempt++ {
+		// wait for rate limit
+		if c.rateLimiter != nil {
+			if err := c.rateLimiter.Wait(ctx, methods); err != nil {
+				return nil, fmt.Errorf("error waiting for rate limit: %w", err)
+			}
+		}
+
+		httpResponse, err := c.postData(ctx, description, additionalHeaders, rpcRequestData)
+		if err == nil {
+			return httpResponse, nil
+		}
+
+		// determine if and when to retry
+		if c.retryPolicy == nil {
+			return nil, err
+		}
+		delay, retry := c.retryPolicy.retryDelay(attempt, methods, err)
+		if !retry {
+			return nil, err
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			// no time to retry
+			return nil, err
+		}
+
+		// wait to retry
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("%w while waiting to retry after: %v", ctx.Err(), err)
+		}
+	}
+}
+
+// postData posts the given marshalled payload to the endpoint once
+func (c *HTTPClient) postData(ctx context.Context, description string, additionalHeaders map[string]string, rpcRequestData []byte) (*http.Response, error) {
 	// construct http request
 	httpRequest, err := http.NewRequestWithContext(
 		ctx,
@@ -159,40 +282,25 @@ func (c *HTTPClient) call(ctx context.Context, method string, additionalHeaders
 	}
 
 	// preform http request
-	var httpResponse *http.Response
-	if c.httpClient == nil {
+	httpClient := c.httpClient
+	if httpClient == nil {
 		// if http client was not set, use default client
-		httpResponse, err = http.DefaultClient.Do(httpRequest)
-		if err != nil {
-			return nil, fmt.Errorf("rpc call %v() on %v: %v", method, httpRequest.URL.String(), err.Error())
-		}
-	} else {
-		// otherwise http client was set, use it to perform http request
-		httpResponse, err = c.httpClient.Do(httpRequest)
-		if err != nil {
-			return nil, fmt.Errorf("rpc call %v() on %v: %v", method, httpRequest.URL.String(), err.Error())
-		}
+		httpClient = http.DefaultClient
+	}
+	httpResponse, err := httpClient.Do(httpRequest)
+	if err != nil {
+		return nil, fmt.Errorf("%s on %v: %w", description, httpRequest.URL.String(), err)
 	}
 
 	// check for an http error
 	if httpResponse.StatusCode >= 400 {
+		httpResponse.Body.Close()
 		return nil, &HTTPError{
-			Code: httpResponse.StatusCode,
-			err:  fmt.Errorf("rpc call %v() on %v status code", httpRequest.URL.String(), httpResponse.StatusCode),
+			Code:       httpResponse.StatusCode,
+			err:        fmt.Errorf("%s on %v status code %d", description, httpRequest.URL.String(), httpResponse.StatusCode),
+			retryAfter: parseRetryAfter(httpResponse.Header.Get("Retry-After")),
 		}
 	}
 
-	// read body of http response
-	httpResponseBodyBytes, err := ioutil.ReadAll(httpResponse.Body)
-	if err != nil {
-		return nil, fmt.Errorf("error reading http resposne body: %w", err)
-	}
-
-	// unmarshall http response body to rpc response
-	var rpcResponse RPCResponse
-	if err := json.Unmarshal(httpResponseBodyBytes, &rpcResponse); err != nil {
-		return nil, fmt.Errorf("error unmarshalling http response body bytes to rpc response")
-	}
-
-	return &rpcResponse, nil
+	return httpResponse, nil
 }
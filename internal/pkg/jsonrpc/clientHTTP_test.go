@@ -0,0 +1,181 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPClient_CallParamArrayWithResultDecoder(t *testing.T) {
+	tests := []struct {
+		name         string
+		responseBody string
+		statusCode   int
+		decoderErr   error
+		want         []int
+		wantResponse *RPCResponse
+		wantErr      error
+	}{
+		{
+			name:         "result decoded",
+			responseBody: `{"jsonrpc": "2.0", "result": [1, 2, 3], "id": 1}`,
+			statusCode:   http.StatusOK,
+			want:         []int{1, 2, 3},
+			wantResponse: &RPCResponse{JSONRPC: "2.0", ID: 1},
+		},
+		{
+			name:         "error response",
+			responseBody: `{"jsonrpc": "2.0", "error": {"code": -32602, "message": "invalid params"}, "id": 1}`,
+			statusCode:   http.StatusOK,
+			wantResponse: &RPCResponse{
+				JSONRPC: "2.0",
+				Error:   &RPCError{Code: -32602, Message: "invalid params"},
+				ID:      1,
+			},
+		},
+		{
+			name:         "result decoder error",
+			responseBody: `{"jsonrpc": "2.0", "result": [1, 2, 3], "id": 1}`,
+			statusCode:   http.StatusOK,
+			decoderErr:   errors.New("some err"),
+			wantErr:      errors.New("some err"),
+		},
+		{
+			name:       "http error",
+			statusCode: http.StatusBadRequest,
+			wantErr:    ErrBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var request RPCRequest
+				require.Nil(t, json.NewDecoder(r.Body).Decode(&request))
+				require.Equal(t, "someMethod", request.Method)
+				w.WriteHeader(tt.statusCode)
+				_, _ = io.WriteString(w, tt.responseBody)
+			}))
+			defer server.Close()
+
+			// decode result element by element
+			var got []int
+			resultDecoder := func(decoder *json.Decoder) error {
+				if tt.decoderErr != nil {
+					return tt.decoderErr
+				}
+				if err := expectDelim(decoder, '['); err != nil {
+					return err
+				}
+				for decoder.More() {
+					var element int
+					if err := decoder.Decode(&element); err != nil {
+						return err
+					}
+					got = append(got, element)
+				}
+				return expectDelim(decoder, ']')
+			}
+
+			response, err := NewHTTPClient(server.URL).CallParamArrayWithResultDecoder(
+				context.Background(),
+				"someMethod",
+				nil,
+				resultDecoder,
+				"param",
+			)
+			if tt.wantErr != nil {
+				require.NotNil(t, err)
+				require.Contains(t, err.Error(), tt.wantErr.Error())
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.wantResponse, response)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHTTPClient_CallBatch(t *testing.T) {
+	tests := []struct {
+		name         string
+		responseBody string
+		statusCode   int
+		want         []*RPCResponse
+		wantErr      error
+	}{
+		{
+			name:         "out of order responses with item error",
+			responseBody: `[{"jsonrpc": "2.0", "result": 2, "id": 2}, {"jsonrpc": "2.0", "error": {"code": -32602, "message": "invalid params"}, "id": 3}, {"jsonrpc": "2.0", "result": 1, "id": 1}]`,
+			statusCode:   http.StatusOK,
+			want: []*RPCResponse{
+				{JSONRPC: "2.0", Result: json.RawMessage("1"), ID: 1},
+				{JSONRPC: "2.0", Result: json.RawMessage("2"), ID: 2},
+				{JSONRPC: "2.0", Error: &RPCError{Code: -32602, Message: "invalid params"}, ID: 3},
+			},
+		},
+		{
+			name:         "missing response",
+			responseBody: `[{"jsonrpc": "2.0", "result": 2, "id": 2}, {"jsonrpc": "2.0", "result": 1, "id": 1}]`,
+			statusCode:   http.StatusOK,
+			wantErr:      ErrNilResponse,
+		},
+		{
+			name:         "batch rejected",
+			responseBody: `{"jsonrpc": "2.0", "error": {"code": -32600, "message": "batch too large"}, "id": null}`,
+			statusCode:   http.StatusOK,
+			wantErr:      errors.New("batch too large"),
+		},
+		{
+			name:       "http error",
+			statusCode: http.StatusTooManyRequests,
+			wantErr:    ErrHTTPError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var requests []RPCRequest
+				require.Nil(t, json.NewDecoder(r.Body).Decode(&requests))
+				require.Equal(
+					t,
+					[]RPCRequest{
+						{Method: "a", Params: []interface{}{"1"}, ID: 1, JSONRPC: "2.0"},
+						{Method: "b", Params: []interface{}{"2"}, ID: 2, JSONRPC: "2.0"},
+						{Method: "c", ID: 3, JSONRPC: "2.0"},
+					},
+					requests,
+				)
+				w.WriteHeader(tt.statusCode)
+				_, _ = io.WriteString(w, tt.responseBody)
+			}))
+			defer server.Close()
+
+			got, err := NewHTTPClient(server.URL).CallBatch(
+				context.Background(),
+				nil,
+				[]RPCRequest{
+					{Method: "a", Params: []interface{}{"1"}},
+					{Method: "b", Params: []interface{}{"2"}},
+					{Method: "c"},
+				},
+			)
+			if tt.wantErr != nil {
+				require.NotNil(t, err)
+				require.Contains(t, err.Error(), tt.wantErr.Error())
+				if tt.wantErr == ErrHTTPError {
+					var httpError *HTTPError
+					require.ErrorAs(t, err, &httpError)
+					require.Equal(t, tt.statusCode, httpError.Code)
+				}
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
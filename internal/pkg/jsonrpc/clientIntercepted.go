@@ -0,0 +1,154 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ensure InterceptedClient implements Client
+var _ Client = &InterceptedClient{}
+
+// Call is a call made by a Client, as passed through its Interceptors
+type Call struct {
+	// Requests are the requests of the call. A call that is not a batch call has a single request.
+	// The IDs and JSONRPC versions of the requests are set by the underlying Client.
+	Requests []RPCRequest
+
+	// Batch is true if the call is a batch call
+	Batch bool
+
+	// Headers are the additional headers sent with the call
+	Headers map[string]string
+}
+
+// Invoker performs a call, returning a response to each of its requests in order
+type Invoker func(ctx context.Context, call *Call) ([]*RPCResponse, error)
+
+// Interceptor intercepts a call made by an InterceptedClient. The interceptor may inspect or
+// modify the call, before performing it with the given Invoker, and may inspect or modify the
+// responses and error returned. The call can be short-circuited by returning without invoking it.
+type Interceptor func(ctx context.Context, call *Call, invoke Invoker) ([]*RPCResponse, error)
+
+// InterceptedClient is a Client that passes each call through a chain of Interceptors
+// before performing it with an underlying Client.
+//
+// Results decoded with a ResultDecoder are read in full before being decoded,
+// so that the Interceptors see the Result of each response.
+type InterceptedClient struct {
+	client Client
+	chain  Invoker
+}
+
+// NewInterceptedClient returns a new InterceptedClient that performs calls with the given Client.
+// Interceptors are called in the order given, such that the first interceptor is outermost.
+func NewInterceptedClient(client Client, interceptors ...Interceptor) *InterceptedClient {
+	c := &InterceptedClient{
+		client: client,
+	}
+
+	// build chain from the innermost interceptor outwards
+	c.chain = c.invoke
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], c.chain
+		c.chain = func(ctx context.Context, call *Call) ([]*RPCResponse, error) {
+			return interceptor(ctx, call, next)
+		}
+	}
+
+	return c
+}
+
+func (c *InterceptedClient) CallParamArray(ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*RPCResponse, error) {
+	return c.callSingle(ctx, method, additionalHeaders, params)
+}
+
+func (c *InterceptedClient) CallParamStruct(ctx context.Context, method string, additionalHeaders map[string]string, params interface{}) (*RPCResponse, error) {
+	return c.callSingle(ctx, method, additionalHeaders, params)
+}
+
+func (c *InterceptedClient) CallParamArrayWithResultDecoder(ctx context.Context, method string, additionalHeaders map[string]string, resultDecoder ResultDecoder, params ...interface{}) (*RPCResponse, error) {
+	// perform call
+	rpcResponse, err := c.callSingle(ctx, method, additionalHeaders, params)
+	if err != nil {
+		return nil, err
+	}
+	if rpcResponse.Result == nil {
+		return rpcResponse, nil
+	}
+
+	// decode result
+	if err := resultDecoder(json.NewDecoder(bytes.NewReader(rpcResponse.Result))); err != nil {
+		return nil, err
+	}
+	decodedRPCResponse := *rpcResponse
+	decodedRPCResponse.Result = nil
+
+	return &decodedRPCResponse, nil
+}
+
+func (c *InterceptedClient) CallBatch(ctx context.Context, additionalHeaders map[string]string, requests []RPCRequest) ([]*RPCResponse, error) {
+	return c.chain(ctx, &Call{
+		Requests: requests,
+		Batch:    true,
+		Headers:  copyHeaders(additionalHeaders),
+	})
+}
+
+// callSingle passes a call of a single request through the chain
+func (c *InterceptedClient) callSingle(ctx context.Context, method string, additionalHeaders map[string]string, params interface{}) (*RPCResponse, error) {
+	rpcResponses, err := c.chain(ctx, &Call{
+		Requests: []RPCRequest{{Method: method, Params: params}},
+		Headers:  copyHeaders(additionalHeaders),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(rpcResponses) != 1 {
+		return nil, fmt.Errorf("%d responses to call: %w", len(rpcResponses), ErrNilResponse)
+	}
+	if rpcResponses[0] == nil {
+		return nil, ErrNilResponse
+	}
+
+	return rpcResponses[0], nil
+}
+
+// invoke performs the given call with the underlying Client
+func (c *InterceptedClient) invoke(ctx context.Context, call *Call) ([]*RPCResponse, error) {
+	if call.Batch {
+		return c.client.CallBatch(ctx, call.Headers, call.Requests)
+	}
+	if len(call.Requests) != 1 {
+		return nil, fmt.Errorf("call of %d requests is not a batch call", len(call.Requests))
+	}
+
+	// perform single call
+	var rpcResponse *RPCResponse
+	var err error
+	request := call.Requests[0]
+	switch params := request.Params.(type) {
+	case nil:
+		rpcResponse, err = c.client.CallParamArray(ctx, request.Method, call.Headers)
+	case []interface{}:
+		rpcResponse, err = c.client.CallParamArray(ctx, request.Method, call.Headers, params...)
+	default:
+		rpcResponse, err = c.client.CallParamStruct(ctx, request.Method, call.Headers, params)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return []*RPCResponse{rpcResponse}, nil
+}
+
+// copyHeaders returns a copy of the given headers, which
+// can be modified by interceptors without affecting the caller
+func copyHeaders(headers map[string]string) map[string]string {
+	headersCopy := make(map[string]string, len(headers))
+	for key, value := range headers {
+		headersCopy[key] = value
+	}
+	return headersCopy
+}
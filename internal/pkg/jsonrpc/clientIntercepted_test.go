@@ -0,0 +1,132 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestInterceptedClient(t *testing.T) {
+	var order []string
+	recordingInterceptor := func(name string) Interceptor {
+		return func(ctx context.Context, call *Call, invoke Invoker) ([]*RPCResponse, error) {
+			order = append(order, name+" before")
+			rpcResponses, err := invoke(ctx, call)
+			order = append(order, name+" after")
+			return rpcResponses, err
+		}
+	}
+	authInterceptor := func(ctx context.Context, call *Call, invoke Invoker) ([]*RPCResponse, error) {
+		call.Headers["Authorization"] = "Bearer token"
+		return invoke(ctx, call)
+	}
+
+	mockClient := &MockClient{
+		T: t,
+		CallParamArrayFunc: func(t *testing.T, m *MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*RPCResponse, error) {
+			require.Equal(t, "getBalance", method)
+			require.Equal(t, []interface{}{"address"}, params)
+			require.Equal(t, map[string]string{"Authorization": "Bearer token", "X-Caller": "test"}, additionalHeaders)
+			return &RPCResponse{Result: json.RawMessage(`{"value":10}`)}, nil
+		},
+	}
+	client := NewInterceptedClient(mockClient, recordingInterceptor("outer"), authInterceptor, recordingInterceptor("inner"))
+
+	// interceptors are called in order and may modify headers
+	callerHeaders := map[string]string{"X-Caller": "test"}
+	rpcResponse, err := client.CallParamArray(context.Background(), "getBalance", callerHeaders, "address")
+	require.NoError(t, err)
+	require.Equal(t, json.RawMessage(`{"value":10}`), rpcResponse.Result)
+	require.Equal(t, []string{"outer before", "inner before", "inner after", "outer after"}, order)
+	require.Equal(t, map[string]string{"X-Caller": "test"}, callerHeaders)
+
+	// results are decoded after passing through the interceptors
+	var result struct {
+		Value uint64 `json:"value"`
+	}
+	rpcResponse, err = client.CallParamArrayWithResultDecoder(
+		context.Background(),
+		"getBalance",
+		callerHeaders,
+		func(decoder *json.Decoder) error {
+			return decoder.Decode(&result)
+		},
+		"address",
+	)
+	require.NoError(t, err)
+	require.Nil(t, rpcResponse.Result)
+	require.Equal(t, uint64(10), result.Value)
+}
+
+func TestInterceptedClient_ShortCircuit(t *testing.T) {
+	errShortCircuit := errors.New("short circuit")
+	mockClient := &MockClient{T: t}
+
+	tests := []struct {
+		name        string
+		interceptor Interceptor
+		call        func(client Client) (interface{}, error)
+		want        interface{}
+		wantErr     error
+	}{
+		{
+			name: "response returned without invoking",
+			interceptor: func(ctx context.Context, call *Call, invoke Invoker) ([]*RPCResponse, error) {
+				require.Equal(t, []RPCRequest{{Method: "getSlot", Params: map[string]interface{}{"commitment": "confirmed"}}}, call.Requests)
+				return []*RPCResponse{{Result: json.RawMessage(`1`)}}, nil
+			},
+			call: func(client Client) (interface{}, error) {
+				return client.CallParamStruct(context.Background(), "getSlot", nil, map[string]interface{}{"commitment": "confirmed"})
+			},
+			want: &RPCResponse{Result: json.RawMessage(`1`)},
+		},
+		{
+			name: "error returned without invoking",
+			interceptor: func(ctx context.Context, call *Call, invoke Invoker) ([]*RPCResponse, error) {
+				return nil, errShortCircuit
+			},
+			call: func(client Client) (interface{}, error) {
+				return client.CallParamArray(context.Background(), "getSlot", nil)
+			},
+			wantErr: errShortCircuit,
+		},
+		{
+			name: "batch responses returned without invoking",
+			interceptor: func(ctx context.Context, call *Call, invoke Invoker) ([]*RPCResponse, error) {
+				require.True(t, call.Batch)
+				require.Len(t, call.Requests, 2)
+				return []*RPCResponse{{Result: json.RawMessage(`1`)}, {Result: json.RawMessage(`2`)}}, nil
+			},
+			call: func(client Client) (interface{}, error) {
+				return client.CallBatch(context.Background(), nil, []RPCRequest{{Method: "getSlot"}, {Method: "getBlockHeight"}})
+			},
+			want: []*RPCResponse{{Result: json.RawMessage(`1`)}, {Result: json.RawMessage(`2`)}},
+		},
+		{
+			name: "missing response",
+			interceptor: func(ctx context.Context, call *Call, invoke Invoker) ([]*RPCResponse, error) {
+				return nil, nil
+			},
+			call: func(client Client) (interface{}, error) {
+				return client.CallParamArray(context.Background(), "getSlot", nil)
+			},
+			wantErr: ErrNilResponse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.call(NewInterceptedClient(mockClient, tt.interceptor))
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+	require.Zero(t, mockClient.CallParamArrayFuncInvocations)
+	require.Zero(t, mockClient.CallParamStructFuncInvocations)
+	require.Zero(t, mockClient.CallBatchFuncInvocations)
+}
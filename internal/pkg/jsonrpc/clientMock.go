@@ -1,16 +1,22 @@
 package jsonrpc
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"testing"
 )
 
 type MockClient struct {
-	T                              *testing.T
-	CallParamArrayFunc             func(t *testing.T, m *MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*RPCResponse, error)
-	CallParamArrayFuncInvocations  int
-	CallParamStructFunc            func(t *testing.T, m *MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params interface{}) (*RPCResponse, error)
-	CallParamStructFuncInvocations int
+	T                                              *testing.T
+	CallParamArrayFunc                             func(t *testing.T, m *MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*RPCResponse, error)
+	CallParamArrayFuncInvocations                  int
+	CallParamStructFunc                            func(t *testing.T, m *MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params interface{}) (*RPCResponse, error)
+	CallParamStructFuncInvocations                 int
+	CallParamArrayWithResultDecoderFunc            func(t *testing.T, m *MockClient, ctx context.Context, method string, additionalHeaders map[string]string, resultDecoder ResultDecoder, params ...interface{}) (*RPCResponse, error)
+	CallParamArrayWithResultDecoderFuncInvocations int
+	CallBatchFunc                                  func(t *testing.T, m *MockClient, ctx context.Context, additionalHeaders map[string]string, requests []RPCRequest) ([]*RPCResponse, error)
+	CallBatchFuncInvocations                       int
 }
 
 func (m *MockClient) CallParamArray(ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*RPCResponse, error) {
@@ -28,3 +34,56 @@ func (m *MockClient) CallParamStruct(ctx context.Context, method string, additio
 	}
 	return m.CallParamStructFunc(m.T, m, ctx, method, additionalHeaders, params)
 }
+
+// CallParamArrayWithResultDecoder calls CallParamArrayWithResultDecoderFunc if it is set.
+// Otherwise the call is delegated to CallParamArray and the Result of the
+// RPCResponse returned is decoded with the given ResultDecoder.
+func (m *MockClient) CallParamArrayWithResultDecoder(ctx context.Context, method string, additionalHeaders map[string]string, resultDecoder ResultDecoder, params ...interface{}) (*RPCResponse, error) {
+	m.CallParamArrayWithResultDecoderFuncInvocations++
+	if m.CallParamArrayWithResultDecoderFunc != nil {
+		return m.CallParamArrayWithResultDecoderFunc(m.T, m, ctx, method, additionalHeaders, resultDecoder, params...)
+	}
+
+	// delegate to CallParamArray
+	rpcResponse, err := m.CallParamArray(ctx, method, additionalHeaders, params...)
+	if err != nil || rpcResponse == nil || rpcResponse.Result == nil {
+		return rpcResponse, err
+	}
+
+	// decode result
+	if err := resultDecoder(json.NewDecoder(bytes.NewReader(rpcResponse.Result))); err != nil {
+		return nil, err
+	}
+	decodedRPCResponse := *rpcResponse
+	decodedRPCResponse.Result = nil
+
+	return &decodedRPCResponse, nil
+}
+
+// CallBatch calls CallBatchFunc if it is set. Otherwise each request is delegated to
+// CallParamArray, or to CallParamStruct if its params are neither nil nor an array.
+func (m *MockClient) CallBatch(ctx context.Context, additionalHeaders map[string]string, requests []RPCRequest) ([]*RPCResponse, error) {
+	m.CallBatchFuncInvocations++
+	if m.CallBatchFunc != nil {
+		return m.CallBatchFunc(m.T, m, ctx, additionalHeaders, requests)
+	}
+
+	// delegate each request
+	rpcResponses := make([]*RPCResponse, len(requests))
+	for i, request := range requests {
+		var err error
+		switch params := request.Params.(type) {
+		case nil:
+			rpcResponses[i], err = m.CallParamArray(ctx, request.Method, additionalHeaders)
+		case []interface{}:
+			rpcResponses[i], err = m.CallParamArray(ctx, request.Method, additionalHeaders, params...)
+		default:
+			rpcResponses[i], err = m.CallParamStruct(ctx, request.Method, additionalHeaders, params)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return rpcResponses, nil
+}
@@ -8,4 +8,5 @@ var (
 	ErrBadRequest        = errors.New("bad request")
 	ErrHTTPError         = errors.New("http error")
 	ErrConnectionRefused = errors.New("connection refused")
+	ErrUnexpectedToken   = errors.New("unexpected json token")
 )
@@ -0,0 +1,155 @@
+package jsonrpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateLimit is a rate at which calls may be made
+type RateLimit struct {
+	// PerSecond is the sustained number of calls allowed per second.
+	// A PerSecond of 0 or less allows unlimited calls.
+	PerSecond float64
+
+	// Burst is the number of calls that may be made at once before
+	// calls are limited to PerSecond. Values less than 1 are treated as 1.
+	Burst int
+}
+
+// RateLimiter limits the rate of calls with token buckets. A global bucket limits
+// calls of all methods, and a bucket per method limits calls of that method.
+// A RateLimiter is safe for concurrent use and may be shared between clients.
+type RateLimiter struct {
+	global  *tokenBucket
+	methods map[string]*tokenBucket
+}
+
+// NewRateLimiter returns a new RateLimiter that limits calls of all methods to the
+// given global RateLimit, and calls of each given method to the given RateLimit
+func NewRateLimiter(global RateLimit, methods map[string]RateLimit) *RateLimiter {
+	l := &RateLimiter{
+		global:  newTokenBucket(global),
+		methods: make(map[string]*tokenBucket),
+	}
+	for method, rateLimit := range methods {
+		if bucket := newTokenBucket(rateLimit); bucket != nil {
+			l.methods[method] = bucket
+		}
+	}
+	return l
+}
+
+// Wait waits until one call of each of the given methods is allowed. If the context is done
+// first, or the wait would exceed the deadline of the context, then an error is returned
+// and the calls are not counted against the limits.
+func (l *RateLimiter) Wait(ctx context.Context, methods []string) error {
+	// reserve a token for each call from the relevant buckets
+	now := time.Now()
+	type reservation struct {
+		bucket *tokenBucket
+		tokens float64
+	}
+	reservations := make([]reservation, 0, len(methods)+1)
+	if l.global != nil {
+		reservations = append(reservations, reservation{bucket: l.global, tokens: float64(len(methods))})
+	}
+	methodTokens := make(map[string]float64)
+	for _, method := range methods {
+		methodTokens[method]++
+	}
+	for method, tokens := range methodTokens {
+		if bucket, found := l.methods[method]; found {
+			reservations = append(reservations, reservation{bucket: bucket, tokens: tokens})
+		}
+	}
+	var delay time.Duration
+	for _, r := range reservations {
+		if d := r.bucket.reserve(now, r.tokens); d > delay {
+			delay = d
+		}
+	}
+	if delay <= 0 {
+		return nil
+	}
+	cancel := func() {
+		for _, r := range reservations {
+			r.bucket.cancel(r.tokens)
+		}
+	}
+
+	// wait for reservations
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(now.Add(delay)) {
+		cancel()
+		return fmt.Errorf("rate limit wait of %s exceeds context deadline: %w", delay, context.DeadlineExceeded)
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		cancel()
+		return ctx.Err()
+	}
+}
+
+// tokenBucket is a token bucket that is filled at a rate up to a burst size
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket returns a new full tokenBucket for the given RateLimit,
+// or nil if the RateLimit does not limit calls
+func newTokenBucket(rateLimit RateLimit) *tokenBucket {
+	if rateLimit.PerSecond <= 0 {
+		return nil
+	}
+	burst := float64(rateLimit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   rateLimit.PerSecond,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// reserve takes the given number of tokens from the bucket, returning the time after now
+// at which they are available. The tokens of the bucket go negative to reserve future tokens.
+func (b *tokenBucket) reserve(now time.Time, tokens float64) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// fill bucket for the time elapsed
+	if now.After(b.last) {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+
+	b.tokens -= tokens
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns the given number of reserved tokens to the bucket
+func (b *tokenBucket) cancel(tokens float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens += tokens
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
@@ -0,0 +1,101 @@
+package jsonrpc
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Wait(t *testing.T) {
+	tests := []struct {
+		name      string
+		global    RateLimit
+		methods   map[string]RateLimit
+		calls     [][]string
+		wantDelay []bool
+	}{
+		{
+			name:      "no limits",
+			calls:     [][]string{{"getBalance"}, {"getBalance"}, {"getBalance"}},
+			wantDelay: []bool{false, false, false},
+		},
+		{
+			name:      "global burst then limited",
+			global:    RateLimit{PerSecond: 10, Burst: 2},
+			calls:     [][]string{{"getBalance"}, {"getAccountInfo"}, {"getBalance"}},
+			wantDelay: []bool{false, false, true},
+		},
+		{
+			name:      "method limited independently",
+			methods:   map[string]RateLimit{"getProgramAccounts": {PerSecond: 10, Burst: 1}},
+			calls:     [][]string{{"getProgramAccounts"}, {"getBalance"}, {"getBalance"}, {"getProgramAccounts"}},
+			wantDelay: []bool{false, false, false, true},
+		},
+		{
+			name:      "batch takes a token per call",
+			global:    RateLimit{PerSecond: 10, Burst: 3},
+			calls:     [][]string{{"getBalance", "getBalance", "getBalance"}, {"getBalance"}},
+			wantDelay: []bool{false, true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rateLimiter := NewRateLimiter(tt.global, tt.methods)
+			for i, methods := range tt.calls {
+				start := time.Now()
+				require.NoError(t, rateLimiter.Wait(context.Background(), methods))
+				require.Equal(t, tt.wantDelay[i], time.Since(start) >= 50*time.Millisecond, "call %d", i)
+			}
+		})
+	}
+}
+
+func TestRateLimiter_WaitContext(t *testing.T) {
+	rateLimiter := NewRateLimiter(RateLimit{PerSecond: 1, Burst: 1}, nil)
+	require.NoError(t, rateLimiter.Wait(context.Background(), []string{"getBalance"}))
+
+	// wait exceeding deadline fails immediately
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	require.ErrorIs(t, rateLimiter.Wait(ctx, []string{"getBalance"}), context.DeadlineExceeded)
+	require.Less(t, int64(time.Since(start)), int64(50*time.Millisecond))
+
+	// cancelled wait fails and returns its token
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	require.ErrorIs(t, rateLimiter.Wait(ctx, []string{"getBalance"}), context.Canceled)
+	require.Equal(t, time.Duration(0), rateLimiter.global.reserve(time.Now(), 0))
+}
+
+func TestHTTPClient_RateLimiter(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = io.WriteString(w, `{"jsonrpc": "2.0", "result": 1, "id": 1}`)
+	}))
+	defer server.Close()
+
+	// clients sharing a rate limiter share its limits
+	rateLimiter := NewRateLimiter(RateLimit{PerSecond: 1, Burst: 2}, nil)
+	clientA := NewHTTPClientFromOpts(server.URL, RPCClientOpts{RateLimiter: rateLimiter})
+	clientB := NewHTTPClientFromOpts(server.URL, RPCClientOpts{RateLimiter: rateLimiter})
+
+	_, err := clientA.CallParamArray(context.Background(), "getBalance", nil)
+	require.NoError(t, err)
+	_, err = clientB.CallParamArray(context.Background(), "getBalance", nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = clientA.CallParamArray(ctx, "getBalance", nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, 2, calls)
+}
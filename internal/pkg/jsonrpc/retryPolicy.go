@@ -0,0 +1,117 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures the retrying of calls that fail with transient errors.
+//
+// Calls are retried if they fail with an http 429, 502, 503 or 504 status code, or if the
+// http request fails. Calls to NonIdempotentMethods are only retried if the call can not
+// have been processed, i.e. with an http 429 status code or if the connection was refused.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts of each call, including the first
+	MaxAttempts int
+
+	// MinBackoff is the delay before the first retry, which doubles on each retry up to MaxBackoff,
+	// or without limit if MaxBackoff is 0.
+	// A Retry-After header in a failed response is honoured if it requests a longer delay.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// NonIdempotentMethods are the methods that are only retried if the call can not have been processed
+	NonIdempotentMethods []string
+}
+
+// retryDelay returns the delay before retrying a call that failed with the given error on
+// the given attempt, or false if the call should not be retried
+func (p *RetryPolicy) retryDelay(attempt int, methods []string, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts {
+		return 0, false
+	}
+
+	// classify error
+	var retryAfter time.Duration
+	var processed bool
+	var httpError *HTTPError
+	var opError *net.OpError
+	switch {
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		return 0, false
+
+	case errors.As(err, &httpError):
+		switch httpError.Code {
+		case http.StatusTooManyRequests:
+			retryAfter = httpError.retryAfter
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			retryAfter = httpError.retryAfter
+			processed = true
+		default:
+			return 0, false
+		}
+
+	case errors.As(err, &opError) && opError.Op == "dial":
+		// request was not sent
+
+	default:
+		// request may have been sent
+		processed = true
+	}
+
+	// check if the call may be retried given that it may have been processed
+	if processed {
+		for _, method := range methods {
+			for _, nonIdempotentMethod := range p.NonIdempotentMethods {
+				if method == nonIdempotentMethod {
+					return 0, false
+				}
+			}
+		}
+	}
+
+	// determine delay, which is not capped if there is no MaxBackoff
+	delay := p.MinBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || delay < p.MaxBackoff) && delay <= math.MaxInt64/2; i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if delay > 0 {
+		// reduce the delay by a random jitter of up to half so that
+		// clients failing together do not retry together
+		delay -= time.Duration(rand.Int63n(int64(delay)/2 + 1))
+	}
+	if retryAfter > delay {
+		delay = retryAfter
+	}
+
+	return delay, true
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which
+// is either a number of seconds or an http date. Returns 0 if invalid.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay
+		}
+	}
+	return 0
+}
@@ -0,0 +1,143 @@
+package jsonrpc
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHTTPClient_RetryPolicy(t *testing.T) {
+	retryPolicy := &RetryPolicy{
+		MaxAttempts:          3,
+		MinBackoff:           time.Millisecond,
+		MaxBackoff:           time.Millisecond,
+		NonIdempotentMethods: []string{"sendTransaction"},
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		statusCodes  []int
+		wantAttempts int
+		wantErr      error
+	}{
+		{
+			name:         "retried until success",
+			method:       "getBalance",
+			statusCodes:  []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusOK},
+			wantAttempts: 3,
+		},
+		{
+			name:         "retried until max attempts",
+			method:       "getBalance",
+			statusCodes:  []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK},
+			wantAttempts: 3,
+			wantErr:      ErrHTTPError,
+		},
+		{
+			name:         "non transient error not retried",
+			method:       "getBalance",
+			statusCodes:  []int{http.StatusBadRequest, http.StatusOK},
+			wantAttempts: 1,
+			wantErr:      ErrBadRequest,
+		},
+		{
+			name:         "non idempotent method retried if not processed",
+			method:       "sendTransaction",
+			statusCodes:  []int{http.StatusTooManyRequests, http.StatusOK},
+			wantAttempts: 2,
+		},
+		{
+			name:         "non idempotent method not retried if possibly processed",
+			method:       "sendTransaction",
+			statusCodes:  []int{http.StatusBadGateway, http.StatusOK},
+			wantAttempts: 1,
+			wantErr:      ErrHTTPError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				statusCode := tt.statusCodes[attempts]
+				attempts++
+				if statusCode == http.StatusTooManyRequests {
+					w.Header().Set("Retry-After", "0")
+				}
+				w.WriteHeader(statusCode)
+				_, _ = io.WriteString(w, `{"jsonrpc": "2.0", "result": 1, "id": 1}`)
+			}))
+			defer server.Close()
+
+			client := NewHTTPClientFromOpts(server.URL, RPCClientOpts{RetryPolicy: retryPolicy})
+			_, err := client.CallParamArray(context.Background(), tt.method, nil)
+			require.Equal(t, tt.wantAttempts, attempts)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRetryPolicy_retryDelay(t *testing.T) {
+	retryPolicy := &RetryPolicy{
+		MaxAttempts: 10,
+		MinBackoff:  100 * time.Millisecond,
+		MaxBackoff:  time.Second,
+	}
+
+	// delay doubles up to the maximum backoff, reduced by up to half
+	for attempt, wantMax := range []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	} {
+		delay, retry := retryPolicy.retryDelay(attempt+1, []string{"getBalance"}, &HTTPError{Code: http.StatusBadGateway})
+		require.True(t, retry)
+		require.LessOrEqual(t, delay, wantMax)
+		require.GreaterOrEqual(t, delay, wantMax/2)
+	}
+
+	// delay is not capped without a maximum backoff
+	uncappedRetryPolicy := &RetryPolicy{
+		MaxAttempts: 10,
+		MinBackoff:  100 * time.Millisecond,
+	}
+	delay, retry := uncappedRetryPolicy.retryDelay(6, []string{"getBalance"}, &HTTPError{Code: http.StatusBadGateway})
+	require.True(t, retry)
+	require.LessOrEqual(t, delay, 3200*time.Millisecond)
+	require.GreaterOrEqual(t, delay, 1600*time.Millisecond)
+
+	// retry after is honoured
+	delay, retry = retryPolicy.retryDelay(1, []string{"getBalance"}, &HTTPError{Code: http.StatusTooManyRequests, retryAfter: 5 * time.Second})
+	require.True(t, retry)
+	require.Equal(t, 5*time.Second, delay)
+
+	// context errors are not retried
+	_, retry = retryPolicy.retryDelay(1, []string{"getBalance"}, context.Canceled)
+	require.False(t, retry)
+}
+
+func TestHTTPClient_RetryPolicy_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// cancel while waiting to retry
+		cancel()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewHTTPClientFromOpts(server.URL, RPCClientOpts{RetryPolicy: &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Hour}})
+	_, err := client.CallParamArray(ctx, "getBalance", nil)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -1,8 +1,12 @@
 package jsonrpc
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"net/http"
 	"strconv"
+	"time"
 )
 
 // RPCResponse represents a JSON-RPC response object.
@@ -54,9 +58,112 @@ func (RPCResponse *RPCResponse) GetObject(target interface{}) error {
 type HTTPError struct {
 	Code int
 	err  error
+
+	// retryAfter is the delay requested by a Retry-After header, if any
+	retryAfter time.Duration
 }
 
 // Error function is provided to be used as error object.
 func (e *HTTPError) Error() string {
 	return e.err.Error()
 }
+
+// Is reports whether the target is ErrHTTPError, or ErrBadRequest
+// or ErrUnauthorized if the error has the corresponding status code
+func (e *HTTPError) Is(target error) bool {
+	switch target {
+	case ErrHTTPError:
+		return true
+	case ErrBadRequest:
+		return e.Code == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.Code == http.StatusUnauthorized
+	default:
+		return false
+	}
+}
+
+// DecodeRPCResponse decodes an RPCResponse object with the given json.Decoder.
+// If a ResultDecoder is given then it is used to decode the result of the
+// response, and the Result of the returned RPCResponse is not set.
+func DecodeRPCResponse(decoder *json.Decoder, resultDecoder ResultDecoder) (*RPCResponse, error) {
+	if resultDecoder == nil {
+		var rpcResponse RPCResponse
+		if err := decoder.Decode(&rpcResponse); err != nil {
+			return nil, err
+		}
+		return &rpcResponse, nil
+	}
+
+	// walk the fields of the response object, passing the result to the ResultDecoder
+	if err := expectDelim(decoder, '{'); err != nil {
+		return nil, err
+	}
+	var rpcResponse RPCResponse
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch key {
+		case "jsonrpc":
+			err = decoder.Decode(&rpcResponse.JSONRPC)
+		case "result":
+			err = resultDecoder(decoder)
+		case "error":
+			err = decoder.Decode(&rpcResponse.Error)
+		case "id":
+			err = decoder.Decode(&rpcResponse.ID)
+		default:
+			err = decoder.Decode(new(json.RawMessage))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error decoding %v: %w", key, err)
+		}
+	}
+	if err := expectDelim(decoder, '}'); err != nil {
+		return nil, err
+	}
+
+	return &rpcResponse, nil
+}
+
+// expectDelim reads the next token from the given json.Decoder and
+// returns an error if it is not the given delimiter
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected '%v', got '%v': %w", delim, token, ErrUnexpectedToken)
+	}
+	return nil
+}
+
+// DecodeRPCBatchResponse decodes the RPCResponse objects of a batch response with the given
+// json.Decoder. If the response is a single RPCResponse object, as is returned if the batch
+// as a whole is rejected, then the RPCError set on it is returned.
+func DecodeRPCBatchResponse(decoder *json.Decoder) ([]*RPCResponse, error) {
+	var data json.RawMessage
+	if err := decoder.Decode(&data); err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		var rpcResponse RPCResponse
+		if err := json.Unmarshal(data, &rpcResponse); err != nil {
+			return nil, err
+		}
+		if rpcResponse.Error != nil {
+			return nil, rpcResponse.Error
+		}
+		return nil, fmt.Errorf("expected batch response, got single response: %w", ErrUnexpectedToken)
+	}
+
+	var rpcResponses []*RPCResponse
+	if err := json.Unmarshal(data, &rpcResponses); err != nil {
+		return nil, err
+	}
+	return rpcResponses, nil
+}
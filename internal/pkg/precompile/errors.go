@@ -0,0 +1,11 @@
+package precompile
+
+import "errors"
+
+var (
+	ErrNoSignedMessages        = errors.New("no signed messages")
+	ErrTooManySignedMessages   = errors.New("too many signed messages")
+	ErrUnexpectedProgramID     = errors.New("unexpected program id")
+	ErrInvalidInstructionData  = errors.New("invalid instruction data")
+	ErrInvalidInstructionIndex = errors.New("invalid instruction index")
+)
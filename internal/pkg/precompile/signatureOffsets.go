@@ -0,0 +1,134 @@
+// Package precompile holds the instruction data layout shared by the signature verification
+// precompiles, i.e. the ed25519 and secp256k1 programs. The instruction data holds the number of
+// signed messages, followed by the serialised signature offsets of each signed message, which
+// locate the parts of the signed message in the data of an instruction in the transaction.
+package precompile
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/BRBussy/solgo"
+	"math"
+)
+
+// Layout is the instruction data layout of a signature verification precompile
+type Layout struct {
+	// ProgramID is the ID of the precompile
+	ProgramID solana.PublicKey
+
+	// OffsetsStart is the offset of the first signature offsets in the instruction data
+	OffsetsStart int
+
+	// OffsetsSize is the size of serialised signature offsets
+	OffsetsSize int
+}
+
+// NewInstruction creates an Instruction of the precompile that holds the given signed messages in its data.
+// Each signed message is given as the parts with which it is held in the data, e.g. public key, signature
+// and message, which are written consecutively after the signature offsets of every signed message.
+// newOffsets returns the signature offsets of the signed message at index i, given the offset
+// of each of its parts, which are written in little endian with encoding/binary
+// and must be encoded in exactly OffsetsSize bytes.
+func (l Layout) NewInstruction(signedMessages [][][]byte, newOffsets func(i int, partOffsets []uint16) interface{}) ([]solana.Instruction, error) {
+	if len(signedMessages) == 0 {
+		return nil, ErrNoSignedMessages
+	}
+	if len(signedMessages) > math.MaxUint8 {
+		return nil, fmt.Errorf("%d signed messages: %w", len(signedMessages), ErrTooManySignedMessages)
+	}
+
+	// the signed message data follows the signature offsets of every message
+	offsets := make([]interface{}, 0, len(signedMessages))
+	signedMessageData := new(bytes.Buffer)
+	dataStart := l.OffsetsStart + l.OffsetsSize*len(signedMessages)
+	for i, parts := range signedMessages {
+		partOffsets := make([]uint16, len(parts))
+		for j, part := range parts {
+			partOffset := dataStart + signedMessageData.Len()
+			if partOffset+len(part) > math.MaxUint16 {
+				return nil, fmt.Errorf("signed message data exceeds %d bytes: %w", math.MaxUint16, ErrInvalidInstructionData)
+			}
+			partOffsets[j] = uint16(partOffset)
+			signedMessageData.Write(part)
+		}
+		o := newOffsets(i, partOffsets)
+		if size := binary.Size(o); size != l.OffsetsSize {
+			return nil, fmt.Errorf("signature offsets of %d bytes, expected %d: %w", size, l.OffsetsSize, ErrInvalidInstructionData)
+		}
+		offsets = append(offsets, o)
+	}
+
+	// encode instruction data
+	buf := new(bytes.Buffer)
+	header := make([]byte, l.OffsetsStart)
+	header[0] = uint8(len(signedMessages))
+	buf.Write(header)
+	for _, o := range offsets {
+		if err := binary.Write(buf, binary.LittleEndian, o); err != nil {
+			return nil, fmt.Errorf("error encoding signature offsets: %w", err)
+		}
+	}
+	buf.Write(signedMessageData.Bytes())
+
+	// construct and return instruction
+	return []solana.Instruction{
+		{
+			InstructionAccountMeta: []solana.InstructionAccountMeta{},
+			ProgramIDPubKey:        l.ProgramID,
+			Data:                   buf.Bytes(),
+		},
+	}, nil
+}
+
+// ReadOffsets reads the signature offsets held in the data of the precompile Instruction at the
+// given index in the given Instructions of a transaction into the slice returned by newOffsets,
+// which is called with the number of signed messages held in the instruction and must return
+// a value that is decoded from exactly OffsetsSize bytes per signed message.
+func (l Layout) ReadOffsets(instructions []solana.Instruction, index int, newOffsets func(n int) interface{}) error {
+	if index < 0 || index >= len(instructions) {
+		return fmt.Errorf("index %d of %d instructions: %w", index, len(instructions), ErrInvalidInstructionIndex)
+	}
+	instruction := instructions[index]
+	if instruction.ProgramIDPubKey.ToBase58() != l.ProgramID.ToBase58() {
+		return fmt.Errorf("%s: %w", instruction.ProgramIDPubKey.ToBase58(), ErrUnexpectedProgramID)
+	}
+	if len(instruction.Data) < l.OffsetsStart {
+		return fmt.Errorf("data shorter than header: %w", ErrInvalidInstructionData)
+	}
+
+	// read signature offsets
+	noSignatures := int(instruction.Data[0])
+	if noSignatures == 0 {
+		return ErrNoSignedMessages
+	}
+	if len(instruction.Data) < l.OffsetsStart+l.OffsetsSize*noSignatures {
+		return fmt.Errorf("data shorter than signature offsets: %w", ErrInvalidInstructionData)
+	}
+	offsets := newOffsets(noSignatures)
+	if size := binary.Size(offsets); size != l.OffsetsSize*noSignatures {
+		return fmt.Errorf("signature offsets of %d bytes, expected %d: %w", size, l.OffsetsSize*noSignatures, ErrInvalidInstructionData)
+	}
+	if err := binary.Read(
+		bytes.NewReader(instruction.Data[l.OffsetsStart:]),
+		binary.LittleEndian,
+		offsets,
+	); err != nil {
+		return fmt.Errorf("error reading signature offsets: %w", err)
+	}
+
+	return nil
+}
+
+// Data returns the data of the given size at the given offset
+// in the data of the Instruction at the given index
+func Data(instructions []solana.Instruction, instructionIndex int, offset uint16, size int) ([]byte, error) {
+	if instructionIndex >= len(instructions) {
+		return nil, fmt.Errorf("referenced instruction %d: %w", instructionIndex, ErrInvalidInstructionIndex)
+	}
+	data := instructions[instructionIndex].Data
+	if int(offset)+size > len(data) {
+		return nil, fmt.Errorf("%d bytes at offset %d exceeds data: %w", size, offset, ErrInvalidInstructionData)
+	}
+	return data[offset : int(offset)+size], nil
+}
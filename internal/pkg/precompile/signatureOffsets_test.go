@@ -0,0 +1,86 @@
+package precompile
+
+import (
+	"github.com/BRBussy/solgo"
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+)
+
+type testOffsets struct {
+	KeyOffset     uint16
+	MessageOffset uint16
+	MessageSize   uint8
+}
+
+func TestLayout(t *testing.T) {
+	layout := Layout{
+		ProgramID:    solana.MustNewRandomKeypair().PublicKey,
+		OffsetsStart: 2,
+		OffsetsSize:  5,
+	}
+	signedMessages := [][][]byte{
+		{[]byte("key1"), []byte("message1")},
+		{[]byte("k2"), []byte("m2")},
+	}
+	newOffsets := func(i int, partOffsets []uint16) interface{} {
+		return testOffsets{
+			KeyOffset:     partOffsets[0],
+			MessageOffset: partOffsets[1],
+			MessageSize:   uint8(len(signedMessages[i][1])),
+		}
+	}
+
+	// signed message data follows the header and offsets
+	instructions, err := layout.NewInstruction(signedMessages, newOffsets)
+	require.NoError(t, err)
+	require.Len(t, instructions, 1)
+	require.Equal(t, layout.ProgramID, instructions[0].ProgramIDPubKey)
+	require.Equal(
+		t,
+		append(
+			[]byte{
+				2, 0,
+				12, 0, 16, 0, 8,
+				24, 0, 26, 0, 2,
+			},
+			[]byte("key1message1k2m2")...,
+		),
+		instructions[0].Data,
+	)
+
+	// offsets are read back and locate the data
+	var offsets []testOffsets
+	require.NoError(t, layout.ReadOffsets(instructions, 0, func(n int) interface{} {
+		offsets = make([]testOffsets, n)
+		return offsets
+	}))
+	require.Len(t, offsets, 2)
+	message, err := Data(instructions, 0, offsets[1].MessageOffset, int(offsets[1].MessageSize))
+	require.NoError(t, err)
+	require.Equal(t, []byte("m2"), message)
+	_, err = Data(instructions, 0, offsets[1].MessageOffset, 3)
+	require.ErrorIs(t, err, ErrInvalidInstructionData)
+	_, err = Data(instructions, 1, 0, 0)
+	require.ErrorIs(t, err, ErrInvalidInstructionIndex)
+
+	// invalid signed messages are rejected
+	_, err = layout.NewInstruction(nil, newOffsets)
+	require.ErrorIs(t, err, ErrNoSignedMessages)
+	_, err = layout.NewInstruction(make([][][]byte, math.MaxUint8+1), newOffsets)
+	require.ErrorIs(t, err, ErrTooManySignedMessages)
+	_, err = layout.NewInstruction([][][]byte{{make([]byte, math.MaxUint16)}}, newOffsets)
+	require.ErrorIs(t, err, ErrInvalidInstructionData)
+	_, err = layout.NewInstruction(signedMessages, func(int, []uint16) interface{} { return uint16(0) })
+	require.ErrorIs(t, err, ErrInvalidInstructionData)
+	_, err = layout.NewInstruction(signedMessages, func(int, []uint16) interface{} { return "offsets" })
+	require.ErrorIs(t, err, ErrInvalidInstructionData)
+
+	// invalid instructions are rejected
+	noOffsets := func(n int) interface{} { return make([]testOffsets, n) }
+	require.ErrorIs(t, layout.ReadOffsets(instructions, 1, noOffsets), ErrInvalidInstructionIndex)
+	require.ErrorIs(t, layout.ReadOffsets([]solana.Instruction{{ProgramIDPubKey: solana.MustNewRandomKeypair().PublicKey}}, 0, noOffsets), ErrUnexpectedProgramID)
+	require.ErrorIs(t, layout.ReadOffsets([]solana.Instruction{{ProgramIDPubKey: layout.ProgramID, Data: []byte{0, 0}}}, 0, noOffsets), ErrNoSignedMessages)
+	require.ErrorIs(t, layout.ReadOffsets([]solana.Instruction{{ProgramIDPubKey: layout.ProgramID, Data: instructions[0].Data[:10]}}, 0, noOffsets), ErrInvalidInstructionData)
+	require.ErrorIs(t, layout.ReadOffsets(instructions, 0, func(n int) interface{} { return make([]uint16, n) }), ErrInvalidInstructionData)
+}
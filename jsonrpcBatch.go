@@ -0,0 +1,145 @@
+package solana
+
+import (
+	"context"
+	"fmt"
+	"github.com/BRBussy/solgo/internal/pkg/jsonrpc"
+)
+
+// JSONRPCBatch is a batch of calls that are performed by a JSONRPCConnection
+// in a single json-rpc batch request. Construct with JSONRPCConnection.NewBatch.
+//
+// Each call added to the batch returns a handle from which the response
+// of the call can be retrieved once the batch has been executed:
+//
+//	batch := connection.NewBatch()
+//	calls := make([]*GetBalanceBatchCall, len(publicKeys))
+//	for i, publicKey := range publicKeys {
+//		calls[i] = batch.GetBalance(GetBalanceRequest{PublicKey: publicKey})
+//	}
+//	if err := batch.Execute(ctx); err != nil {
+//		return err
+//	}
+//	for _, call := range calls {
+//		response, err := call.Response()
+//		...
+//	}
+type JSONRPCBatch struct {
+	connection *JSONRPCConnection
+	requests   []jsonrpc.RPCRequest
+
+	// handlers handle the response, or the error, of each request
+	handlers []func(rpcResponse *jsonrpc.RPCResponse, err error)
+}
+
+// NewBatch returns a new and empty JSONRPCBatch that is executed with the JSONRPCConnection
+func (j *JSONRPCConnection) NewBatch() *JSONRPCBatch {
+	return &JSONRPCBatch{
+		connection: j,
+	}
+}
+
+// Len returns the number of calls in the batch
+func (b *JSONRPCBatch) Len() int {
+	return len(b.requests)
+}
+
+// Execute performs all calls in the batch in a single json-rpc batch request.
+// If the batch request fails then the error is returned, and each call fails
+// with it. Otherwise the response or error of each call is set on its handle.
+func (b *JSONRPCBatch) Execute(ctx context.Context) error {
+	// perform rpc call
+	rpcResponses, err := b.connection.jsonRPCClient.CallBatch(ctx, nil, b.requests)
+	if err != nil {
+		err = fmt.Errorf("error performing json-rpc batch call: %w", err)
+		for _, handler := range b.handlers {
+			handler(nil, err)
+		}
+		return err
+	}
+	if len(rpcResponses) != len(b.requests) {
+		err := fmt.Errorf("%d responses to %d batch requests: %w", len(rpcResponses), len(b.requests), ErrUnexpectedResponse)
+		for _, handler := range b.handlers {
+			handler(nil, err)
+		}
+		return err
+	}
+
+	// handle responses
+	for i, handler := range b.handlers {
+		if rpcResponses[i].Error != nil {
+			handler(nil, fmt.Errorf("error set on rpc response: %w", newRPCError(rpcResponses[i].Error)))
+			continue
+		}
+		handler(rpcResponses[i], nil)
+	}
+
+	return nil
+}
+
+// add adds a call to the batch
+func (b *JSONRPCBatch) add(method string, params []interface{}, handler func(rpcResponse *jsonrpc.RPCResponse, err error)) {
+	b.requests = append(b.requests, jsonrpc.RPCRequest{
+		Method: method,
+		Params: params,
+	})
+	b.handlers = append(b.handlers, handler)
+}
+
+// GetBalanceBatchCall is a getBalance call added to a JSONRPCBatch
+type GetBalanceBatchCall struct {
+	response *GetBalanceResponse
+	err      error
+}
+
+// Response returns the response of the call, or the error with which it failed.
+// Returns ErrBatchNotExecuted if the batch has not yet been executed.
+func (c *GetBalanceBatchCall) Response() (*GetBalanceResponse, error) {
+	return c.response, c.err
+}
+
+// GetBalance adds a getBalance call to the batch
+func (b *JSONRPCBatch) GetBalance(request GetBalanceRequest) *GetBalanceBatchCall {
+	call := &GetBalanceBatchCall{err: ErrBatchNotExecuted}
+	b.add(
+		"getBalance",
+		b.connection.getBalanceParams(request),
+		func(rpcResponse *jsonrpc.RPCResponse, err error) {
+			if err != nil {
+				call.response, call.err = nil, err
+				return
+			}
+			call.response, call.err = parseGetBalanceResponse(rpcResponse)
+		},
+	)
+	return call
+}
+
+// GetAccountInfoBatchCall is a getAccountInfo call added to a JSONRPCBatch
+type GetAccountInfoBatchCall struct {
+	response *GetAccountInfoResponse
+	err      error
+}
+
+// Response returns the response of the call, or the error with which it failed.
+// Returns ErrBatchNotExecuted if the batch has not yet been executed.
+func (c *GetAccountInfoBatchCall) Response() (*GetAccountInfoResponse, error) {
+	return c.response, c.err
+}
+
+// GetAccountInfo adds a getAccountInfo call to the batch
+func (b *JSONRPCBatch) GetAccountInfo(request GetAccountInfoRequest) *GetAccountInfoBatchCall {
+	call := &GetAccountInfoBatchCall{err: ErrBatchNotExecuted}
+	b.add(
+		"getAccountInfo",
+		b.connection.getAccountInfoParams(request),
+		func(rpcResponse *jsonrpc.RPCResponse, err error) {
+			if err != nil {
+				call.response, call.err = nil, err
+				return
+			}
+			call.response, call.err = parseGetAccountInfoResponse(rpcResponse, request.Encoding)
+		},
+	)
+	return call
+}
@@ -0,0 +1,129 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/BRBussy/solgo/internal/pkg/jsonrpc"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestJSONRPCBatch_Execute(t *testing.T) {
+	publicKeyA := NewPublicKeyFromBase58String("9B5XszUGdMaxCZ7uSQhPzdks5ZQSmWxrmzCSvtJ6Ns6g")
+	publicKeyB := NewPublicKeyFromBase58String("11111111111111111111111111111111")
+
+	tests := []struct {
+		name            string
+		callBatchFunc   func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, additionalHeaders map[string]string, requests []jsonrpc.RPCRequest) ([]*jsonrpc.RPCResponse, error)
+		wantErr         bool
+		wantBalanceA    *GetBalanceResponse
+		wantBalanceB    *GetBalanceResponse
+		wantBalanceBErr bool
+		wantAccountInfo *GetAccountInfoResponse
+	}{
+		{
+			name: "responses and item error",
+			callBatchFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, additionalHeaders map[string]string, requests []jsonrpc.RPCRequest) ([]*jsonrpc.RPCResponse, error) {
+				require.Equal(
+					t,
+					[]jsonrpc.RPCRequest{
+						{
+							Method: "getBalance",
+							Params: []interface{}{
+								publicKeyA.ToBase58(),
+								map[string]interface{}{"commitment": ConfirmedCommitmentLevel},
+							},
+						},
+						{
+							Method: "getBalance",
+							Params: []interface{}{
+								publicKeyB.ToBase58(),
+								map[string]interface{}{"commitment": FinalizedCommitmentLevel},
+							},
+						},
+						{
+							Method: "getAccountInfo",
+							Params: []interface{}{
+								publicKeyA.ToBase58(),
+								map[string]interface{}{"commitment": ConfirmedCommitmentLevel, "encoding": Base64Encoding},
+							},
+						},
+					},
+					requests,
+				)
+				return []*jsonrpc.RPCResponse{
+					{Result: json.RawMessage(`{"context":{"slot":1},"value":10}`)},
+					{Error: &jsonrpc.RPCError{Code: -32602, Message: "invalid params"}},
+					{Result: json.RawMessage(`{"context":{"slot":1},"value":{"data":["","base64"],"executable":false,"lamports":10,"owner":"11111111111111111111111111111111","rentEpoch":2}}`)},
+				}, nil
+			},
+			wantBalanceA:    &GetBalanceResponse{Context: Context{Slot: 1}, Value: 10},
+			wantBalanceBErr: true,
+			wantAccountInfo: &GetAccountInfoResponse{
+				Context: Context{Slot: 1},
+				AccountInfo: AccountInfoEncodedData{
+					Lamports:  10,
+					Data:      []string{"", "base64"},
+					Owner:     "11111111111111111111111111111111",
+					RentEpoch: 2,
+				},
+			},
+		},
+		{
+			name: "batch error",
+			callBatchFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, additionalHeaders map[string]string, requests []jsonrpc.RPCRequest) ([]*jsonrpc.RPCResponse, error) {
+				return nil, errors.New("some err")
+			},
+			wantErr:         true,
+			wantBalanceBErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connection := &JSONRPCConnection{
+				jsonRPCClient: &jsonrpc.MockClient{
+					T:             t,
+					CallBatchFunc: tt.callBatchFunc,
+				},
+				config: &jsonrpcConnectionConfig{
+					commitmentLevel: ConfirmedCommitmentLevel,
+				},
+			}
+
+			// prepare batch
+			batch := connection.NewBatch()
+			balanceA := batch.GetBalance(GetBalanceRequest{PublicKey: publicKeyA})
+			balanceB := batch.GetBalance(GetBalanceRequest{PublicKey: publicKeyB, CommitmentLevel: FinalizedCommitmentLevel})
+			accountInfo := batch.GetAccountInfo(GetAccountInfoRequest{PublicKey: publicKeyA})
+			require.Equal(t, 3, batch.Len())
+			_, err := balanceA.Response()
+			require.ErrorIs(t, err, ErrBatchNotExecuted)
+
+			// execute and check responses
+			err = batch.Execute(context.Background())
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			gotBalanceA, err := balanceA.Response()
+			require.Equal(t, tt.wantErr, err != nil)
+			require.Equal(t, tt.wantBalanceA, gotBalanceA)
+
+			gotBalanceB, err := balanceB.Response()
+			require.Equal(t, tt.wantBalanceBErr, err != nil)
+			require.Equal(t, tt.wantBalanceB, gotBalanceB)
+			if !tt.wantErr {
+				var rpcError *RPCError
+				require.ErrorAs(t, err, &rpcError)
+				require.Equal(t, RPCErrorCode(-32602), rpcError.Code)
+			}
+
+			gotAccountInfo, err := accountInfo.Response()
+			require.Equal(t, tt.wantErr, err != nil)
+			require.Equal(t, tt.wantAccountInfo, gotAccountInfo)
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/BRBussy/solgo/internal/pkg/jsonrpc"
+)
+
+// JSONRPCCall is a json-rpc call made by a JSONRPCConnection, as passed through its JSONRPCInterceptors
+type JSONRPCCall struct {
+	// Requests are the requests of the call. A call that is not a batch call has a single request.
+	Requests []JSONRPCRequest
+
+	// Batch is true if the call is a batch call, made by a JSONRPCBatch
+	Batch bool
+
+	// Headers are the additional http headers sent with the call
+	Headers map[string]string
+}
+
+// JSONRPCRequest is a request of a JSONRPCCall
+type JSONRPCRequest struct {
+	Method string
+
+	// Params are the params of the request, usually a []interface{}
+	Params interface{}
+}
+
+// JSONRPCResponse is the response to a JSONRPCRequest
+type JSONRPCResponse struct {
+	// Result is the raw json result of the request, nil if an error is set
+	Result json.RawMessage
+
+	// Error is the error with which the node responded, if any
+	Error *RPCError
+}
+
+// JSONRPCInvoker performs a JSONRPCCall, returning a response to each of its requests in order
+type JSONRPCInvoker func(ctx context.Context, call *JSONRPCCall) ([]*JSONRPCResponse, error)
+
+// JSONRPCInterceptor intercepts each json-rpc call made by a JSONRPCConnection. The interceptor may
+// inspect or modify the call, e.g. to set headers, before performing it with the given JSONRPCInvoker,
+// and may inspect or modify the responses and error returned. The call can be short-circuited
+// by returning without invoking it. e.g. to log the duration of each call:
+//
+//	func(ctx context.Context, call *JSONRPCCall, invoke JSONRPCInvoker) ([]*JSONRPCResponse, error) {
+//		start := time.Now()
+//		responses, err := invoke(ctx, call)
+//		log.Printf("%s took %s: %v", call.Requests[0].Method, time.Since(start), err)
+//		return responses, err
+//	}
+type JSONRPCInterceptor func(ctx context.Context, call *JSONRPCCall, invoke JSONRPCInvoker) ([]*JSONRPCResponse, error)
+
+// toJSONRPCInterceptor converts the JSONRPCInterceptor to a jsonrpc.Interceptor
+func (i JSONRPCInterceptor) toJSONRPCInterceptor() jsonrpc.Interceptor {
+	return func(ctx context.Context, call *jsonrpc.Call, invoke jsonrpc.Invoker) ([]*jsonrpc.RPCResponse, error) {
+		responses, err := i(
+			ctx,
+			newJSONRPCCall(call),
+			func(ctx context.Context, call *JSONRPCCall) ([]*JSONRPCResponse, error) {
+				rpcResponses, err := invoke(ctx, call.toJSONRPCCall())
+				return newJSONRPCResponses(rpcResponses), err
+			},
+		)
+		return toJSONRPCResponses(responses), err
+	}
+}
+
+// newJSONRPCCall returns the JSONRPCCall of the given jsonrpc.Call
+func newJSONRPCCall(call *jsonrpc.Call) *JSONRPCCall {
+	requests := make([]JSONRPCRequest, len(call.Requests))
+	for i, request := range call.Requests {
+		requests[i] = JSONRPCRequest{
+			Method: request.Method,
+			Params: request.Params,
+		}
+	}
+	return &JSONRPCCall{
+		Requests: requests,
+		Batch:    call.Batch,
+		Headers:  call.Headers,
+	}
+}
+
+// toJSONRPCCall converts the JSONRPCCall to a jsonrpc.Call
+func (c *JSONRPCCall) toJSONRPCCall() *jsonrpc.Call {
+	requests := make([]jsonrpc.RPCRequest, len(c.Requests))
+	for i, request := range c.Requests {
+		requests[i] = jsonrpc.RPCRequest{
+			Method: request.Method,
+			Params: request.Params,
+		}
+	}
+	return &jsonrpc.Call{
+		Requests: requests,
+		Batch:    c.Batch,
+		Headers:  c.Headers,
+	}
+}
+
+// newJSONRPCResponses returns the JSONRPCResponses of the given jsonrpc.RPCResponses
+func newJSONRPCResponses(rpcResponses []*jsonrpc.RPCResponse) []*JSONRPCResponse {
+	if rpcResponses == nil {
+		return nil
+	}
+	responses := make([]*JSONRPCResponse, len(rpcResponses))
+	for i, rpcResponse := range rpcResponses {
+		if rpcResponse == nil {
+			continue
+		}
+		responses[i] = &JSONRPCResponse{
+			Result: rpcResponse.Result,
+		}
+		if rpcResponse.Error != nil {
+			responses[i].Error = &RPCError{
+				Code:    RPCErrorCode(rpcResponse.Error.Code),
+				Message: rpcResponse.Error.Message,
+			}
+			if rpcResponse.Error.Data != nil {
+				if data, err := json.Marshal(rpcResponse.Error.Data); err == nil {
+					responses[i].Error.Data = data
+				}
+			}
+		}
+	}
+	return responses
+}
+
+// toJSONRPCResponses converts the JSONRPCResponses to jsonrpc.RPCResponses
+func toJSONRPCResponses(responses []*JSONRPCResponse) []*jsonrpc.RPCResponse {
+	if responses == nil {
+		return nil
+	}
+	rpcResponses := make([]*jsonrpc.RPCResponse, len(responses))
+	for i, response := range responses {
+		if response == nil {
+			continue
+		}
+		rpcResponses[i] = &jsonrpc.RPCResponse{
+			JSONRPC: "2.0",
+			Result:  response.Result,
+		}
+		if response.Error != nil {
+			rpcResponses[i].Error = &jsonrpc.RPCError{
+				Code:    int(response.Error.Code),
+				Message: response.Error.Message,
+			}
+			if response.Error.Data != nil {
+				rpcResponses[i].Error.Data = response.Error.Data
+			}
+		}
+	}
+	return rpcResponses
+}
@@ -0,0 +1,88 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithInterceptors(t *testing.T) {
+	publicKey := NewPublicKeyFromBase58String("9B5XszUGdMaxCZ7uSQhPzdks5ZQSmWxrmzCSvtJ6Ns6g")
+
+	// prepare server that responds to getBalance and checks headers
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		require.Equal(t, "custom", r.Header.Get("X-Custom"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var request struct {
+			Method string `json:"method"`
+		}
+		require.NoError(t, json.Unmarshal(body, &request))
+		switch request.Method {
+		case "getBalance":
+			_, _ = io.WriteString(w, `{"jsonrpc":"2.0","result":{"context":{"slot":1},"value":10},"id":1}`)
+		default:
+			_, _ = io.WriteString(w, `{"jsonrpc":"2.0","error":{"code":-32601,"message":"Method not found"},"id":1}`)
+		}
+	}))
+	defer server.Close()
+
+	// prepare interceptors
+	var methods []string
+	var responses []*JSONRPCResponse
+	recordingInterceptor := func(ctx context.Context, call *JSONRPCCall, invoke JSONRPCInvoker) ([]*JSONRPCResponse, error) {
+		methods = append(methods, call.Requests[0].Method)
+		callResponses, err := invoke(ctx, call)
+		responses = append(responses, callResponses...)
+		return callResponses, err
+	}
+	authInterceptor := func(ctx context.Context, call *JSONRPCCall, invoke JSONRPCInvoker) ([]*JSONRPCResponse, error) {
+		call.Headers["Authorization"] = "Bearer token"
+		return invoke(ctx, call)
+	}
+	slotInterceptor := func(ctx context.Context, call *JSONRPCCall, invoke JSONRPCInvoker) ([]*JSONRPCResponse, error) {
+		if call.Requests[0].Method == "getSlot" {
+			return []*JSONRPCResponse{{Result: json.RawMessage(`42`)}}, nil
+		}
+		return invoke(ctx, call)
+	}
+
+	connection := NewJSONRPCConnection(
+		WithEndpoint(server.URL),
+		WithCustomHeaders(map[string]string{"X-Custom": "custom"}),
+		WithInterceptors(recordingInterceptor, authInterceptor),
+		WithInterceptors(slotInterceptor),
+	)
+
+	// call is passed through interceptors
+	getBalanceResponse, err := connection.GetBalance(context.Background(), GetBalanceRequest{PublicKey: publicKey})
+	require.NoError(t, err)
+	require.Equal(t, &GetBalanceResponse{Context: Context{Slot: 1}, Value: 10}, getBalanceResponse)
+
+	// call is short-circuited
+	getSlotResponse, err := connection.GetSlot(context.Background(), GetSlotRequest{})
+	require.NoError(t, err)
+	require.Equal(t, &GetSlotResponse{Slot: 42}, getSlotResponse)
+
+	// rpc errors are seen by interceptors and returned
+	err = connection.GetHealth(context.Background())
+	var rpcError *RPCError
+	require.ErrorAs(t, err, &rpcError)
+	require.Equal(t, RPCErrorCode(-32601), rpcError.Code)
+
+	require.Equal(t, []string{"getBalance", "getSlot", "getHealth"}, methods)
+	require.Equal(
+		t,
+		[]*JSONRPCResponse{
+			{Result: json.RawMessage(`{"context":{"slot":1},"value":10}`)},
+			{Result: json.RawMessage(`42`)},
+			{Error: &RPCError{Code: -32601, Message: "Method not found"}},
+		},
+		responses,
+	)
+}
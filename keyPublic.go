@@ -2,7 +2,10 @@ package solana
 
 import (
 	"crypto/ed25519"
+	"crypto/sha256"
+	"fmt"
 	"github.com/btcsuite/btcutil/base58"
+	"math/big"
 )
 
 type PublicKey struct {
@@ -16,3 +19,115 @@ func NewPublicKeyFromBase58String(publicKey string) PublicKey {
 func (p PublicKey) ToBase58() string {
 	return base58.Encode(p.PublicKey)
 }
+
+// NewPublicKeyFromBytes creates a PublicKey from the given 32 bytes.
+func NewPublicKeyFromBytes(publicKey [32]byte) PublicKey {
+	return PublicKey{PublicKey: publicKey[:]}
+}
+
+// ToBytes returns the PublicKey as a fixed size 32 byte array.
+func (p PublicKey) ToBytes() [32]byte {
+	var b [32]byte
+	copy(b[:], p.PublicKey)
+	return b
+}
+
+const (
+	// MaxSeeds is the maximum number of seeds that may be used to derive a program address
+	MaxSeeds = 16
+
+	// MaxSeedLength is the maximum length in bytes of a seed used to derive a program address
+	MaxSeedLength = 32
+)
+
+// CreateProgramAddress derives a program address from the given seeds and programID.
+// An error is returned if the derived address lies on the ed25519 curve, in which
+// case the seeds are not valid for a program derived address.
+// See: https://docs.solana.com/developing/programming-model/calling-between-programs#program-derived-addresses
+func CreateProgramAddress(seeds [][]byte, programID PublicKey) (PublicKey, error) {
+	if len(seeds) > MaxSeeds {
+		return PublicKey{}, fmt.Errorf("more than %d seeds given: %w", MaxSeeds, ErrInvalidSeeds)
+	}
+
+	h := sha256.New()
+	for _, seed := range seeds {
+		if len(seed) > MaxSeedLength {
+			return PublicKey{}, fmt.Errorf("seed longer than %d bytes: %w", MaxSeedLength, ErrInvalidSeeds)
+		}
+		h.Write(seed)
+	}
+	h.Write(programID.PublicKey)
+	h.Write([]byte("ProgramDerivedAddress"))
+
+	var address [32]byte
+	copy(address[:], h.Sum(nil))
+	if isOnCurve(address) {
+		return PublicKey{}, fmt.Errorf("derived address on curve: %w", ErrInvalidSeeds)
+	}
+
+	return NewPublicKeyFromBytes(address), nil
+}
+
+// FindProgramAddress finds a valid program address and its bump seed for the given
+// seeds and programID. The bump seed is appended to the given seeds and searched
+// downwards from 255 until an address which lies off the ed25519 curve is found.
+func FindProgramAddress(seeds [][]byte, programID PublicKey) (PublicKey, uint8, error) {
+	for bump := 255; bump >= 0; bump-- {
+		address, err := CreateProgramAddress(
+			append(seeds[:len(seeds):len(seeds)], []byte{uint8(bump)}),
+			programID,
+		)
+		if err == nil {
+			return address, uint8(bump), nil
+		}
+		if len(seeds) >= MaxSeeds {
+			return PublicKey{}, 0, err
+		}
+	}
+	return PublicKey{}, 0, fmt.Errorf("no viable bump seed found: %w", ErrInvalidSeeds)
+}
+
+var (
+	// curveP is the field prime 2^255 - 19 of curve25519
+	curveP, _ = new(big.Int).SetString("57896044618658097711785492504343953926634992332820282019728792003956564819949", 10)
+
+	// curveD is the edwards25519 curve constant -121665/121666 mod curveP
+	curveD, _ = new(big.Int).SetString("37095705934669439343138083508754565189542113879843219016388785533085940283555", 10)
+)
+
+// isOnCurve determines if the given compressed edwards y-coordinate point
+// can be decompressed into a point on the ed25519 curve.
+// The point is on the curve if x^2 = (y^2 - 1) / (d*y^2 + 1) has a solution, which is
+// the case if the right hand side is zero or a quadratic residue mod curveP.
+func isOnCurve(point [32]byte) bool {
+	// the y-coordinate is the little endian point with the x sign bit cleared
+	yBytes := make([]byte, 32)
+	for i := range point {
+		yBytes[31-i] = point[i]
+	}
+	yBytes[0] &= 0x7f
+	y := new(big.Int).SetBytes(yBytes)
+	y.Mod(y, curveP)
+
+	ySquared := new(big.Int).Mul(y, y)
+	ySquared.Mod(ySquared, curveP)
+
+	// u = y^2 - 1
+	u := new(big.Int).Sub(ySquared, big.NewInt(1))
+	u.Mod(u, curveP)
+	if u.Sign() == 0 {
+		return true
+	}
+
+	// v = d*y^2 + 1
+	v := new(big.Int).Mul(curveD, ySquared)
+	v.Add(v, big.NewInt(1))
+	v.Mod(v, curveP)
+
+	// x^2 = u / v
+	xSquared := new(big.Int).ModInverse(v, curveP)
+	xSquared.Mul(xSquared, u)
+	xSquared.Mod(xSquared, curveP)
+
+	return big.Jacobi(xSquared, curveP) == 1
+}
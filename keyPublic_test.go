@@ -0,0 +1,88 @@
+package solana
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCreateProgramAddress(t *testing.T) {
+	programID := NewPublicKeyFromBase58String("BPFLoader1111111111111111111111111111111111")
+	seedPubKey := NewPublicKeyFromBase58String("SeedPubey1111111111111111111111111111111111")
+
+	tests := []struct {
+		name    string
+		seeds   [][]byte
+		want    PublicKey
+		wantErr bool
+	}{
+		{
+			name:  "empty seed and bump",
+			seeds: [][]byte{[]byte(""), {1}},
+			want:  NewPublicKeyFromBase58String("3gF2KMe9KiC6FNVBmfg9i267aMPvK37FewCip4eGBFcT"),
+		},
+		{
+			name:  "utf8 seed",
+			seeds: [][]byte{[]byte("☉")},
+			want:  NewPublicKeyFromBase58String("7ytmC1nT1xY4RfxCV2ZgyA7UakC93do5ZdyhdF3EtPj7"),
+		},
+		{
+			name:  "multiple seeds",
+			seeds: [][]byte{[]byte("Talking"), []byte("Squirrels")},
+			want:  NewPublicKeyFromBase58String("HwRVBufQ4haG5XSgpspwKtNd3PC9GM9m1196uJW36vds"),
+		},
+		{
+			name:  "public key seed",
+			seeds: [][]byte{seedPubKey.PublicKey},
+			want:  NewPublicKeyFromBase58String("GUs5qLUfsEHkcMB9T38vjr18ypEhRuNWiePW2LoK4E3K"),
+		},
+		{
+			name:    "seed too long",
+			seeds:   [][]byte{make([]byte, MaxSeedLength+1)},
+			wantErr: true,
+		},
+		{
+			name:    "too many seeds",
+			seeds:   make([][]byte, MaxSeeds+1),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CreateProgramAddress(tt.seeds, programID)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidSeeds)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want.ToBase58(), got.ToBase58())
+		})
+	}
+}
+
+func TestFindProgramAddress(t *testing.T) {
+	programID := NewPublicKeyFromBase58String("BPFLoader1111111111111111111111111111111111")
+	seeds := [][]byte{[]byte("some seed")}
+
+	address, bump, err := FindProgramAddress(seeds, programID)
+	require.Nil(t, err)
+
+	// address should be recreated with the bump seed appended
+	created, err := CreateProgramAddress([][]byte{seeds[0], {bump}}, programID)
+	require.Nil(t, err)
+	require.Equal(t, created, address)
+
+	// given seeds should not have been modified
+	require.Len(t, seeds, 1)
+}
+
+func TestIsOnCurve(t *testing.T) {
+	// ed25519 public keys always lie on the curve
+	for i := 0; i < 100; i++ {
+		require.True(t, isOnCurve(MustNewRandomKeypair().PublicKey.ToBytes()))
+	}
+
+	// program derived addresses never do
+	address, _, err := FindProgramAddress([][]byte{[]byte("seed")}, NewPublicKeyFromBase58String("11111111111111111111111111111111"))
+	require.Nil(t, err)
+	require.False(t, isOnCurve(address.ToBytes()))
+}
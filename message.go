@@ -0,0 +1,180 @@
+package solana
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/BRBussy/solgo/internal/pkg/encoding"
+	"github.com/btcsuite/btcutil/base58"
+)
+
+// MessageHeader describes the account addresses of a Message.
+// The addresses requiring signatures are listed first in Message.AccountKeys,
+// followed by those that do not. Within each of these groups the
+// read-write addresses are listed before the read-only addresses.
+type MessageHeader struct {
+	// NumRequiredSignatures is the no. of signatures required for the Message
+	// to be valid. These are provided by the first NumRequiredSignatures
+	// entries of Message.AccountKeys.
+	NumRequiredSignatures uint8
+
+	// NumReadonlySignedAccounts is the no. of the signed accounts that are read-only
+	NumReadonlySignedAccounts uint8
+
+	// NumReadonlyUnsignedAccounts is the no. of the unsigned accounts that are read-only
+	NumReadonlyUnsignedAccounts uint8
+}
+
+// CompiledInstruction is an Instruction in which the program and accounts
+// are referenced by their index in Message.AccountKeys.
+type CompiledInstruction struct {
+	// ProgramIDIndex is the index of the program ID in Message.AccountKeys
+	ProgramIDIndex uint8
+
+	// AccountIndices are the indices in Message.AccountKeys of the
+	// accounts to be passed to the program
+	AccountIndices []uint8
+
+	// Data is the data to be input to the program
+	Data []byte
+}
+
+// Message is the compiled content of a Transaction that is signed.
+// Learn more at: https://docs.solana.com/developing/programming-model/transactions#message-format
+type Message struct {
+	// Header describes the AccountKeys
+	Header MessageHeader
+
+	// AccountKeys are all of the accounts used by the Instructions
+	AccountKeys []PublicKey
+
+	// RecentBlockHash is a base58 encoded recent block hash
+	RecentBlockHash string
+
+	// Instructions are the instructions to be executed
+	Instructions []CompiledInstruction
+}
+
+// ToBytes serialises the Message into the Solana wire format
+func (m Message) ToBytes() ([]byte, error) {
+	// decode recent block hash
+	recentBlockHash := base58.Decode(m.RecentBlockHash)
+	if len(recentBlockHash) != 32 {
+		return nil, fmt.Errorf("'%s': %w", m.RecentBlockHash, ErrInvalidBlockHash)
+	}
+	if len(m.AccountKeys) > 0xff {
+		return nil, fmt.Errorf("%d account keys: %w", len(m.AccountKeys), ErrTooManyAccounts)
+	}
+
+	buf := new(bytes.Buffer)
+
+	// [1.] Header
+	buf.Write([]byte{
+		m.Header.NumRequiredSignatures,
+		m.Header.NumReadonlySignedAccounts,
+		m.Header.NumReadonlyUnsignedAccounts,
+	})
+
+	// [2.] Compact array of account addresses
+	buf.Write(encoding.EncodeCompactU16(uint16(len(m.AccountKeys))))
+	for _, accountKey := range m.AccountKeys {
+		b := accountKey.ToBytes()
+		buf.Write(b[:])
+	}
+
+	// [3.] Recent blockhash
+	buf.Write(recentBlockHash)
+
+	// [4.] Compact array of instructions
+	buf.Write(encoding.EncodeCompactU16(uint16(len(m.Instructions))))
+	for _, instruction := range m.Instructions {
+		if len(instruction.Data) > 0xffff {
+			return nil, fmt.Errorf("instruction data of %d bytes: %w", len(instruction.Data), ErrTransactionTooLarge)
+		}
+		buf.WriteByte(instruction.ProgramIDIndex)
+		buf.Write(encoding.EncodeCompactU16(uint16(len(instruction.AccountIndices))))
+		buf.Write(instruction.AccountIndices)
+		buf.Write(encoding.EncodeCompactU16(uint16(len(instruction.Data))))
+		buf.Write(instruction.Data)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// compileMessage compiles the given instructions into a Message with the feePayer
+// as the first account. Accounts are de-duplicated and ordered as described by
+// MessageHeader, preserving the order in which they are first referenced.
+func compileMessage(feePayer PublicKey, recentBlockHash string, instructions Instructions) (*Message, error) {
+	// collect account metas, merging the permissions of duplicate accounts
+	accountMetas := []InstructionAccountMeta{{PubKey: feePayer, IsSigner: true, IsWritable: true}}
+	metaIdx := map[string]int{feePayer.ToBase58(): 0}
+	addAccountMeta := func(meta InstructionAccountMeta) {
+		key := meta.PubKey.ToBase58()
+		if idx, found := metaIdx[key]; found {
+			accountMetas[idx].IsSigner = accountMetas[idx].IsSigner || meta.IsSigner
+			accountMetas[idx].IsWritable = accountMetas[idx].IsWritable || meta.IsWritable
+			return
+		}
+		metaIdx[key] = len(accountMetas)
+		accountMetas = append(accountMetas, meta)
+	}
+	for _, instruction := range instructions {
+		for _, meta := range instruction.InstructionAccountMeta {
+			addAccountMeta(meta)
+		}
+		addAccountMeta(InstructionAccountMeta{PubKey: instruction.ProgramIDPubKey})
+	}
+	if len(accountMetas) > 0xff {
+		return nil, fmt.Errorf("%d accounts: %w", len(accountMetas), ErrTooManyAccounts)
+	}
+
+	// order accounts and build header
+	var message Message
+	message.RecentBlockHash = recentBlockHash
+	for _, group := range []struct {
+		isSigner   bool
+		isWritable bool
+	}{
+		{isSigner: true, isWritable: true},
+		{isSigner: true, isWritable: false},
+		{isSigner: false, isWritable: true},
+		{isSigner: false, isWritable: false},
+	} {
+		for _, meta := range accountMetas {
+			if meta.IsSigner != group.isSigner || meta.IsWritable != group.isWritable {
+				continue
+			}
+			message.AccountKeys = append(message.AccountKeys, meta.PubKey)
+			switch {
+			case meta.IsSigner && meta.IsWritable:
+				message.Header.NumRequiredSignatures++
+			case meta.IsSigner:
+				message.Header.NumRequiredSignatures++
+				message.Header.NumReadonlySignedAccounts++
+			case !meta.IsWritable:
+				message.Header.NumReadonlyUnsignedAccounts++
+			}
+		}
+	}
+
+	// compile instructions
+	accountKeyIdx := make(map[string]uint8)
+	for i, accountKey := range message.AccountKeys {
+		accountKeyIdx[accountKey.ToBase58()] = uint8(i)
+	}
+	for _, instruction := range instructions {
+		compiledInstruction := CompiledInstruction{
+			ProgramIDIndex: accountKeyIdx[instruction.ProgramIDPubKey.ToBase58()],
+			AccountIndices: make([]uint8, 0, len(instruction.InstructionAccountMeta)),
+			Data:           instruction.Data,
+		}
+		for _, meta := range instruction.InstructionAccountMeta {
+			compiledInstruction.AccountIndices = append(
+				compiledInstruction.AccountIndices,
+				accountKeyIdx[meta.PubKey.ToBase58()],
+			)
+		}
+		message.Instructions = append(message.Instructions, compiledInstruction)
+	}
+
+	return &message, nil
+}
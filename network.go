@@ -46,3 +46,35 @@ func (n Network) MustToRPCURL() string {
 	}
 	return rpcURL
 }
+
+// ToWebSocketURL returns the websocket pubsub url of the relevant public
+// Solana foundation nodes for MainnetBeta, Testnet and Devnet.
+// Returns an error if Network n is invalid.
+func (n Network) ToWebSocketURL() (string, error) {
+	switch n {
+	case MainnetBeta:
+		return "wss://api.mainnet-beta.solana.com", nil
+
+	case Testnet:
+		return "wss://api.testnet.solana.com", nil
+
+	case Devnet:
+		return "wss://api.devnet.solana.com", nil
+
+	case LocalTestnet:
+		return "ws://localhost:8900", nil
+	}
+
+	return "", fmt.Errorf("%s: %w", n, ErrUnexpectedNetwork)
+}
+
+// MustToWebSocketURL returns the websocket pubsub url of the relevant public
+// Solana foundation nodes for MainnetBeta, Testnet and Devnet.
+// Panics if Network n is invalid.
+func (n Network) MustToWebSocketURL() string {
+	webSocketURL, err := n.ToWebSocketURL()
+	if err != nil {
+		panic(err)
+	}
+	return webSocketURL
+}
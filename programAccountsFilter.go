@@ -0,0 +1,66 @@
+package solana
+
+import (
+	"encoding/base64"
+	"github.com/btcsuite/btcutil/base58"
+)
+
+// maxBase58MemcmpBytes is the maximum number of bytes that may be
+// compared by a memcmp filter given base58 encoded bytes
+const maxBase58MemcmpBytes = 128
+
+// ProgramAccountsFilter filters the accounts returned by GetProgramAccounts.
+// Exactly one of Memcmp or DataSize should be set.
+// Construct with NewMemcmpFilter or NewDataSizeFilter.
+type ProgramAccountsFilter struct {
+	// Memcmp filters accounts with data that matches the given bytes at an offset
+	Memcmp *MemcmpFilter `json:"memcmp,omitempty"`
+
+	// DataSize filters accounts with data of the given length
+	DataSize *uint64 `json:"dataSize,omitempty"`
+}
+
+// MemcmpFilter compares the given Bytes to the account data at Offset
+type MemcmpFilter struct {
+	// Offset is the offset into the account data at which to start the comparison
+	Offset uint64 `json:"offset"`
+
+	// Bytes are the encoded bytes to compare
+	Bytes string `json:"bytes"`
+
+	// Encoding is the Encoding of Bytes, either Base58Encoding or Base64Encoding
+	Encoding Encoding `json:"encoding"`
+}
+
+// NewMemcmpFilter returns a ProgramAccountsFilter matching accounts with data equal to the given
+// bytes from the given offset. The bytes are base58 encoded, unless they are too long for
+// base58 to be used, in which case they are base64 encoded.
+func NewMemcmpFilter(offset uint64, bytes []byte) ProgramAccountsFilter {
+	if len(bytes) > maxBase58MemcmpBytes {
+		return NewBase64MemcmpFilter(offset, bytes)
+	}
+	return ProgramAccountsFilter{
+		Memcmp: &MemcmpFilter{
+			Offset:   offset,
+			Bytes:    base58.Encode(bytes),
+			Encoding: Base58Encoding,
+		},
+	}
+}
+
+// NewBase64MemcmpFilter returns a ProgramAccountsFilter matching accounts with data
+// equal to the given bytes from the given offset, with the bytes base64 encoded.
+func NewBase64MemcmpFilter(offset uint64, bytes []byte) ProgramAccountsFilter {
+	return ProgramAccountsFilter{
+		Memcmp: &MemcmpFilter{
+			Offset:   offset,
+			Bytes:    base64.StdEncoding.EncodeToString(bytes),
+			Encoding: Base64Encoding,
+		},
+	}
+}
+
+// NewDataSizeFilter returns a ProgramAccountsFilter matching accounts with data of the given length
+func NewDataSizeFilter(dataSize uint64) ProgramAccountsFilter {
+	return ProgramAccountsFilter{DataSize: &dataSize}
+}
@@ -0,0 +1,44 @@
+package solana
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestProgramAccountsFilter_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter ProgramAccountsFilter
+		want   string
+	}{
+		{
+			name:   "data size",
+			filter: NewDataSizeFilter(165),
+			want:   `{"dataSize": 165}`,
+		},
+		{
+			name:   "base58 memcmp",
+			filter: NewMemcmpFilter(32, []byte{1, 2, 3}),
+			want:   `{"memcmp": {"offset": 32, "bytes": "Ldp", "encoding": "base58"}}`,
+		},
+		{
+			name:   "bytes too long for base58 memcmp",
+			filter: NewMemcmpFilter(0, make([]byte, 129)),
+			want:   `{"memcmp": {"offset": 0, "bytes": "` + base64.StdEncoding.EncodeToString(make([]byte, 129)) + `", "encoding": "base64"}}`,
+		},
+		{
+			name:   "base64 memcmp",
+			filter: NewBase64MemcmpFilter(8, []byte{1, 2, 3}),
+			want:   `{"memcmp": {"offset": 8, "bytes": "AQID", "encoding": "base64"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.filter)
+			require.Nil(t, err)
+			require.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
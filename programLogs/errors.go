@@ -0,0 +1,8 @@
+package programLogs
+
+import "errors"
+
+var (
+	ErrUnexpectedLog = errors.New("unexpected log")
+	ErrInvalidData   = errors.New("invalid program data")
+)
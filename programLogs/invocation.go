@@ -0,0 +1,108 @@
+// Package programLogs parses the log messages of a transaction into the tree of
+// program invocations that produced them.
+// See the runtime log formats here:
+// https://github.com/solana-labs/solana/blob/v1.16.0/program-runtime/src/stable_log.rs
+package programLogs
+
+import "github.com/BRBussy/solgo"
+
+// Logs are the parsed log messages of a transaction
+type Logs struct {
+	// Invocations are the invocations made by each of the transaction's instructions, in order
+	Invocations []*Invocation
+
+	// Data are the "Program data:" payloads emitted by every invocation, in log order
+	Data []Data
+}
+
+// Data is a "Program data:" payload emitted by a program
+type Data struct {
+	// ProgramID is the program that emitted the data
+	ProgramID solana.PublicKey
+
+	// Fields are the decoded fields given in a single call to sol_log_data
+	Fields [][]byte
+}
+
+// Invocation is the invocation of a program by a transaction instruction
+// or by another program through a cross program invocation.
+type Invocation struct {
+	// ProgramID is the program invoked
+	ProgramID solana.PublicKey
+
+	// Depth is the invocation stack depth, 1 for programs
+	// invoked directly by a transaction instruction
+	Depth int
+
+	// Logs are the messages logged by the program with "Program log:",
+	// and any other messages logged by the runtime during the invocation
+	Logs []string
+
+	// Data are the decoded "Program data:" payloads emitted by the program.
+	// Each payload holds the fields given in a single call to sol_log_data.
+	Data [][][]byte
+
+	// ReturnData is the data returned by the program, if any
+	ReturnData []byte
+
+	// ComputeUnitsConsumed is the number of compute units consumed by the
+	// invocation, including those consumed by the programs that it invoked
+	ComputeUnitsConsumed uint64
+
+	// ComputeUnitsLimit is the number of compute units that
+	// were available to the invocation when it was made
+	ComputeUnitsLimit uint64
+
+	// Success is true if the invocation completed successfully
+	Success bool
+
+	// Err is the error with which the invocation failed, if it did
+	Err string
+
+	// Truncated is true if the log messages were truncated during the invocation,
+	// in which case the invocation may be incomplete
+	Truncated bool
+
+	// Invocations are the programs invoked by this program, in order
+	Invocations []*Invocation
+}
+
+// Complete returns true if the invocation succeeded or failed
+func (i *Invocation) Complete() bool {
+	return i.Success || i.Err != ""
+}
+
+// Walk calls fn for this invocation and every invocation
+// made during it, in the order in which they were made
+func (i *Invocation) Walk(fn func(invocation *Invocation)) {
+	fn(i)
+	for _, invocation := range i.Invocations {
+		invocation.Walk(fn)
+	}
+}
+
+// ComputeUnitsConsumedExclusive returns the number of compute units consumed
+// by the invocation excluding those consumed by the programs that it invoked
+func (i *Invocation) ComputeUnitsConsumedExclusive() uint64 {
+	consumed := i.ComputeUnitsConsumed
+	for _, invocation := range i.Invocations {
+		if invocation.ComputeUnitsConsumed > consumed {
+			return 0
+		}
+		consumed -= invocation.ComputeUnitsConsumed
+	}
+	return consumed
+}
+
+// ComputeUnitsByProgram returns the number of compute units consumed by each
+// program in the given invocations, excluding those consumed by the programs that
+// they invoked, keyed by base58 program ID.
+func ComputeUnitsByProgram(invocations []*Invocation) map[string]uint64 {
+	computeUnits := make(map[string]uint64)
+	for _, invocation := range invocations {
+		invocation.Walk(func(invocation *Invocation) {
+			computeUnits[invocation.ProgramID.ToBase58()] += invocation.ComputeUnitsConsumedExclusive()
+		})
+	}
+	return computeUnits
+}
@@ -0,0 +1,164 @@
+package programLogs
+
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/BRBussy/solgo"
+	"strconv"
+	"strings"
+)
+
+const (
+	programPrefix       = "Program "
+	programLogPrefix    = "Program log: "
+	programDataPrefix   = "Program data: "
+	programReturnPrefix = "Program return: "
+	logTruncated        = "Log truncated"
+)
+
+// Parse parses the given transaction log messages into the invocations made by each of the
+// transaction's instructions, in order, and the data emitted by every invocation, in log order.
+func Parse(logMessages []string) (*Logs, error) {
+	var (
+		logs  = new(Logs)
+		stack []*Invocation
+	)
+
+	for i, logMessage := range logMessages {
+		// current is the invocation on the top of the stack, if any
+		var current *Invocation
+		if len(stack) > 0 {
+			current = stack[len(stack)-1]
+		}
+
+		switch {
+		case logMessage == logTruncated:
+			for _, invocation := range stack {
+				invocation.Truncated = true
+			}
+
+		case strings.HasPrefix(logMessage, programLogPrefix):
+			if current == nil {
+				return nil, fmt.Errorf("log message %d outside of invocation: %w", i, ErrUnexpectedLog)
+			}
+			current.Logs = append(current.Logs, strings.TrimPrefix(logMessage, programLogPrefix))
+
+		case strings.HasPrefix(logMessage, programDataPrefix):
+			if current == nil {
+				return nil, fmt.Errorf("log message %d outside of invocation: %w", i, ErrUnexpectedLog)
+			}
+			data, err := decodeData(strings.TrimPrefix(logMessage, programDataPrefix))
+			if err != nil {
+				return nil, fmt.Errorf("error decoding log message %d: %w", i, err)
+			}
+			current.Data = append(current.Data, data)
+			logs.Data = append(logs.Data, Data{ProgramID: current.ProgramID, Fields: data})
+
+		case strings.HasPrefix(logMessage, programReturnPrefix):
+			if current == nil {
+				return nil, fmt.Errorf("log message %d outside of invocation: %w", i, ErrUnexpectedLog)
+			}
+			fields := strings.Fields(strings.TrimPrefix(logMessage, programReturnPrefix))
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("return data in log message %d: %w", i, ErrUnexpectedLog)
+			}
+			returnData, err := base64.StdEncoding.DecodeString(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("error decoding return data in log message %d: %w", i, ErrInvalidData)
+			}
+			current.ReturnData = returnData
+
+		case strings.HasPrefix(logMessage, programPrefix):
+			fields := strings.Fields(strings.TrimPrefix(logMessage, programPrefix))
+			if len(fields) < 2 {
+				if current == nil {
+					return nil, fmt.Errorf("log message %d outside of invocation: %w", i, ErrUnexpectedLog)
+				}
+				current.Logs = append(current.Logs, logMessage)
+				continue
+			}
+			programID := fields[0]
+
+			switch {
+			// Program <id> invoke [<depth>]
+			case fields[1] == "invoke" && len(fields) == 3:
+				depth, err := strconv.Atoi(strings.Trim(fields[2], "[]"))
+				if err != nil || depth != len(stack)+1 {
+					return nil, fmt.Errorf("invoke depth in log message %d: %w", i, ErrUnexpectedLog)
+				}
+				invocation := &Invocation{
+					ProgramID: solana.NewPublicKeyFromBase58String(programID),
+					Depth:     depth,
+				}
+				if current == nil {
+					logs.Invocations = append(logs.Invocations, invocation)
+				} else {
+					current.Invocations = append(current.Invocations, invocation)
+				}
+				stack = append(stack, invocation)
+
+			// Program <id> consumed <consumed> of <limit> compute units
+			case fields[1] == "consumed" && len(fields) == 7:
+				if current == nil || current.ProgramID.ToBase58() != programID {
+					return nil, fmt.Errorf("compute units of unexpected program in log message %d: %w", i, ErrUnexpectedLog)
+				}
+				consumed, err := strconv.ParseUint(fields[2], 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("compute units consumed in log message %d: %w", i, ErrUnexpectedLog)
+				}
+				limit, err := strconv.ParseUint(fields[4], 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("compute unit limit in log message %d: %w", i, ErrUnexpectedLog)
+				}
+				current.ComputeUnitsConsumed = consumed
+				current.ComputeUnitsLimit = limit
+
+			// Program <id> success
+			case fields[1] == "success" && len(fields) == 2:
+				if current == nil || current.ProgramID.ToBase58() != programID {
+					return nil, fmt.Errorf("success of unexpected program in log message %d: %w", i, ErrUnexpectedLog)
+				}
+				current.Success = true
+				stack = stack[:len(stack)-1]
+
+			// Program <id> failed: <err>
+			case fields[1] == "failed:":
+				if current == nil || current.ProgramID.ToBase58() != programID {
+					return nil, fmt.Errorf("failure of unexpected program in log message %d: %w", i, ErrUnexpectedLog)
+				}
+				current.Err = strings.TrimPrefix(logMessage, programPrefix+programID+" failed: ")
+				stack = stack[:len(stack)-1]
+
+			// any other message, e.g. Program consumption: <n> units remaining
+			default:
+				if current == nil {
+					return nil, fmt.Errorf("log message %d outside of invocation: %w", i, ErrUnexpectedLog)
+				}
+				current.Logs = append(current.Logs, logMessage)
+			}
+
+		default:
+			// messages logged by the runtime or builtin programs, e.g. system program transfer failures
+			if current == nil {
+				return nil, fmt.Errorf("log message %d outside of invocation: %w", i, ErrUnexpectedLog)
+			}
+			current.Logs = append(current.Logs, logMessage)
+		}
+	}
+
+	return logs, nil
+}
+
+// decodeData decodes the space separated base64 fields of a "Program data:" log message
+func decodeData(message string) ([][]byte, error) {
+	fields := strings.Fields(message)
+	data := make([][]byte, 0, len(fields))
+	for _, field := range fields {
+		decoded, err := base64.StdEncoding.DecodeString(field)
+		if err != nil {
+			return nil, ErrInvalidData
+		}
+		data = append(data, decoded)
+	}
+	return data, nil
+}
@@ -0,0 +1,173 @@
+package programLogs
+
+import (
+	"github.com/BRBussy/solgo"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	programA := solana.MustNewRandomKeypair().PublicKey
+	programB := solana.MustNewRandomKeypair().PublicKey
+	a := programA.ToBase58()
+	b := programB.ToBase58()
+
+	tests := []struct {
+		name        string
+		logMessages []string
+		want        []*Invocation
+		wantData    []Data
+		wantErr     error
+	}{
+		{
+			name: "nested invocations",
+			logMessages: []string{
+				"Program " + a + " invoke [1]",
+				"Program log: Instruction: Increment",
+				"Program " + b + " invoke [2]",
+				"Program log: inner",
+				"Program data: AQI= AwQ=",
+				"Program return: " + b + " BQY=",
+				"Program " + b + " consumed 1000 of 190000 compute units",
+				"Program " + b + " success",
+				"Program data: Bw==",
+				"Program " + a + " consumed 15000 of 200000 compute units",
+				"Program " + a + " success",
+				"Program " + b + " invoke [1]",
+				"Program " + b + " consumed 500 of 185000 compute units",
+				"Program " + b + " failed: custom program error: 0x1770",
+			},
+			want: []*Invocation{
+				{
+					ProgramID:            programA,
+					Depth:                1,
+					Logs:                 []string{"Instruction: Increment"},
+					Data:                 [][][]byte{{{7}}},
+					ComputeUnitsConsumed: 15000,
+					ComputeUnitsLimit:    200000,
+					Success:              true,
+					Invocations: []*Invocation{
+						{
+							ProgramID:            programB,
+							Depth:                2,
+							Logs:                 []string{"inner"},
+							Data:                 [][][]byte{{{1, 2}, {3, 4}}},
+							ReturnData:           []byte{5, 6},
+							ComputeUnitsConsumed: 1000,
+							ComputeUnitsLimit:    190000,
+							Success:              true,
+						},
+					},
+				},
+				{
+					ProgramID:            programB,
+					Depth:                1,
+					ComputeUnitsConsumed: 500,
+					ComputeUnitsLimit:    185000,
+					Err:                  "custom program error: 0x1770",
+				},
+			},
+			wantData: []Data{
+				{ProgramID: programB, Fields: [][]byte{{1, 2}, {3, 4}}},
+				{ProgramID: programA, Fields: [][]byte{{7}}},
+			},
+		},
+		{
+			name: "truncated logs",
+			logMessages: []string{
+				"Program " + a + " invoke [1]",
+				"Log truncated",
+			},
+			want: []*Invocation{
+				{
+					ProgramID: programA,
+					Depth:     1,
+					Truncated: true,
+				},
+			},
+		},
+		{
+			name: "runtime messages",
+			logMessages: []string{
+				"Program 11111111111111111111111111111111 invoke [1]",
+				"Transfer: insufficient lamports 0, need 100",
+				"Program 11111111111111111111111111111111 failed: custom program error: 0x1",
+			},
+			want: []*Invocation{
+				{
+					ProgramID: solana.NewPublicKeyFromBase58String("11111111111111111111111111111111"),
+					Depth:     1,
+					Logs:      []string{"Transfer: insufficient lamports 0, need 100"},
+					Err:       "custom program error: 0x1",
+				},
+			},
+		},
+		{
+			name: "unexpected invoke depth",
+			logMessages: []string{
+				"Program " + a + " invoke [2]",
+			},
+			wantErr: ErrUnexpectedLog,
+		},
+		{
+			name: "success of unexpected program",
+			logMessages: []string{
+				"Program " + a + " invoke [1]",
+				"Program " + b + " success",
+			},
+			wantErr: ErrUnexpectedLog,
+		},
+		{
+			name: "log outside of invocation",
+			logMessages: []string{
+				"Program log: hello",
+			},
+			wantErr: ErrUnexpectedLog,
+		},
+		{
+			name: "invalid program data",
+			logMessages: []string{
+				"Program " + a + " invoke [1]",
+				"Program data: !!!",
+			},
+			wantErr: ErrInvalidData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.logMessages)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got.Invocations)
+			require.Equal(t, tt.wantData, got.Data)
+		})
+	}
+}
+
+func TestComputeUnitsByProgram(t *testing.T) {
+	programA := solana.MustNewRandomKeypair().PublicKey
+	programB := solana.MustNewRandomKeypair().PublicKey
+
+	invocations := []*Invocation{
+		{
+			ProgramID:            programA,
+			ComputeUnitsConsumed: 15000,
+			Invocations: []*Invocation{
+				{ProgramID: programB, ComputeUnitsConsumed: 1000},
+				{ProgramID: programB, ComputeUnitsConsumed: 2000},
+			},
+		},
+		{ProgramID: programB, ComputeUnitsConsumed: 500},
+	}
+	require.Equal(
+		t,
+		map[string]uint64{
+			programA.ToBase58(): 12000,
+			programB.ToBase58(): 3500,
+		},
+		ComputeUnitsByProgram(invocations),
+	)
+}
@@ -0,0 +1,1054 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/BRBussy/solgo/internal/pkg/jsonrpc"
+	"github.com/gorilla/websocket"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ensure WebSocketPubSubConnection implements PubSubConnection
+var _ PubSubConnection = &WebSocketPubSubConnection{}
+
+const (
+	// DefaultPubSubNotificationBufferSize is the default number of notifications
+	// buffered for each subscription of a WebSocketPubSubConnection
+	DefaultPubSubNotificationBufferSize = 100
+
+	// DefaultPubSubReconnectMinBackoff is the default delay before
+	// the first attempt to reconnect a WebSocketPubSubConnection
+	DefaultPubSubReconnectMinBackoff = 500 * time.Millisecond
+
+	// DefaultPubSubReconnectMaxBackoff is the default maximum delay
+	// between attempts to reconnect a WebSocketPubSubConnection
+	DefaultPubSubReconnectMaxBackoff = 30 * time.Second
+
+	// DefaultPubSubHeartbeatInterval is the default interval at which
+	// a WebSocketPubSubConnection pings the node
+	DefaultPubSubHeartbeatInterval = 30 * time.Second
+)
+
+// resubscribeTimeout is the time allowed for each subscription to be resubscribed after reconnecting
+const resubscribeTimeout = 10 * time.Second
+
+// WebSocketPubSubConnection is a json-rpc websocket implementation of the solana.PubSubConnection interface.
+//
+// Notifications are read from the websocket by a single goroutine, which never waits for a
+// slow consumer. If the notification buffer of a subscription is full when a notification
+// arrives then the subscription ends with ErrPubSubNotificationOverflow, so that the
+// notifications of all other subscriptions on the connection, and heartbeats, are not delayed.
+//
+// The node is pinged periodically to detect a dead websocket. If the websocket fails
+// then it is redialed with exponential backoff and all active subscriptions are
+// resubscribed, after which a GapNotification is delivered on each of them.
+type WebSocketPubSubConnection struct {
+	config *websocketPubSubConnectionConfig
+
+	// writeMu guards writes to conn
+	writeMu sync.Mutex
+
+	// mu guards the following, as well as the state of each Subscription
+	mu sync.Mutex
+
+	// conn is the current websocket, nil while reconnecting,
+	// and connected is closed once conn is set
+	conn      *websocket.Conn
+	connected chan struct{}
+
+	nextRequestID int
+	pending       map[int]*pendingPubSubRequest
+
+	// subscriptions are the subscriptions registered on conn, by subscription id
+	subscriptions map[uint64]*Subscription
+
+	// active are all subscriptions that have not ended, including
+	// those waiting to be resubscribed after a reconnect
+	active map[*Subscription]struct{}
+
+	closed chan struct{}
+	err    error
+}
+
+// pendingPubSubRequest is a request awaiting its response
+type pendingPubSubRequest struct {
+	// response receives the response to the request,
+	// or nil if the websocket failed before it was received
+	response chan *jsonrpc.RPCResponse
+
+	// subscription is set if the request is a subscribe request
+	subscription *Subscription
+}
+
+// websocketPubSubConnectionConfig is the configuration for a WebSocketPubSubConnection
+type websocketPubSubConnectionConfig struct {
+	endpoint               string
+	commitmentLevel        CommitmentLevel
+	notificationBufferSize int
+	reconnect              bool
+	reconnectMinBackoff    time.Duration
+	reconnectMaxBackoff    time.Duration
+	maxReconnectAttempts   int
+	heartbeatInterval      time.Duration
+}
+
+// WebSocketPubSubConnectionOption makes a change to the websocketPubSubConnectionConfig
+type WebSocketPubSubConnectionOption interface {
+	apply(*websocketPubSubConnectionConfig)
+}
+
+type websocketPubSubConnectionOptionFunc func(*websocketPubSubConnectionConfig)
+
+func (fn websocketPubSubConnectionOptionFunc) apply(cfg *websocketPubSubConnectionConfig) {
+	fn(cfg)
+}
+
+// WithPubSubEndpoint sets endpoint on the WebSocketPubSubConnection
+func WithPubSubEndpoint(e string) WebSocketPubSubConnectionOption {
+	return websocketPubSubConnectionOptionFunc(func(config *websocketPubSubConnectionConfig) {
+		config.endpoint = e
+	})
+}
+
+// WithPubSubCommitmentLevel sets CommitmentLevel on the WebSocketPubSubConnection
+func WithPubSubCommitmentLevel(c CommitmentLevel) WebSocketPubSubConnectionOption {
+	return websocketPubSubConnectionOptionFunc(func(config *websocketPubSubConnectionConfig) {
+		config.commitmentLevel = c
+	})
+}
+
+// WithPubSubNotificationBufferSize sets the number of notifications buffered for each subscription
+// of the WebSocketPubSubConnection. A subscription ends with ErrPubSubNotificationOverflow if its
+// buffer is full when a notification arrives.
+func WithPubSubNotificationBufferSize(n int) WebSocketPubSubConnectionOption {
+	return websocketPubSubConnectionOptionFunc(func(config *websocketPubSubConnectionConfig) {
+		config.notificationBufferSize = n
+	})
+}
+
+// WithPubSubReconnect sets whether the WebSocketPubSubConnection reconnects when the websocket fails.
+// If it does not then the connection is closed, ending all subscriptions.
+func WithPubSubReconnect(enabled bool) WebSocketPubSubConnectionOption {
+	return websocketPubSubConnectionOptionFunc(func(config *websocketPubSubConnectionConfig) {
+		config.reconnect = enabled
+	})
+}
+
+// WithPubSubReconnectBackoff sets the delay before the first attempt to reconnect the
+// WebSocketPubSubConnection, which doubles on each failed attempt up to the given maximum
+func WithPubSubReconnectBackoff(min, max time.Duration) WebSocketPubSubConnectionOption {
+	return websocketPubSubConnectionOptionFunc(func(config *websocketPubSubConnectionConfig) {
+		config.reconnectMinBackoff = min
+		config.reconnectMaxBackoff = max
+	})
+}
+
+// WithPubSubMaxReconnectAttempts sets the number of consecutive failed attempts to reconnect
+// after which the WebSocketPubSubConnection is closed. 0 attempts indefinitely.
+func WithPubSubMaxReconnectAttempts(n int) WebSocketPubSubConnectionOption {
+	return websocketPubSubConnectionOptionFunc(func(config *websocketPubSubConnectionConfig) {
+		config.maxReconnectAttempts = n
+	})
+}
+
+// WithPubSubHeartbeatInterval sets the interval at which the WebSocketPubSubConnection pings the node.
+// The websocket is considered dead if nothing is received from the node for two intervals.
+// An interval of 0 disables heartbeats.
+func WithPubSubHeartbeatInterval(d time.Duration) WebSocketPubSubConnectionOption {
+	return websocketPubSubConnectionOptionFunc(func(config *websocketPubSubConnectionConfig) {
+		config.heartbeatInterval = d
+	})
+}
+
+// NewWebSocketPubSubConnection dials and returns a new and configured WebSocketPubSubConnection.
+//
+// The default returned WebSocketPubSubConnection is configured with:
+//  - endpoint: wss://api.mainnet-beta.solana.com
+//  - commitmentLevel: ConfirmedCommitmentLevel
+//  - notificationBufferSize: DefaultPubSubNotificationBufferSize
+//  - reconnect: true
+//  - reconnectBackoff: DefaultPubSubReconnectMinBackoff to DefaultPubSubReconnectMaxBackoff
+//  - maxReconnectAttempts: 0, i.e. unlimited
+//  - heartbeatInterval: DefaultPubSubHeartbeatInterval
+//
+// The passed opts are used to override these default values and configure the
+// returned WebSocketPubSubConnection as desired.
+func NewWebSocketPubSubConnection(ctx context.Context, opts ...WebSocketPubSubConnectionOption) (*WebSocketPubSubConnection, error) {
+	// prepare default configuration
+	config := &websocketPubSubConnectionConfig{
+		endpoint:               MainnetBeta.MustToWebSocketURL(),
+		commitmentLevel:        ConfirmedCommitmentLevel,
+		notificationBufferSize: DefaultPubSubNotificationBufferSize,
+		reconnect:              true,
+		reconnectMinBackoff:    DefaultPubSubReconnectMinBackoff,
+		reconnectMaxBackoff:    DefaultPubSubReconnectMaxBackoff,
+		heartbeatInterval:      DefaultPubSubHeartbeatInterval,
+	}
+
+	// apply any provided options
+	for _, opt := range opts {
+		opt.apply(config)
+	}
+
+	// dial endpoint
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, config.endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error dialing %s: %w", config.endpoint, err)
+	}
+
+	c := &WebSocketPubSubConnection{
+		config:        config,
+		connected:     make(chan struct{}),
+		pending:       make(map[int]*pendingPubSubRequest),
+		subscriptions: make(map[uint64]*Subscription),
+		active:        make(map[*Subscription]struct{}),
+		closed:        make(chan struct{}),
+	}
+	c.connect(conn)
+	go c.run(conn)
+
+	return c, nil
+}
+
+func (c *WebSocketPubSubConnection) Commitment() CommitmentLevel {
+	return c.config.commitmentLevel
+}
+
+// Close closes the connection, ending all subscriptions with ErrPubSubConnectionClosed
+func (c *WebSocketPubSubConnection) Close() error {
+	if !c.closeWithError(ErrPubSubConnectionClosed) {
+		return nil
+	}
+	c.mu.Lock()
+	conn := c.conn
+	c.mu.Unlock()
+	if conn == nil {
+		return nil
+	}
+
+	// attempt a clean close before closing the underlying connection
+	_ = conn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+		time.Now().Add(time.Second),
+	)
+
+	return conn.Close()
+}
+
+// closeWithError marks the connection as closed and ends all subscriptions with the given error.
+// Returns false if the connection was already closed.
+func (c *WebSocketPubSubConnection) closeWithError(err error) bool {
+	c.mu.Lock()
+	select {
+	case <-c.closed:
+		c.mu.Unlock()
+		return false
+	default:
+	}
+	c.err = err
+	close(c.closed)
+	subscriptions := make([]*Subscription, 0, len(c.active))
+	for subscription := range c.active {
+		subscriptions = append(subscriptions, subscription)
+	}
+	c.mu.Unlock()
+
+	for _, subscription := range subscriptions {
+		subscription.end(err)
+	}
+
+	return true
+}
+
+// connect sets the given websocket as the current one.
+// Returns false if the connection has been closed.
+func (c *WebSocketPubSubConnection) connect(conn *websocket.Conn) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	select {
+	case <-c.closed:
+		return false
+	default:
+	}
+	c.conn = conn
+	close(c.connected)
+	return true
+}
+
+// disconnect clears the current websocket after it has failed, failing all pending requests
+// and marking all subscriptions to be resubscribed. Returns false if the connection has been closed.
+func (c *WebSocketPubSubConnection) disconnect() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	select {
+	case <-c.closed:
+		return false
+	default:
+	}
+	c.conn = nil
+	c.connected = make(chan struct{})
+	for id, pending := range c.pending {
+		delete(c.pending, id)
+		pending.response <- nil
+	}
+	for id, subscription := range c.subscriptions {
+		delete(c.subscriptions, id)
+		subscription.subscribed = false
+	}
+	return true
+}
+
+// run reads from the given websocket, reconnecting and resubscribing each time it fails,
+// until the connection is closed or can not be reconnected
+func (c *WebSocketPubSubConnection) run(conn *websocket.Conn) {
+	for {
+		err := c.read(conn)
+		_ = conn.Close()
+		disconnectedAt := time.Now()
+
+		if !c.config.reconnect {
+			c.closeWithError(err)
+			return
+		}
+		if !c.disconnect() {
+			return
+		}
+
+		// reconnect
+		var reconnectErr error
+		conn, reconnectErr = c.reconnect()
+		if reconnectErr != nil {
+			c.closeWithError(reconnectErr)
+			return
+		}
+
+		// resubscribe while reading from the new websocket
+		go c.resubscribe(GapNotification{
+			DisconnectedAt: disconnectedAt,
+			ReconnectedAt:  time.Now(),
+			Err:            err,
+		})
+	}
+}
+
+// reconnect redials the endpoint with exponential backoff until it succeeds, the
+// maximum number of attempts is reached, or the connection is closed
+func (c *WebSocketPubSubConnection) reconnect() (*websocket.Conn, error) {
+	// cancel dialing if the connection is closed
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-c.closed:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	var err error
+	for attempt := 0; c.config.maxReconnectAttempts == 0 || attempt < c.config.maxReconnectAttempts; attempt++ {
+		// wait before attempting
+		select {
+		case <-time.After(c.reconnectBackoff(attempt)):
+		case <-ctx.Done():
+			return nil, ErrPubSubConnectionClosed
+		}
+
+		// dial endpoint
+		var conn *websocket.Conn
+		conn, _, err = websocket.DefaultDialer.DialContext(ctx, c.config.endpoint, nil)
+		if err != nil {
+			continue
+		}
+		if !c.connect(conn) {
+			_ = conn.Close()
+			return nil, ErrPubSubConnectionClosed
+		}
+		return conn, nil
+	}
+
+	return nil, fmt.Errorf("error reconnecting after %d attempts: %w", c.config.maxReconnectAttempts, err)
+}
+
+// reconnectBackoff returns the delay before the given reconnect attempt. The delay doubles from the
+// minimum backoff with each attempt up to the maximum backoff, and is reduced by a random jitter
+// of up to half so that clients disconnected together do not reconnect together.
+func (c *WebSocketPubSubConnection) reconnectBackoff(attempt int) time.Duration {
+	delay := c.config.reconnectMinBackoff
+	for i := 0; i < attempt && delay < c.config.reconnectMaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > c.config.reconnectMaxBackoff {
+		delay = c.config.reconnectMaxBackoff
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay - time.Duration(rand.Int63n(int64(delay)/2+1))
+}
+
+// resubscribe resubscribes all active subscriptions that are not subscribed
+// on the current websocket, notifying each of the given gap
+func (c *WebSocketPubSubConnection) resubscribe(gap GapNotification) {
+	c.mu.Lock()
+	connected := c.connected
+	subscriptions := make([]*Subscription, 0, len(c.active))
+	for subscription := range c.active {
+		if !subscription.subscribed {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+	c.mu.Unlock()
+
+	for _, subscription := range subscriptions {
+		ctx, cancel := context.WithTimeout(context.Background(), resubscribeTimeout)
+		rpcResponse, err := c.call(ctx, subscription.method, subscription.params, subscription)
+		cancel()
+		if err != nil {
+			// remaining subscriptions are resubscribed on the next
+			// reconnect if the websocket has failed again
+			c.mu.Lock()
+			disconnected := c.connected != connected
+			c.mu.Unlock()
+			if disconnected {
+				return
+			}
+			subscription.end(fmt.Errorf("error resubscribing with %s: %w", subscription.method, err))
+			continue
+		}
+		if rpcResponse.Error != nil {
+			subscription.end(fmt.Errorf("error resubscribing with %s: %w", subscription.method, newRPCError(rpcResponse.Error)))
+			continue
+		}
+		subscription.notifyGap(gap)
+	}
+}
+
+// pubsubMessage is a message received on the websocket,
+// either a response to a request or a subscription notification
+type pubsubMessage struct {
+	ID     *int              `json:"id"`
+	Result json.RawMessage   `json:"result"`
+	Error  *jsonrpc.RPCError `json:"error"`
+	Method string            `json:"method"`
+	Params *struct {
+		Subscription uint64          `json:"subscription"`
+		Result       json.RawMessage `json:"result"`
+	} `json:"params"`
+}
+
+// read reads messages from the given websocket until it fails, returning the error with which it failed
+func (c *WebSocketPubSubConnection) read(conn *websocket.Conn) error {
+	// ping the node on each heartbeat, expecting to
+	// receive something within two heartbeat intervals
+	extendReadDeadline := func() {}
+	if interval := c.config.heartbeatInterval; interval > 0 {
+		extendReadDeadline = func() {
+			_ = conn.SetReadDeadline(time.Now().Add(2 * interval))
+		}
+		extendReadDeadline()
+		conn.SetPongHandler(func(string) error {
+			extendReadDeadline()
+			return nil
+		})
+
+		stop := make(chan struct{})
+		defer close(stop)
+		go func() {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-stop:
+					return
+				case <-ticker.C:
+					if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(interval)); err != nil {
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return fmt.Errorf("error reading from websocket: %w", err)
+		}
+		extendReadDeadline()
+
+		var message pubsubMessage
+		if err := json.Unmarshal(data, &message); err != nil {
+			// messages that are not json-rpc are ignored
+			continue
+		}
+		switch {
+		case message.ID != nil:
+			c.handleResponse(*message.ID, &jsonrpc.RPCResponse{
+				JSONRPC: "2.0",
+				Result:  message.Result,
+				Error:   message.Error,
+				ID:      *message.ID,
+			})
+
+		case message.Params != nil:
+			c.handleNotification(message.Params.Subscription, message.Params.Result)
+		}
+	}
+}
+
+// handleResponse passes the given response to the pending request with the given id.
+// If the request is a subscribe request then the subscription is registered before
+// the next message is read so that no notifications for it are missed.
+func (c *WebSocketPubSubConnection) handleResponse(id int, rpcResponse *jsonrpc.RPCResponse) {
+	c.mu.Lock()
+	pending, found := c.pending[id]
+	if !found {
+		c.mu.Unlock()
+		return
+	}
+	delete(c.pending, id)
+
+	// register subscription
+	unsubscribe := false
+	if subscription := pending.subscription; subscription != nil && rpcResponse.Error == nil {
+		var subscriptionID uint64
+		if err := json.Unmarshal(rpcResponse.Result, &subscriptionID); err == nil {
+			subscription.id = subscriptionID
+			subscription.subscribed = true
+			select {
+			case <-subscription.done:
+				// subscription ended while it was being made
+				unsubscribe = true
+			default:
+				c.subscriptions[subscriptionID] = subscription
+				c.active[subscription] = struct{}{}
+			}
+		}
+	}
+	c.mu.Unlock()
+
+	if unsubscribe {
+		pending.subscription.unsubscribeInBackground()
+	}
+	pending.response <- rpcResponse
+}
+
+// handleNotification parses and delivers a notification to the subscription with the given id
+func (c *WebSocketPubSubConnection) handleNotification(subscriptionID uint64, result json.RawMessage) {
+	c.mu.Lock()
+	subscription, found := c.subscriptions[subscriptionID]
+	c.mu.Unlock()
+	if !found {
+		return
+	}
+
+	// parse notification
+	notification, final, err := subscription.parse(result)
+	if err != nil {
+		if subscription.end(fmt.Errorf("error parsing %s notification: %w", subscription.method, err)) {
+			subscription.unsubscribeInBackground()
+		}
+		return
+	}
+
+	// deliver notification without blocking the reader, ending the subscription if its buffer is full
+	select {
+	case subscription.notifications <- notification:
+	case <-subscription.done:
+		return
+	default:
+		err := fmt.Errorf(
+			"%d %s notifications buffered: %w",
+			cap(subscription.notifications), subscription.method, ErrPubSubNotificationOverflow,
+		)
+		if subscription.end(err) {
+			subscription.unsubscribeInBackground()
+		}
+		return
+	}
+
+	// no further notifications are sent by the node after a final notification,
+	// and so the subscription is not resubscribed if the websocket fails
+	if final {
+		c.mu.Lock()
+		subscription.serverDone = true
+		delete(c.subscriptions, subscriptionID)
+		delete(c.active, subscription)
+		c.mu.Unlock()
+		close(subscription.notifications)
+	}
+}
+
+// write writes the given value to the given websocket as json
+func (c *WebSocketPubSubConnection) write(ctx context.Context, conn *websocket.Conn, v interface{}) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetWriteDeadline(deadline); err != nil {
+		return err
+	}
+	return conn.WriteJSON(v)
+}
+
+// call performs a json-rpc call on the websocket and waits for its response, waiting for the
+// websocket to be reconnected if necessary. If subscription is given then it is registered
+// on a successful response. Returns ErrPubSubDisconnected if the websocket fails before
+// the response is received.
+func (c *WebSocketPubSubConnection) call(ctx context.Context, method string, params []interface{}, subscription *Subscription) (*jsonrpc.RPCResponse, error) {
+	// wait for websocket
+	c.mu.Lock()
+	connected := c.connected
+	c.mu.Unlock()
+	select {
+	case <-connected:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-c.closed:
+		return nil, c.err
+	}
+
+	// register pending request
+	c.mu.Lock()
+	select {
+	case <-c.closed:
+		c.mu.Unlock()
+		return nil, c.err
+	default:
+	}
+	conn := c.conn
+	if conn == nil {
+		c.mu.Unlock()
+		return nil, ErrPubSubDisconnected
+	}
+	c.nextRequestID++
+	id := c.nextRequestID
+	pending := &pendingPubSubRequest{
+		response:     make(chan *jsonrpc.RPCResponse, 1),
+		subscription: subscription,
+	}
+	c.pending[id] = pending
+	c.mu.Unlock()
+
+	removePending := func() {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+	}
+
+	// send request
+	if err := c.write(ctx, conn, jsonrpc.RPCRequest{
+		Method:  method,
+		Params:  params,
+		ID:      id,
+		JSONRPC: "2.0",
+	}); err != nil {
+		removePending()
+		return nil, fmt.Errorf("error writing to websocket: %w", err)
+	}
+
+	// wait for response
+	select {
+	case rpcResponse := <-pending.response:
+		if rpcResponse == nil {
+			return nil, ErrPubSubDisconnected
+		}
+		return rpcResponse, nil
+	case <-ctx.Done():
+		// a pending subscribe request is left for its response
+		// so that the subscription can be unsubscribed from
+		if subscription == nil {
+			removePending()
+		}
+		return nil, ctx.Err()
+	case <-c.closed:
+		return nil, c.err
+	}
+}
+
+// subscribe makes a subscription with the given rpc methods and params.
+// The subscription lasts until it is unsubscribed, the given context is done,
+// or the connection is closed.
+func (c *WebSocketPubSubConnection) subscribe(
+	ctx context.Context,
+	method string,
+	unsubscribeMethod string,
+	params []interface{},
+	parse notificationParser,
+) (*Subscription, error) {
+	subscription := &Subscription{
+		connection:        c,
+		method:            method,
+		params:            params,
+		unsubscribeMethod: unsubscribeMethod,
+		parse:             parse,
+		notifications:     make(chan interface{}, c.config.notificationBufferSize),
+		gaps:              make(chan GapNotification, 1),
+		done:              make(chan struct{}),
+	}
+
+	// perform rpc call
+	rpcResponse, err := c.call(ctx, method, params, subscription)
+	if err != nil {
+		subscription.end(err)
+		return nil, fmt.Errorf("error performing %s json-rpc call: %w", method, err)
+	}
+	if rpcResponse.Error != nil {
+		err := newRPCError(rpcResponse.Error)
+		subscription.end(err)
+		return nil, fmt.Errorf("error set on rpc response: %w", err)
+	}
+	var subscriptionID uint64
+	if err := rpcResponse.GetObject(&subscriptionID); err != nil {
+		subscription.end(err)
+		return nil, fmt.Errorf("error parsing %s response: %w", method, err)
+	}
+
+	subscription.watch(ctx)
+
+	return subscription, nil
+}
+
+// contextValueNotification is the result of notifications
+// that carry a context and a value
+type contextValueNotification struct {
+	Context Context         `json:"context"`
+	Value   json.RawMessage `json:"value"`
+}
+
+func (c *WebSocketPubSubConnection) AccountSubscribe(ctx context.Context, request AccountSubscribeRequest) (*AccountSubscription, error) {
+	// prepare configuration object
+	config := map[string]interface{}{
+		"commitment": c.Commitment(),
+		"encoding":   Base64Encoding,
+	}
+
+	// set commitment level if provided
+	if request.CommitmentLevel != "" {
+		config["commitment"] = request.CommitmentLevel
+	}
+
+	// set encoding if provided
+	if request.Encoding != "" {
+		config["encoding"] = request.Encoding
+	}
+
+	// make subscription
+	subscription, err := c.subscribe(
+		ctx,
+		"accountSubscribe",
+		"accountUnsubscribe",
+		[]interface{}{request.PublicKey.ToBase58(), config},
+		func(result json.RawMessage) (interface{}, bool, error) {
+			var r contextValueNotification
+			if err := json.Unmarshal(result, &r); err != nil {
+				return nil, false, err
+			}
+			accountInfo, err := parseAccountInfo(r.Value)
+			if err != nil {
+				return nil, false, fmt.Errorf("error parsing account info: %w", err)
+			}
+			return AccountNotification{
+				Context:     r.Context,
+				AccountInfo: accountInfo,
+			}, false, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAccountSubscription(subscription), nil
+}
+
+func (c *WebSocketPubSubConnection) ProgramSubscribe(ctx context.Context, request ProgramSubscribeRequest) (*ProgramSubscription, error) {
+	// prepare configuration object
+	config := map[string]interface{}{
+		"commitment": c.Commitment(),
+		"encoding":   Base64Encoding,
+	}
+
+	// set commitment level if provided
+	if request.CommitmentLevel != "" {
+		config["commitment"] = request.CommitmentLevel
+	}
+
+	// set encoding if provided
+	if request.Encoding != "" {
+		config["encoding"] = request.Encoding
+	}
+
+	// set filters if provided
+	if len(request.Filters) > 0 {
+		config["filters"] = request.Filters
+	}
+
+	// make subscription
+	subscription, err := c.subscribe(
+		ctx,
+		"programSubscribe",
+		"programUnsubscribe",
+		[]interface{}{request.ProgramID.ToBase58(), config},
+		func(result json.RawMessage) (interface{}, bool, error) {
+			var r contextValueNotification
+			if err := json.Unmarshal(result, &r); err != nil {
+				return nil, false, err
+			}
+			programAccount := new(
+				struct {
+					PublicKey string          `json:"pubkey"`
+					Account   json.RawMessage `json:"account"`
+				},
+			)
+			if err := json.Unmarshal(r.Value, programAccount); err != nil {
+				return nil, false, err
+			}
+			accountInfo, err := parseAccountInfo(programAccount.Account)
+			if err != nil {
+				return nil, false, fmt.Errorf("error parsing account info of %s: %w", programAccount.PublicKey, err)
+			}
+			return ProgramNotification{
+				Context: r.Context,
+				ProgramAccount: ProgramAccount{
+					PublicKey:   NewPublicKeyFromBase58String(programAccount.PublicKey),
+					AccountInfo: accountInfo,
+				},
+			}, false, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return newProgramSubscription(subscription), nil
+}
+
+func (c *WebSocketPubSubConnection) SignatureSubscribe(ctx context.Context, request SignatureSubscribeRequest) (*SignatureSubscription, error) {
+	// prepare configuration object
+	config := map[string]interface{}{
+		"commitment": c.Commitment(),
+	}
+
+	// set commitment level if provided
+	if request.CommitmentLevel != "" {
+		config["commitment"] = request.CommitmentLevel
+	}
+
+	// set enable received notification if requested
+	if request.EnableReceivedNotification {
+		config["enableReceivedNotification"] = true
+	}
+
+	// make subscription
+	subscription, err := c.subscribe(
+		ctx,
+		"signatureSubscribe",
+		"signatureUnsubscribe",
+		[]interface{}{request.Signature, config},
+		func(result json.RawMessage) (interface{}, bool, error) {
+			var r contextValueNotification
+			if err := json.Unmarshal(result, &r); err != nil {
+				return nil, false, err
+			}
+
+			// value is either the string "receivedSignature" or an object with the transaction error
+			if len(r.Value) > 0 && r.Value[0] == '"' {
+				var value string
+				if err := json.Unmarshal(r.Value, &value); err != nil {
+					return nil, false, err
+				}
+				if value != "receivedSignature" {
+					return nil, false, fmt.Errorf("value %s: %w", value, ErrUnexpectedResponse)
+				}
+				return SignatureNotification{
+					Context:  r.Context,
+					Received: true,
+				}, false, nil
+			}
+			value := new(
+				struct {
+					Err *TransactionError `json:"err"`
+				},
+			)
+			if err := json.Unmarshal(r.Value, value); err != nil {
+				return nil, false, err
+			}
+			return SignatureNotification{
+				Context: r.Context,
+				Err:     value.Err,
+			}, true, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSignatureSubscription(subscription), nil
+}
+
+func (c *WebSocketPubSubConnection) LogsSubscribe(ctx context.Context, request LogsSubscribeRequest) (*LogsSubscription, error) {
+	// prepare configuration object
+	config := map[string]interface{}{
+		"commitment": c.Commitment(),
+	}
+
+	// set commitment level if provided
+	if request.CommitmentLevel != "" {
+		config["commitment"] = request.CommitmentLevel
+	}
+
+	// make subscription
+	subscription, err := c.subscribe(
+		ctx,
+		"logsSubscribe",
+		"logsUnsubscribe",
+		[]interface{}{request.Filter, config},
+		func(result json.RawMessage) (interface{}, bool, error) {
+			var r contextValueNotification
+			if err := json.Unmarshal(result, &r); err != nil {
+				return nil, false, err
+			}
+			var notification LogsNotification
+			if err := json.Unmarshal(r.Value, &notification); err != nil {
+				return nil, false, err
+			}
+			notification.Context = r.Context
+			return notification, false, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return newLogsSubscription(subscription), nil
+}
+
+func (c *WebSocketPubSubConnection) SlotSubscribe(ctx context.Context) (*SlotSubscription, error) {
+	// make subscription
+	subscription, err := c.subscribe(
+		ctx,
+		"slotSubscribe",
+		"slotUnsubscribe",
+		[]interface{}{},
+		func(result json.RawMessage) (interface{}, bool, error) {
+			var notification SlotNotification
+			if err := json.Unmarshal(result, &notification); err != nil {
+				return nil, false, err
+			}
+			return notification, false, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSlotSubscription(subscription), nil
+}
+
+func (c *WebSocketPubSubConnection) RootSubscribe(ctx context.Context) (*RootSubscription, error) {
+	// make subscription
+	subscription, err := c.subscribe(
+		ctx,
+		"rootSubscribe",
+		"rootUnsubscribe",
+		[]interface{}{},
+		func(result json.RawMessage) (interface{}, bool, error) {
+			var notification RootNotification
+			if err := json.Unmarshal(result, &notification.Root); err != nil {
+				return nil, false, err
+			}
+			return notification, false, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return newRootSubscription(subscription), nil
+}
+
+func (c *WebSocketPubSubConnection) BlockSubscribe(ctx context.Context, request BlockSubscribeRequest) (*BlockSubscription, error) {
+	// prepare filter
+	var filter interface{} = "all"
+	if request.MentionsAccountOrProgram != nil {
+		filter = map[string]string{
+			"mentionsAccountOrProgram": request.MentionsAccountOrProgram.ToBase58(),
+		}
+	}
+
+	// prepare configuration object
+	config := map[string]interface{}{
+		"commitment":         c.Commitment(),
+		"encoding":           JSONEncoding,
+		"transactionDetails": FullTransactionDetails,
+		"showRewards":        !request.ExcludeRewards,
+	}
+
+	// set commitment level if provided
+	if request.CommitmentLevel != "" {
+		config["commitment"] = request.CommitmentLevel
+	}
+
+	// set encoding if provided
+	if request.Encoding != "" {
+		config["encoding"] = request.Encoding
+	}
+
+	// set transaction details if provided
+	if request.TransactionDetails != "" {
+		config["transactionDetails"] = request.TransactionDetails
+	}
+
+	// set max supported transaction version if provided
+	if request.MaxSupportedTransactionVersion != nil {
+		config["maxSupportedTransactionVersion"] = int(*request.MaxSupportedTransactionVersion)
+	}
+
+	// make subscription
+	subscription, err := c.subscribe(
+		ctx,
+		"blockSubscribe",
+		"blockUnsubscribe",
+		[]interface{}{filter, config},
+		func(result json.RawMessage) (interface{}, bool, error) {
+			var r contextValueNotification
+			if err := json.Unmarshal(result, &r); err != nil {
+				return nil, false, err
+			}
+			value := new(
+				struct {
+					Slot  uint64                `json:"slot"`
+					Err   json.RawMessage       `json:"err"`
+					Block *blockJSONRPCResponse `json:"block"`
+				},
+			)
+			if err := json.Unmarshal(r.Value, value); err != nil {
+				return nil, false, err
+			}
+			notification := BlockNotification{
+				Context: r.Context,
+				Slot:    value.Slot,
+			}
+			if len(value.Err) > 0 && string(value.Err) != "null" {
+				notification.Err = value.Err
+			}
+			if value.Block != nil {
+				block, err := value.Block.toBlock()
+				if err != nil {
+					return nil, false, fmt.Errorf("error parsing block: %w", err)
+				}
+				notification.Block = block
+			}
+			return notification, false, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return newBlockSubscription(subscription), nil
+}
@@ -0,0 +1,640 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// pubsubTestRequest is a request received by a pubsubTestServer
+type pubsubTestRequest struct {
+	ID     int               `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+// pubsubTestServer is a stand-in pubsub node. Each subscribe request is answered with the
+// next subscription id, and each unsubscribe request with true. Requests are passed to
+// the test on requests, and notifications can be sent with notify.
+type pubsubTestServer struct {
+	*httptest.Server
+	requests chan pubsubTestRequest
+
+	// errors holds rpc errors with which to answer requests, by method
+	errors map[string]string
+
+	mu                 sync.Mutex
+	conn               *websocket.Conn
+	connections        int
+	nextSubscriptionID uint64
+
+	// reject is set to refuse connections
+	reject bool
+
+	// ignorePingsOnConnection is the number of the connection on which pings are not answered
+	ignorePingsOnConnection int
+}
+
+func newPubSubTestServer(t *testing.T) *pubsubTestServer {
+	s := &pubsubTestServer{
+		requests:           make(chan pubsubTestRequest, 100),
+		errors:             make(map[string]string),
+		nextSubscriptionID: 10,
+	}
+	upgrader := websocket.Upgrader{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		reject := s.reject
+		s.mu.Unlock()
+		if reject {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conn = conn
+		s.connections++
+		if s.connections == s.ignorePingsOnConnection {
+			conn.SetPingHandler(func(string) error { return nil })
+		}
+		s.mu.Unlock()
+		for {
+			var request pubsubTestRequest
+			if err := conn.ReadJSON(&request); err != nil {
+				return
+			}
+			response := map[string]interface{}{"jsonrpc": "2.0", "id": request.ID}
+			switch {
+			case s.errors[request.Method] != "":
+				response["error"] = map[string]interface{}{"code": -32602, "message": s.errors[request.Method]}
+			case strings.HasSuffix(request.Method, "Unsubscribe"):
+				response["result"] = true
+			default:
+				s.mu.Lock()
+				response["result"] = s.nextSubscriptionID
+				s.nextSubscriptionID++
+				s.mu.Unlock()
+			}
+			s.requests <- request
+			s.write(t, response)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *pubsubTestServer) url() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+func (s *pubsubTestServer) write(t *testing.T, v interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	require.NoError(t, s.conn.WriteJSON(v))
+}
+
+// drop closes the current connection without a close handshake
+func (s *pubsubTestServer) drop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_ = s.conn.Close()
+}
+
+// notify sends a notification with the given result for the given subscription
+func (s *pubsubTestServer) notify(t *testing.T, method string, subscription uint64, result string) {
+	s.write(t, map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  method,
+		"params": map[string]interface{}{
+			"subscription": subscription,
+			"result":       json.RawMessage(result),
+		},
+	})
+}
+
+// expectRequest waits for the next request received by the server
+func (s *pubsubTestServer) expectRequest(t *testing.T) pubsubTestRequest {
+	select {
+	case request := <-s.requests:
+		return request
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for request")
+		return pubsubTestRequest{}
+	}
+}
+
+func newTestPubSubConnection(t *testing.T, server *pubsubTestServer, opts ...WebSocketPubSubConnectionOption) *WebSocketPubSubConnection {
+	connection, err := NewWebSocketPubSubConnection(
+		context.Background(),
+		append(
+			[]WebSocketPubSubConnectionOption{
+				WithPubSubEndpoint(server.url()),
+				WithPubSubCommitmentLevel(FinalizedCommitmentLevel),
+				WithPubSubReconnectBackoff(time.Millisecond, 10*time.Millisecond),
+			},
+			opts...,
+		)...,
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = connection.Close() })
+	return connection
+}
+
+// receive waits for a value on the given channel, which
+// must be a channel returned by a Notifications method
+func receive(t *testing.T, notifications interface{}) interface{} {
+	timeout := time.After(5 * time.Second)
+	switch c := notifications.(type) {
+	case <-chan AccountNotification:
+		select {
+		case n := <-c:
+			return n
+		case <-timeout:
+		}
+	case <-chan ProgramNotification:
+		select {
+		case n := <-c:
+			return n
+		case <-timeout:
+		}
+	case <-chan SignatureNotification:
+		select {
+		case n := <-c:
+			return n
+		case <-timeout:
+		}
+	case <-chan LogsNotification:
+		select {
+		case n := <-c:
+			return n
+		case <-timeout:
+		}
+	case <-chan SlotNotification:
+		select {
+		case n := <-c:
+			return n
+		case <-timeout:
+		}
+	case <-chan RootNotification:
+		select {
+		case n := <-c:
+			return n
+		case <-timeout:
+		}
+	case <-chan BlockNotification:
+		select {
+		case n := <-c:
+			return n
+		case <-timeout:
+		}
+	}
+	t.Fatal("timed out waiting for notification")
+	return nil
+}
+
+func TestWebSocketPubSubConnection_Subscribe(t *testing.T) {
+	publicKey := NewPublicKeyFromBase58String("9B5XszUGdMaxCZ7uSQhPzdks5ZQSmWxrmzCSvtJ6Ns6g")
+	version := V0TransactionVersion
+
+	tests := []struct {
+		name            string
+		subscribe       func(PubSubConnection) (*Subscription, interface{}, error)
+		wantMethod      string
+		wantParams      []string
+		notification    string
+		want            interface{}
+		wantUnsubscribe string
+	}{
+		{
+			name: "account",
+			subscribe: func(c PubSubConnection) (*Subscription, interface{}, error) {
+				s, err := c.AccountSubscribe(context.Background(), AccountSubscribeRequest{PublicKey: publicKey})
+				if err != nil {
+					return nil, nil, err
+				}
+				return s.Subscription, s.Notifications(), nil
+			},
+			wantMethod: "accountSubscribe",
+			wantParams: []string{
+				`"9B5XszUGdMaxCZ7uSQhPzdks5ZQSmWxrmzCSvtJ6Ns6g"`,
+				`{"commitment":"finalized","encoding":"base64"}`,
+			},
+			notification: `{"context":{"slot":5},"value":{"data":["AQI=","base64"],"executable":false,"lamports":10,"owner":"11111111111111111111111111111111","rentEpoch":2}}`,
+			want: AccountNotification{
+				Context: Context{Slot: 5},
+				AccountInfo: AccountInfoEncodedData{
+					Lamports:  10,
+					Owner:     "11111111111111111111111111111111",
+					RentEpoch: 2,
+					Data:      []string{"AQI=", "base64"},
+				},
+			},
+			wantUnsubscribe: "accountUnsubscribe",
+		},
+		{
+			name: "program with filters",
+			subscribe: func(c PubSubConnection) (*Subscription, interface{}, error) {
+				s, err := c.ProgramSubscribe(context.Background(), ProgramSubscribeRequest{
+					ProgramID:       publicKey,
+					CommitmentLevel: ProcessedCommitmentLevel,
+					Filters:         []ProgramAccountsFilter{NewDataSizeFilter(2)},
+				})
+				if err != nil {
+					return nil, nil, err
+				}
+				return s.Subscription, s.Notifications(), nil
+			},
+			wantMethod: "programSubscribe",
+			wantParams: []string{
+				`"9B5XszUGdMaxCZ7uSQhPzdks5ZQSmWxrmzCSvtJ6Ns6g"`,
+				`{"commitment":"processed","encoding":"base64","filters":[{"dataSize":2}]}`,
+			},
+			notification: `{"context":{"slot":6},"value":{"pubkey":"11111111111111111111111111111111","account":{"data":["AQI=","base64"],"executable":false,"lamports":10,"owner":"9B5XszUGdMaxCZ7uSQhPzdks5ZQSmWxrmzCSvtJ6Ns6g","rentEpoch":2}}}`,
+			want: ProgramNotification{
+				Context: Context{Slot: 6},
+				ProgramAccount: ProgramAccount{
+					PublicKey: NewPublicKeyFromBase58String("11111111111111111111111111111111"),
+					AccountInfo: AccountInfoEncodedData{
+						Lamports:  10,
+						Owner:     "9B5XszUGdMaxCZ7uSQhPzdks5ZQSmWxrmzCSvtJ6Ns6g",
+						RentEpoch: 2,
+						Data:      []string{"AQI=", "base64"},
+					},
+				},
+			},
+			wantUnsubscribe: "programUnsubscribe",
+		},
+		{
+			name: "signature received",
+			subscribe: func(c PubSubConnection) (*Subscription, interface{}, error) {
+				s, err := c.SignatureSubscribe(context.Background(), SignatureSubscribeRequest{
+					Signature:                  "sig1",
+					EnableReceivedNotification: true,
+				})
+				if err != nil {
+					return nil, nil, err
+				}
+				return s.Subscription, s.Notifications(), nil
+			},
+			wantMethod: "signatureSubscribe",
+			wantParams: []string{
+				`"sig1"`,
+				`{"commitment":"finalized","enableReceivedNotification":true}`,
+			},
+			notification: `{"context":{"slot":7},"value":"receivedSignature"}`,
+			want: SignatureNotification{
+				Context:  Context{Slot: 7},
+				Received: true,
+			},
+			wantUnsubscribe: "signatureUnsubscribe",
+		},
+		{
+			name: "logs mentions",
+			subscribe: func(c PubSubConnection) (*Subscription, interface{}, error) {
+				s, err := c.LogsSubscribe(context.Background(), LogsSubscribeRequest{
+					Filter: MentionsLogsFilter(publicKey),
+				})
+				if err != nil {
+					return nil, nil, err
+				}
+				return s.Subscription, s.Notifications(), nil
+			},
+			wantMethod: "logsSubscribe",
+			wantParams: []string{
+				`{"mentions":["9B5XszUGdMaxCZ7uSQhPzdks5ZQSmWxrmzCSvtJ6Ns6g"]}`,
+				`{"commitment":"finalized"}`,
+			},
+			notification: `{"context":{"slot":8},"value":{"signature":"sig1","err":{"InstructionError":[0,{"Custom":1}]},"logs":["log1"]}}`,
+			want: LogsNotification{
+				Context:   Context{Slot: 8},
+				Signature: "sig1",
+				Err: &TransactionError{
+					Type: InstructionErrorTransactionError,
+					InstructionError: &InstructionError{
+						Index: 0,
+						Err:   CustomInstructionError(1),
+					},
+				},
+				Logs: []string{"log1"},
+			},
+			wantUnsubscribe: "logsUnsubscribe",
+		},
+		{
+			name: "logs all",
+			subscribe: func(c PubSubConnection) (*Subscription, interface{}, error) {
+				s, err := c.LogsSubscribe(context.Background(), LogsSubscribeRequest{})
+				if err != nil {
+					return nil, nil, err
+				}
+				return s.Subscription, s.Notifications(), nil
+			},
+			wantMethod: "logsSubscribe",
+			wantParams: []string{
+				`"all"`,
+				`{"commitment":"finalized"}`,
+			},
+			notification: `{"context":{"slot":8},"value":{"signature":"sig1","err":null,"logs":[]}}`,
+			want: LogsNotification{
+				Context:   Context{Slot: 8},
+				Signature: "sig1",
+				Logs:      []string{},
+			},
+			wantUnsubscribe: "logsUnsubscribe",
+		},
+		{
+			name: "slot",
+			subscribe: func(c PubSubConnection) (*Subscription, interface{}, error) {
+				s, err := c.SlotSubscribe(context.Background())
+				if err != nil {
+					return nil, nil, err
+				}
+				return s.Subscription, s.Notifications(), nil
+			},
+			wantMethod:      "slotSubscribe",
+			wantParams:      []string{},
+			notification:    `{"parent":75,"root":44,"slot":76}`,
+			want:            SlotNotification{Slot: 76, Parent: 75, Root: 44},
+			wantUnsubscribe: "slotUnsubscribe",
+		},
+		{
+			name: "root",
+			subscribe: func(c PubSubConnection) (*Subscription, interface{}, error) {
+				s, err := c.RootSubscribe(context.Background())
+				if err != nil {
+					return nil, nil, err
+				}
+				return s.Subscription, s.Notifications(), nil
+			},
+			wantMethod:      "rootSubscribe",
+			wantParams:      []string{},
+			notification:    `42`,
+			want:            RootNotification{Root: 42},
+			wantUnsubscribe: "rootUnsubscribe",
+		},
+		{
+			name: "block",
+			subscribe: func(c PubSubConnection) (*Subscription, interface{}, error) {
+				s, err := c.BlockSubscribe(context.Background(), BlockSubscribeRequest{
+					MentionsAccountOrProgram:       &publicKey,
+					TransactionDetails:             SignaturesTransactionDetails,
+					ExcludeRewards:                 true,
+					MaxSupportedTransactionVersion: &version,
+				})
+				if err != nil {
+					return nil, nil, err
+				}
+				return s.Subscription, s.Notifications(), nil
+			},
+			wantMethod: "blockSubscribe",
+			wantParams: []string{
+				`{"mentionsAccountOrProgram":"9B5XszUGdMaxCZ7uSQhPzdks5ZQSmWxrmzCSvtJ6Ns6g"}`,
+				`{"commitment":"finalized","encoding":"json","maxSupportedTransactionVersion":0,"showRewards":false,"transactionDetails":"signatures"}`,
+			},
+			notification: `{"context":{"slot":9},"value":{"slot":9,"err":null,"block":{"blockHeight":3,"blockTime":null,"blockhash":"hash2","previousBlockhash":"hash1","parentSlot":8,"signatures":["sig1"]}}}`,
+			want: BlockNotification{
+				Context: Context{Slot: 9},
+				Slot:    9,
+				Block: &Block{
+					BlockHeight:       func() *uint64 { h := uint64(3); return &h }(),
+					BlockHash:         "hash2",
+					PreviousBlockHash: "hash1",
+					ParentSlot:        8,
+					Signatures:        []string{"sig1"},
+				},
+			},
+			wantUnsubscribe: "blockUnsubscribe",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newPubSubTestServer(t)
+			connection := newTestPubSubConnection(t, server)
+
+			// subscribe and check request
+			subscription, notifications, err := tt.subscribe(connection)
+			require.NoError(t, err)
+			request := server.expectRequest(t)
+			require.Equal(t, tt.wantMethod, request.Method)
+			require.Len(t, request.Params, len(tt.wantParams))
+			for i := range tt.wantParams {
+				require.JSONEq(t, tt.wantParams[i], string(request.Params[i]))
+			}
+
+			// notify and check notification
+			server.notify(t, strings.TrimSuffix(tt.wantMethod, "Subscribe")+"Notification", 10, tt.notification)
+			require.Equal(t, tt.want, receive(t, notifications))
+
+			// unsubscribe and check request
+			require.NoError(t, subscription.Unsubscribe(context.Background()))
+			request = server.expectRequest(t)
+			require.Equal(t, tt.wantUnsubscribe, request.Method)
+			require.Len(t, request.Params, 1)
+			require.JSONEq(t, "10", string(request.Params[0]))
+			<-subscription.Done()
+			require.NoError(t, subscription.Err())
+		})
+	}
+}
+
+func TestWebSocketPubSubConnection_SignatureSubscribeFinal(t *testing.T) {
+	server := newPubSubTestServer(t)
+	connection := newTestPubSubConnection(t, server)
+
+	subscription, err := connection.SignatureSubscribe(context.Background(), SignatureSubscribeRequest{Signature: "sig1"})
+	require.NoError(t, err)
+	server.expectRequest(t)
+
+	server.notify(t, "signatureNotification", 10, `{"context":{"slot":7},"value":{"err":"AccountInUse"}}`)
+	require.Equal(
+		t,
+		SignatureNotification{
+			Context: Context{Slot: 7},
+			Err:     &TransactionError{Type: AccountInUseTransactionError},
+		},
+		receive(t, subscription.Notifications()),
+	)
+
+	// subscription ends after the final notification without an unsubscribe request
+	_, open := <-subscription.Notifications()
+	require.False(t, open)
+	require.NoError(t, subscription.Err())
+	require.NoError(t, subscription.Unsubscribe(context.Background()))
+	require.Len(t, server.requests, 0)
+}
+
+func TestWebSocketPubSubConnection_SubscribeContextDone(t *testing.T) {
+	server := newPubSubTestServer(t)
+	connection := newTestPubSubConnection(t, server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	subscription, err := connection.SlotSubscribe(ctx)
+	require.NoError(t, err)
+	server.expectRequest(t)
+
+	// cancelling the context ends and unsubscribes the subscription
+	cancel()
+	request := server.expectRequest(t)
+	require.Equal(t, "slotUnsubscribe", request.Method)
+	_, open := <-subscription.Notifications()
+	require.False(t, open)
+	require.ErrorIs(t, subscription.Err(), context.Canceled)
+}
+
+func TestWebSocketPubSubConnection_NotificationOverflow(t *testing.T) {
+	server := newPubSubTestServer(t)
+	connection := newTestPubSubConnection(t, server, WithPubSubNotificationBufferSize(1))
+
+	slowSubscription, err := connection.SlotSubscribe(context.Background())
+	require.NoError(t, err)
+	server.expectRequest(t)
+	subscription, err := connection.RootSubscribe(context.Background())
+	require.NoError(t, err)
+	server.expectRequest(t)
+
+	// a subscription that is not keeping up ends and is unsubscribed
+	for slot := 1; slot <= 4; slot++ {
+		server.notify(t, "slotNotification", 10, fmt.Sprintf(`{"parent":%d,"root":0,"slot":%d}`, slot-1, slot))
+	}
+	request := server.expectRequest(t)
+	require.Equal(t, "slotUnsubscribe", request.Method)
+	for range slowSubscription.Notifications() {
+	}
+	require.ErrorIs(t, slowSubscription.Err(), ErrPubSubNotificationOverflow)
+
+	// other subscriptions are unaffected
+	server.notify(t, "rootNotification", 11, `5`)
+	require.Equal(t, RootNotification{Root: 5}, receive(t, subscription.Notifications()))
+}
+
+func TestWebSocketPubSubConnection_Close(t *testing.T) {
+	server := newPubSubTestServer(t)
+	connection := newTestPubSubConnection(t, server)
+
+	subscription, err := connection.RootSubscribe(context.Background())
+	require.NoError(t, err)
+	server.expectRequest(t)
+
+	// closing the connection ends all subscriptions
+	require.NoError(t, connection.Close())
+	_, open := <-subscription.Notifications()
+	require.False(t, open)
+	require.ErrorIs(t, subscription.Err(), ErrPubSubConnectionClosed)
+
+	_, err = connection.SlotSubscribe(context.Background())
+	require.ErrorIs(t, err, ErrPubSubConnectionClosed)
+}
+
+func TestWebSocketPubSubConnection_SubscribeRPCError(t *testing.T) {
+	server := newPubSubTestServer(t)
+	server.errors["blockSubscribe"] = "Method not found"
+	connection := newTestPubSubConnection(t, server)
+
+	_, err := connection.BlockSubscribe(context.Background(), BlockSubscribeRequest{})
+	var rpcError *RPCError
+	require.ErrorAs(t, err, &rpcError)
+	require.Equal(t, "Method not found", rpcError.Message)
+}
+
+func TestWebSocketPubSubConnection_Reconnect(t *testing.T) {
+	server := newPubSubTestServer(t)
+	connection := newTestPubSubConnection(t, server)
+
+	subscription, err := connection.SlotSubscribe(context.Background())
+	require.NoError(t, err)
+	server.expectRequest(t)
+
+	// subscription is resubscribed after the connection is lost
+	server.drop()
+	request := server.expectRequest(t)
+	require.Equal(t, "slotSubscribe", request.Method)
+	select {
+	case gap := <-subscription.Gaps():
+		require.Error(t, gap.Err)
+		require.False(t, gap.ReconnectedAt.Before(gap.DisconnectedAt))
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for gap notification")
+	}
+
+	// notifications are routed by the new subscription id
+	server.notify(t, "slotNotification", 10, `{"parent":1,"root":1,"slot":2}`)
+	server.notify(t, "slotNotification", 11, `{"parent":2,"root":1,"slot":3}`)
+	require.Equal(t, SlotNotification{Slot: 3, Parent: 2, Root: 1}, receive(t, subscription.Notifications()))
+
+	require.NoError(t, subscription.Unsubscribe(context.Background()))
+	request = server.expectRequest(t)
+	require.Equal(t, "slotUnsubscribe", request.Method)
+	require.JSONEq(t, "11", string(request.Params[0]))
+}
+
+func TestWebSocketPubSubConnection_ReconnectDisabled(t *testing.T) {
+	server := newPubSubTestServer(t)
+	connection := newTestPubSubConnection(t, server, WithPubSubReconnect(false))
+
+	subscription, err := connection.SlotSubscribe(context.Background())
+	require.NoError(t, err)
+	server.expectRequest(t)
+
+	// subscription ends when the connection is lost
+	server.drop()
+	_, open := <-subscription.Notifications()
+	require.False(t, open)
+	require.Error(t, subscription.Err())
+
+	_, err = connection.RootSubscribe(context.Background())
+	require.Error(t, err)
+}
+
+func TestWebSocketPubSubConnection_MaxReconnectAttempts(t *testing.T) {
+	server := newPubSubTestServer(t)
+	connection := newTestPubSubConnection(t, server, WithPubSubMaxReconnectAttempts(2))
+
+	subscription, err := connection.SlotSubscribe(context.Background())
+	require.NoError(t, err)
+	server.expectRequest(t)
+
+	// subscription ends once reconnecting fails
+	server.mu.Lock()
+	server.reject = true
+	server.mu.Unlock()
+	server.drop()
+	_, open := <-subscription.Notifications()
+	require.False(t, open)
+	require.Error(t, subscription.Err())
+	require.Contains(t, subscription.Err().Error(), "after 2 attempts")
+}
+
+func TestWebSocketPubSubConnection_Heartbeat(t *testing.T) {
+	server := newPubSubTestServer(t)
+	server.ignorePingsOnConnection = 1
+	connection := newTestPubSubConnection(t, server, WithPubSubHeartbeatInterval(20*time.Millisecond))
+
+	subscription, err := connection.RootSubscribe(context.Background())
+	require.NoError(t, err)
+	server.expectRequest(t)
+
+	// connection is reestablished once pings go unanswered
+	request := server.expectRequest(t)
+	require.Equal(t, "rootSubscribe", request.Method)
+	select {
+	case <-subscription.Gaps():
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for gap notification")
+	}
+
+	// pings on the new connection are answered
+	time.Sleep(100 * time.Millisecond)
+	require.Len(t, server.requests, 0)
+	server.notify(t, "rootNotification", 11, `5`)
+	require.Equal(t, RootNotification{Root: 5}, receive(t, subscription.Notifications()))
+}
@@ -0,0 +1,206 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// PubSubConnection represents a connection to a fullnode PubSub websocket endpoint.
+//
+// Each Subscribe method returns a subscription that delivers typed notifications on a channel
+// until the subscription is unsubscribed, the context passed to the Subscribe method is done,
+// or the connection is closed. The notification channel is then closed, and the error that
+// ended the subscription is available from the subscription's Err method.
+type PubSubConnection interface {
+	// Commitment returns the default commitmentLevel used for subscriptions
+	Commitment() CommitmentLevel
+
+	// AccountSubscribe subscribes to changes to the lamports or data of an account
+	AccountSubscribe(ctx context.Context, request AccountSubscribeRequest) (*AccountSubscription, error)
+
+	// ProgramSubscribe subscribes to changes to the lamports or data of
+	// the accounts owned by a program, optionally filtered by ProgramAccountsFilters.
+	ProgramSubscribe(ctx context.Context, request ProgramSubscribeRequest) (*ProgramSubscription, error)
+
+	// SignatureSubscribe subscribes to the processing of a transaction. Only
+	// a single notification is delivered, after which the subscription ends.
+	SignatureSubscribe(ctx context.Context, request SignatureSubscribeRequest) (*SignatureSubscription, error)
+
+	// LogsSubscribe subscribes to the logs of processed transactions
+	LogsSubscribe(ctx context.Context, request LogsSubscribeRequest) (*LogsSubscription, error)
+
+	// SlotSubscribe subscribes to slots processed by the node
+	SlotSubscribe(ctx context.Context) (*SlotSubscription, error)
+
+	// RootSubscribe subscribes to slots set as root by the node
+	RootSubscribe(ctx context.Context) (*RootSubscription, error)
+
+	// BlockSubscribe subscribes to blocks that reach the given or default CommitmentLevel.
+	// This subscription is unstable, and only served by nodes with it enabled.
+	BlockSubscribe(ctx context.Context, request BlockSubscribeRequest) (*BlockSubscription, error)
+
+	// Close closes the connection, ending all subscriptions
+	Close() error
+}
+
+type AccountSubscribeRequest struct {
+	PublicKey       PublicKey
+	CommitmentLevel CommitmentLevel
+	Encoding        Encoding
+}
+
+// AccountNotification is a change to an account
+type AccountNotification struct {
+	Context     Context
+	AccountInfo AccountInfo
+}
+
+type ProgramSubscribeRequest struct {
+	// ProgramID is the program for which owned accounts are notified
+	ProgramID       PublicKey
+	CommitmentLevel CommitmentLevel
+	Encoding        Encoding
+
+	// Filters optionally filter the accounts notified.
+	// Only accounts that pass all filters are notified.
+	Filters []ProgramAccountsFilter
+}
+
+// ProgramNotification is a change to an account owned by a program
+type ProgramNotification struct {
+	Context        Context
+	ProgramAccount ProgramAccount
+}
+
+type SignatureSubscribeRequest struct {
+	// Signature is the base58 encoded signature of the transaction
+	Signature       string
+	CommitmentLevel CommitmentLevel
+
+	// EnableReceivedNotification can be set to true to also be notified
+	// when the transaction is received by the node, before it is processed
+	EnableReceivedNotification bool
+}
+
+// SignatureNotification is a notification of the processing of a transaction
+type SignatureNotification struct {
+	Context Context
+
+	// Received is true if this notifies that the transaction has been received
+	// by the node, and false if it notifies that the transaction has been processed
+	Received bool
+
+	// Err is the error with which the transaction failed. nil if it succeeded.
+	Err *TransactionError
+}
+
+// LogsFilter filters the transactions for which logs are notified
+type LogsFilter struct {
+	value interface{}
+}
+
+// AllLogsFilter notifies the logs of all transactions except simple vote transactions
+func AllLogsFilter() LogsFilter {
+	return LogsFilter{value: "all"}
+}
+
+// AllWithVotesLogsFilter notifies the logs of all transactions including simple vote transactions
+func AllWithVotesLogsFilter() LogsFilter {
+	return LogsFilter{value: "allWithVotes"}
+}
+
+// MentionsLogsFilter notifies the logs of transactions that mention the given account
+func MentionsLogsFilter(publicKey PublicKey) LogsFilter {
+	return LogsFilter{value: map[string][]string{"mentions": {publicKey.ToBase58()}}}
+}
+
+// MarshalJSON implements json.Marshaler for LogsFilter.
+// The zero value LogsFilter is an AllLogsFilter.
+func (f LogsFilter) MarshalJSON() ([]byte, error) {
+	if f.value == nil {
+		return json.Marshal(AllLogsFilter().value)
+	}
+	return json.Marshal(f.value)
+}
+
+type LogsSubscribeRequest struct {
+	// Filter is the filter of transactions for which logs are notified.
+	// Default value if not specified is AllLogsFilter.
+	Filter          LogsFilter
+	CommitmentLevel CommitmentLevel
+}
+
+// LogsNotification holds the logs of a processed transaction
+type LogsNotification struct {
+	Context Context
+
+	// Signature is the base58 encoded signature of the transaction
+	Signature string `json:"signature"`
+
+	// Err is the error with which the transaction failed. nil if it succeeded.
+	Err *TransactionError `json:"err"`
+
+	// Logs are the messages logged during the transaction
+	Logs []string `json:"logs"`
+}
+
+// SlotNotification is a slot processed by the node
+type SlotNotification struct {
+	Slot   uint64 `json:"slot"`
+	Parent uint64 `json:"parent"`
+	Root   uint64 `json:"root"`
+}
+
+// RootNotification is a slot set as root by the node
+type RootNotification struct {
+	Root uint64
+}
+
+type BlockSubscribeRequest struct {
+	// MentionsAccountOrProgram optionally limits notifications to blocks with
+	// transactions that mention the given account or program
+	MentionsAccountOrProgram *PublicKey
+	CommitmentLevel          CommitmentLevel
+
+	// Encoding is the Encoding of the notified transactions. One of JSONEncoding,
+	// JSONParsedEncoding, Base58Encoding or Base64Encoding.
+	// Default value if not specified is JSONEncoding.
+	Encoding Encoding
+
+	// TransactionDetails is the level of transaction detail to notify.
+	// Default value if not specified is FullTransactionDetails.
+	TransactionDetails TransactionDetails
+
+	// ExcludeRewards can be set to true to exclude rewards from the notified blocks
+	ExcludeRewards bool
+
+	// MaxSupportedTransactionVersion is the highest TransactionVersion to notify
+	MaxSupportedTransactionVersion *TransactionVersion
+}
+
+// BlockNotification is a block that reached the subscribed CommitmentLevel
+type BlockNotification struct {
+	Context Context
+	Slot    uint64
+
+	// Err is set if the block could not be retrieved by the node
+	Err json.RawMessage
+
+	// Block is nil if Err is set
+	Block *Block
+}
+
+// GapNotification notifies that a subscription was resubscribed after the connection to the node
+// was lost. Notifications may have been missed between DisconnectedAt and ReconnectedAt, and so
+// any state maintained from the notifications of the subscription should be refreshed.
+type GapNotification struct {
+	// DisconnectedAt is the time at which the connection was lost
+	DisconnectedAt time.Time
+
+	// ReconnectedAt is the time at which the connection was reestablished
+	ReconnectedAt time.Time
+
+	// Err is the error with which the connection was lost
+	Err error
+}
@@ -0,0 +1,373 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// unsubscribeTimeout is the time allowed for a subscription to be unsubscribed
+// from on the node when it ends other than by a call to Unsubscribe
+const unsubscribeTimeout = 10 * time.Second
+
+// notificationParser parses the result of a subscription notification.
+// final is true if no further notifications will be sent by the node for the subscription.
+type notificationParser func(result json.RawMessage) (notification interface{}, final bool, err error)
+
+// Subscription is a subscription made on a WebSocketPubSubConnection
+type Subscription struct {
+	connection *WebSocketPubSubConnection
+
+	// method, params and unsubscribeMethod are the
+	// rpc methods and params of the subscription
+	method            string
+	params            []interface{}
+	unsubscribeMethod string
+
+	// parse parses the result of each notification
+	parse notificationParser
+
+	// notifications receives parsed notifications from the connection.
+	// It is closed by the connection after a final notification.
+	notifications chan interface{}
+
+	// gaps receives a GapNotification each time the subscription is resubscribed
+	gaps chan GapNotification
+
+	// the following are guarded by the mutex of the connection
+	id         uint64
+	subscribed bool
+	serverDone bool
+	done       chan struct{}
+	err        error
+}
+
+// Unsubscribe ends the subscription and unsubscribes from it on the node.
+// The notification channel of the subscription is closed.
+func (s *Subscription) Unsubscribe(ctx context.Context) error {
+	if !s.end(nil) {
+		return nil
+	}
+	return s.unsubscribe(ctx)
+}
+
+// Done returns a channel that is closed when the subscription ends
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Gaps returns a channel on which a GapNotification is delivered each time the subscription is
+// resubscribed after the connection was lost, since notifications may have been missed.
+// Only one GapNotification is buffered, and further gaps are not delivered until it is received.
+// The channel is not closed when the subscription ends.
+func (s *Subscription) Gaps() <-chan GapNotification {
+	return s.gaps
+}
+
+// notifyGap delivers the given GapNotification if one is not already buffered
+func (s *Subscription) notifyGap(gap GapNotification) {
+	select {
+	case s.gaps <- gap:
+	default:
+	}
+}
+
+// Err returns the error that ended the subscription. nil if the subscription
+// is still active, was ended by Unsubscribe, or ended after a final notification.
+func (s *Subscription) Err() error {
+	s.connection.mu.Lock()
+	defer s.connection.mu.Unlock()
+	return s.err
+}
+
+// end ends the subscription with the given error.
+// Returns false if the subscription had already ended.
+func (s *Subscription) end(err error) bool {
+	s.connection.mu.Lock()
+	defer s.connection.mu.Unlock()
+	select {
+	case <-s.done:
+		return false
+	default:
+	}
+	s.err = err
+	close(s.done)
+	if s.subscribed {
+		delete(s.connection.subscriptions, s.id)
+	}
+	delete(s.connection.active, s)
+	return true
+}
+
+// unsubscribe unsubscribes from the subscription on the node
+func (s *Subscription) unsubscribe(ctx context.Context) error {
+	// nothing to do if the node has no subscription
+	s.connection.mu.Lock()
+	if !s.subscribed || s.serverDone {
+		s.connection.mu.Unlock()
+		return nil
+	}
+	id := s.id
+	s.connection.mu.Unlock()
+
+	// perform rpc call
+	rpcResponse, err := s.connection.call(ctx, s.unsubscribeMethod, []interface{}{id}, nil)
+	if err != nil {
+		return fmt.Errorf("error performing %s json-rpc call: %w", s.unsubscribeMethod, err)
+	}
+	if rpcResponse.Error != nil {
+		return fmt.Errorf("error set on rpc response: %w", newRPCError(rpcResponse.Error))
+	}
+
+	return nil
+}
+
+// unsubscribeInBackground unsubscribes from the subscription on the node, ignoring any error.
+// It is used when the subscription ends other than by a call to Unsubscribe.
+func (s *Subscription) unsubscribeInBackground() {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), unsubscribeTimeout)
+		defer cancel()
+		_ = s.unsubscribe(ctx)
+	}()
+}
+
+// watch ends the subscription when the given context is done
+func (s *Subscription) watch(ctx context.Context) {
+	go func() {
+		select {
+		case <-ctx.Done():
+			if s.end(ctx.Err()) {
+				s.unsubscribeInBackground()
+			}
+		case <-s.done:
+		}
+	}()
+}
+
+// forward forwards the parsed notifications of the subscription with the given deliver
+// function until the subscription ends, calling closeNotifications once done.
+// deliver must return without delivering the notification once the subscription is done.
+func (s *Subscription) forward(deliver func(notification interface{}), closeNotifications func()) {
+	go func() {
+		defer closeNotifications()
+		for {
+			select {
+			case <-s.done:
+				return
+			case notification, ok := <-s.notifications:
+				if !ok {
+					// the final notification has been delivered
+					s.end(nil)
+					return
+				}
+				deliver(notification)
+			}
+		}
+	}()
+}
+
+// AccountSubscription is a subscription made with AccountSubscribe
+type AccountSubscription struct {
+	*Subscription
+	notifications chan AccountNotification
+}
+
+// Notifications returns the channel on which notifications are delivered.
+// The channel is closed when the subscription ends.
+func (s *AccountSubscription) Notifications() <-chan AccountNotification {
+	return s.notifications
+}
+
+func newAccountSubscription(subscription *Subscription) *AccountSubscription {
+	s := &AccountSubscription{
+		Subscription:  subscription,
+		notifications: make(chan AccountNotification),
+	}
+	subscription.forward(
+		func(notification interface{}) {
+			select {
+			case s.notifications <- notification.(AccountNotification):
+			case <-s.done:
+			}
+		},
+		func() { close(s.notifications) },
+	)
+	return s
+}
+
+// ProgramSubscription is a subscription made with ProgramSubscribe
+type ProgramSubscription struct {
+	*Subscription
+	notifications chan ProgramNotification
+}
+
+// Notifications returns the channel on which notifications are delivered.
+// The channel is closed when the subscription ends.
+func (s *ProgramSubscription) Notifications() <-chan ProgramNotification {
+	return s.notifications
+}
+
+func newProgramSubscription(subscription *Subscription) *ProgramSubscription {
+	s := &ProgramSubscription{
+		Subscription:  subscription,
+		notifications: make(chan ProgramNotification),
+	}
+	subscription.forward(
+		func(notification interface{}) {
+			select {
+			case s.notifications <- notification.(ProgramNotification):
+			case <-s.done:
+			}
+		},
+		func() { close(s.notifications) },
+	)
+	return s
+}
+
+// SignatureSubscription is a subscription made with SignatureSubscribe
+type SignatureSubscription struct {
+	*Subscription
+	notifications chan SignatureNotification
+}
+
+// Notifications returns the channel on which notifications are delivered.
+// The channel is closed when the subscription ends, which
+// it does after the transaction has been processed.
+func (s *SignatureSubscription) Notifications() <-chan SignatureNotification {
+	return s.notifications
+}
+
+func newSignatureSubscription(subscription *Subscription) *SignatureSubscription {
+	s := &SignatureSubscription{
+		Subscription:  subscription,
+		notifications: make(chan SignatureNotification),
+	}
+	subscription.forward(
+		func(notification interface{}) {
+			select {
+			case s.notifications <- notification.(SignatureNotification):
+			case <-s.done:
+			}
+		},
+		func() { close(s.notifications) },
+	)
+	return s
+}
+
+// LogsSubscription is a subscription made with LogsSubscribe
+type LogsSubscription struct {
+	*Subscription
+	notifications chan LogsNotification
+}
+
+// Notifications returns the channel on which notifications are delivered.
+// The channel is closed when the subscription ends.
+func (s *LogsSubscription) Notifications() <-chan LogsNotification {
+	return s.notifications
+}
+
+func newLogsSubscription(subscription *Subscription) *LogsSubscription {
+	s := &LogsSubscription{
+		Subscription:  subscription,
+		notifications: make(chan LogsNotification),
+	}
+	subscription.forward(
+		func(notification interface{}) {
+			select {
+			case s.notifications <- notification.(LogsNotification):
+			case <-s.done:
+			}
+		},
+		func() { close(s.notifications) },
+	)
+	return s
+}
+
+// SlotSubscription is a subscription made with SlotSubscribe
+type SlotSubscription struct {
+	*Subscription
+	notifications chan SlotNotification
+}
+
+// Notifications returns the channel on which notifications are delivered.
+// The channel is closed when the subscription ends.
+func (s *SlotSubscription) Notifications() <-chan SlotNotification {
+	return s.notifications
+}
+
+func newSlotSubscription(subscription *Subscription) *SlotSubscription {
+	s := &SlotSubscription{
+		Subscription:  subscription,
+		notifications: make(chan SlotNotification),
+	}
+	subscription.forward(
+		func(notification interface{}) {
+			select {
+			case s.notifications <- notification.(SlotNotification):
+			case <-s.done:
+			}
+		},
+		func() { close(s.notifications) },
+	)
+	return s
+}
+
+// RootSubscription is a subscription made with RootSubscribe
+type RootSubscription struct {
+	*Subscription
+	notifications chan RootNotification
+}
+
+// Notifications returns the channel on which notifications are delivered.
+// The channel is closed when the subscription ends.
+func (s *RootSubscription) Notifications() <-chan RootNotification {
+	return s.notifications
+}
+
+func newRootSubscription(subscription *Subscription) *RootSubscription {
+	s := &RootSubscription{
+		Subscription:  subscription,
+		notifications: make(chan RootNotification),
+	}
+	subscription.forward(
+		func(notification interface{}) {
+			select {
+			case s.notifications <- notification.(RootNotification):
+			case <-s.done:
+			}
+		},
+		func() { close(s.notifications) },
+	)
+	return s
+}
+
+// BlockSubscription is a subscription made with BlockSubscribe
+type BlockSubscription struct {
+	*Subscription
+	notifications chan BlockNotification
+}
+
+// Notifications returns the channel on which notifications are delivered.
+// The channel is closed when the subscription ends.
+func (s *BlockSubscription) Notifications() <-chan BlockNotification {
+	return s.notifications
+}
+
+func newBlockSubscription(subscription *Subscription) *BlockSubscription {
+	s := &BlockSubscription{
+		Subscription:  subscription,
+		notifications: make(chan BlockNotification),
+	}
+	subscription.forward(
+		func(notification interface{}) {
+			select {
+			case s.notifications <- notification.(BlockNotification):
+			case <-s.done:
+			}
+		},
+		func() { close(s.notifications) },
+	)
+	return s
+}
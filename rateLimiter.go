@@ -0,0 +1,51 @@
+package solana
+
+import (
+	"github.com/BRBussy/solgo/internal/pkg/jsonrpc"
+)
+
+// RateLimit is a rate at which json-rpc calls may be made
+type RateLimit struct {
+	// PerSecond is the sustained number of calls allowed per second.
+	// A PerSecond of 0 or less allows unlimited calls.
+	PerSecond float64
+
+	// Burst is the number of calls that may be made at once before
+	// calls are limited to PerSecond. Values less than 1 are treated as 1.
+	Burst int
+}
+
+// RateLimiter limits the rate of json-rpc calls made by the JSONRPCConnections that it is set on
+// with WithRateLimiter, so that the limits of an rpc provider are not exceeded. Calls wait until
+// they are allowed by the limits, or until their context is done. Each call in a batch, and each
+// retry of a call, counts against the limits. A RateLimiter may be shared between JSONRPCConnections
+// to apply a single set of limits to all of them. Construct with NewRateLimiter.
+type RateLimiter struct {
+	rateLimiter *jsonrpc.RateLimiter
+}
+
+// NewRateLimiter returns a new RateLimiter that limits calls of all methods to the given
+// global RateLimit, and calls of each method in perMethod to the given RateLimit.
+// e.g. to allow 40 calls per second, of which at most 5 are getProgramAccounts calls:
+//
+//	rateLimiter := NewRateLimiter(
+//		RateLimit{PerSecond: 40, Burst: 40},
+//		map[string]RateLimit{"getProgramAccounts": {PerSecond: 5, Burst: 5}},
+//	)
+func NewRateLimiter(global RateLimit, perMethod map[string]RateLimit) *RateLimiter {
+	methods := make(map[string]jsonrpc.RateLimit, len(perMethod))
+	for method, rateLimit := range perMethod {
+		methods[method] = rateLimit.toJSONRPCRateLimit()
+	}
+	return &RateLimiter{
+		rateLimiter: jsonrpc.NewRateLimiter(global.toJSONRPCRateLimit(), methods),
+	}
+}
+
+// toJSONRPCRateLimit converts the RateLimit to a jsonrpc.RateLimit
+func (r RateLimit) toJSONRPCRateLimit() jsonrpc.RateLimit {
+	return jsonrpc.RateLimit{
+		PerSecond: r.PerSecond,
+		Burst:     r.Burst,
+	}
+}
@@ -0,0 +1,59 @@
+package solana
+
+import (
+	"github.com/BRBussy/solgo/internal/pkg/jsonrpc"
+	"time"
+)
+
+// nonIdempotentMethods are the json-rpc methods that can have a different effect if repeated
+var nonIdempotentMethods = []string{
+	"sendTransaction",
+	"requestAirdrop",
+}
+
+// RetryPolicy configures the retrying of json-rpc calls that fail with transient errors.
+// Calls are retried if they fail with an http 429, 502, 503 or 504 status code, or if the
+// http request fails.
+//
+// By default non-idempotent calls, such as sendTransaction, are only retried if the call
+// can not have been processed by the node, i.e. with an http 429 status code or if the
+// connection was refused. Resending a signed transaction can not result in it being processed
+// twice, but may fail if it was already processed, and so RetryNonIdempotent can be set to
+// true to retry these calls after any transient error.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts of each call, including the first
+	MaxAttempts int
+
+	// MinBackoff is the delay before the first retry, which doubles on each retry up to
+	// MaxBackoff, or without limit if MaxBackoff is 0, and is reduced by a random jitter of up to half.
+	// A Retry-After header in a failed response is honoured if it requests a longer delay.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// RetryNonIdempotent can be set to true to retry non-idempotent
+	// calls after transient errors in which they may have been processed
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a RetryPolicy that makes up to 4 attempts
+// of each call, with a backoff from 250 milliseconds to 5 seconds.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		MinBackoff:  250 * time.Millisecond,
+		MaxBackoff:  5 * time.Second,
+	}
+}
+
+// toJSONRPCRetryPolicy converts the RetryPolicy to a jsonrpc.RetryPolicy
+func (p RetryPolicy) toJSONRPCRetryPolicy() *jsonrpc.RetryPolicy {
+	retryPolicy := &jsonrpc.RetryPolicy{
+		MaxAttempts: p.MaxAttempts,
+		MinBackoff:  p.MinBackoff,
+		MaxBackoff:  p.MaxBackoff,
+	}
+	if !p.RetryNonIdempotent {
+		retryPolicy.NonIdempotentMethods = nonIdempotentMethods
+	}
+	return retryPolicy
+}
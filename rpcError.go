@@ -0,0 +1,157 @@
+package solana
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/BRBussy/solgo/internal/pkg/jsonrpc"
+)
+
+// RPCErrorCode is the code of an error returned by a node in response to a JSON RPC request
+type RPCErrorCode int
+
+const (
+	BlockCleanedUpRPCErrorCode                           RPCErrorCode = -32001
+	SendTransactionPreflightFailureRPCErrorCode          RPCErrorCode = -32002
+	TransactionSignatureVerificationFailureRPCErrorCode  RPCErrorCode = -32003
+	BlockNotAvailableRPCErrorCode                        RPCErrorCode = -32004
+	NodeUnhealthyRPCErrorCode                            RPCErrorCode = -32005
+	TransactionPrecompileVerificationFailureRPCErrorCode RPCErrorCode = -32006
+	SlotSkippedRPCErrorCode                              RPCErrorCode = -32007
+	NoSnapshotRPCErrorCode                               RPCErrorCode = -32008
+	LongTermStorageSlotSkippedRPCErrorCode               RPCErrorCode = -32009
+	KeyExcludedFromSecondaryIndexRPCErrorCode            RPCErrorCode = -32010
+	TransactionHistoryNotAvailableRPCErrorCode           RPCErrorCode = -32011
+	ScanErrorRPCErrorCode                                RPCErrorCode = -32012
+	TransactionSignatureLengthMismatchRPCErrorCode       RPCErrorCode = -32013
+	BlockStatusNotAvailableYetRPCErrorCode               RPCErrorCode = -32014
+	UnsupportedTransactionVersionRPCErrorCode            RPCErrorCode = -32015
+	MinContextSlotNotReachedRPCErrorCode                 RPCErrorCode = -32016
+)
+
+// RPCError is an error returned by a node in response to a JSON RPC request.
+// Errors with the SendTransactionPreflightFailureRPCErrorCode and
+// NodeUnhealthyRPCErrorCode are returned as a PreflightFailureError
+// and NodeUnhealthyError respectively.
+type RPCError struct {
+	Code    RPCErrorCode
+	Message string
+
+	// Data is the additional error data, if any
+	Data json.RawMessage
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("%d: %s", e.Code, e.Message)
+}
+
+// HTTPError is returned when a json-rpc call fails with an http error status
+// code, and the response does not hold an RPCError. Use errors.As to inspect
+// the status Code, e.g. to detect rate limiting with http.StatusTooManyRequests.
+type HTTPError = jsonrpc.HTTPError
+
+// ErrConnectionRefused is returned when the connection to the json-rpc endpoint is refused
+var ErrConnectionRefused = jsonrpc.ErrConnectionRefused
+
+// PreflightFailureError is returned when the preflight simulation of a sent transaction fails.
+// Use errors.As to inspect the TransactionError with which the simulation failed.
+type PreflightFailureError struct {
+	Message string
+
+	// Err is the error with which the simulation failed, if available
+	Err *TransactionError
+
+	// Logs are the messages logged during the simulation
+	Logs []string
+
+	// UnitsConsumed is the number of compute units consumed during the simulation, if available
+	UnitsConsumed *uint64
+
+	// ReturnData is the most recent return data set by a program during the simulation, if any
+	ReturnData *ReturnData
+}
+
+func (e *PreflightFailureError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns Err, if set
+func (e *PreflightFailureError) Unwrap() error {
+	if e.Err == nil {
+		return nil
+	}
+	return e.Err
+}
+
+// NodeUnhealthyError is returned by a node that is not
+// keeping up with the cluster and so cannot serve requests
+type NodeUnhealthyError struct {
+	Message string
+
+	// NumSlotsBehind is the number of slots the node is behind the cluster, if known
+	NumSlotsBehind *uint64
+}
+
+func (e *NodeUnhealthyError) Error() string {
+	return e.Message
+}
+
+// newRPCError returns the typed error for the given jsonrpc.RPCError.
+// A PreflightFailureError, a NodeUnhealthyError or an *RPCError is returned.
+func newRPCError(rpcError *jsonrpc.RPCError) error {
+	// prepare generic error
+	err := &RPCError{
+		Code:    RPCErrorCode(rpcError.Code),
+		Message: rpcError.Message,
+	}
+	if rpcError.Data != nil {
+		data, marshalErr := json.Marshal(rpcError.Data)
+		if marshalErr != nil {
+			return err
+		}
+		err.Data = data
+	}
+
+	// parse data of errors that carry it, falling back to
+	// the generic error if data is not as expected
+	switch err.Code {
+	case SendTransactionPreflightFailureRPCErrorCode:
+		data := new(
+			struct {
+				Err           *TransactionError `json:"err"`
+				Logs          []string          `json:"logs"`
+				UnitsConsumed *uint64           `json:"unitsConsumed"`
+				ReturnData    *ReturnData       `json:"returnData"`
+			},
+		)
+		if len(err.Data) > 0 {
+			if unmarshalErr := json.Unmarshal(err.Data, data); unmarshalErr != nil {
+				return err
+			}
+		}
+		return &PreflightFailureError{
+			Message:       err.Message,
+			Err:           data.Err,
+			Logs:          data.Logs,
+			UnitsConsumed: data.UnitsConsumed,
+			ReturnData:    data.ReturnData,
+		}
+
+	case NodeUnhealthyRPCErrorCode:
+		data := new(
+			struct {
+				NumSlotsBehind *uint64 `json:"numSlotsBehind"`
+			},
+		)
+		if len(err.Data) > 0 {
+			if unmarshalErr := json.Unmarshal(err.Data, data); unmarshalErr != nil {
+				return err
+			}
+		}
+		return &NodeUnhealthyError{
+			Message:        err.Message,
+			NumSlotsBehind: data.NumSlotsBehind,
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,153 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/BRBussy/solgo/internal/pkg/jsonrpc"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// decodeRPCError decodes the given json error object
+// as it would be by the jsonrpc client
+func decodeRPCError(t *testing.T, data string) *jsonrpc.RPCError {
+	rpcError := new(jsonrpc.RPCError)
+	require.Nil(t, json.Unmarshal([]byte(data), rpcError))
+	return rpcError
+}
+
+func TestNewRPCError(t *testing.T) {
+	unitsConsumed := uint64(0)
+	numSlotsBehind := uint64(42)
+
+	tests := []struct {
+		name string
+		data string
+		want error
+	}{
+		{
+			name: "preflight failure",
+			data: `{
+  "code": -32002,
+  "message": "Transaction simulation failed: Error processing Instruction 0: custom program error: 0x1",
+  "data": {
+    "accounts": null,
+    "err": {"InstructionError": [0, {"Custom": 1}]},
+    "logs": ["Program 11111111111111111111111111111111 invoke [1]", "Program 11111111111111111111111111111111 failed: custom program error: 0x1"],
+    "returnData": null,
+    "unitsConsumed": 0
+  }
+}`,
+			want: &PreflightFailureError{
+				Message: "Transaction simulation failed: Error processing Instruction 0: custom program error: 0x1",
+				Err: &TransactionError{
+					Type: InstructionErrorTransactionError,
+					InstructionError: &InstructionError{
+						Index: 0,
+						Err:   CustomInstructionError(1),
+					},
+				},
+				Logs: []string{
+					"Program 11111111111111111111111111111111 invoke [1]",
+					"Program 11111111111111111111111111111111 failed: custom program error: 0x1",
+				},
+				UnitsConsumed: &unitsConsumed,
+			},
+		},
+		{
+			name: "node unhealthy",
+			data: `{"code": -32005, "message": "Node is behind by 42 slots", "data": {"numSlotsBehind": 42}}`,
+			want: &NodeUnhealthyError{
+				Message:        "Node is behind by 42 slots",
+				NumSlotsBehind: &numSlotsBehind,
+			},
+		},
+		{
+			name: "node unhealthy without data",
+			data: `{"code": -32005, "message": "Node is unhealthy"}`,
+			want: &NodeUnhealthyError{
+				Message: "Node is unhealthy",
+			},
+		},
+		{
+			name: "unexpected data",
+			data: `{"code": -32002, "message": "Transaction simulation failed", "data": {"err": 5}}`,
+			want: &RPCError{
+				Code:    SendTransactionPreflightFailureRPCErrorCode,
+				Message: "Transaction simulation failed",
+				Data:    json.RawMessage(`{"err":5}`),
+			},
+		},
+		{
+			name: "other error",
+			data: `{"code": -32007, "message": "Slot 100 was skipped"}`,
+			want: &RPCError{
+				Code:    SlotSkippedRPCErrorCode,
+				Message: "Slot 100 was skipped",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, newRPCError(decodeRPCError(t, tt.data)))
+		})
+	}
+}
+
+func TestNewRPCError_As(t *testing.T) {
+	err := newRPCError(decodeRPCError(t, `{
+  "code": -32002,
+  "message": "Transaction simulation failed: Blockhash not found",
+  "data": {"err": "BlockhashNotFound", "logs": []}
+}`))
+
+	var preflightErr *PreflightFailureError
+	require.True(t, errors.As(err, &preflightErr))
+	require.Equal(t, []string{}, preflightErr.Logs)
+
+	var txnErr *TransactionError
+	require.True(t, errors.As(err, &txnErr))
+	require.Equal(t, BlockhashNotFoundTransactionError, txnErr.Type)
+}
+
+func TestJSONRPCConnection_RPCErrorAs(t *testing.T) {
+	payer := MustNewRandomKeypair()
+	txn := NewTransaction()
+	require.Nil(t, txn.AddInstructions(Instruction{
+		InstructionAccountMeta: []InstructionAccountMeta{
+			{PubKey: payer.PublicKey, IsSigner: true, IsWritable: true},
+		},
+		ProgramIDPubKey: MustNewRandomKeypair().PublicKey,
+	}))
+	require.Nil(t, txn.SetRecentBlockHash("CSymwgTNX1j3E4qhKfJAUE41nBWEwXufoYryPbkde5RR"))
+	require.Nil(t, txn.Sign(payer.PrivateKey))
+
+	j := &JSONRPCConnection{
+		jsonRPCClient: &jsonrpc.MockClient{
+			T: t,
+			CallParamArrayFunc: func(t *testing.T, m *jsonrpc.MockClient, ctx context.Context, method string, additionalHeaders map[string]string, params ...interface{}) (*jsonrpc.RPCResponse, error) {
+				return &jsonrpc.RPCResponse{
+					Error: decodeRPCError(t, `{
+  "code": -32002,
+  "message": "Transaction simulation failed: Error processing Instruction 0: custom program error: 0x1770",
+  "data": {"err": {"InstructionError": [0, {"Custom": 6000}]}, "logs": ["Program log: AnchorError occurred"]}
+}`),
+				}, nil
+			},
+		},
+		config: &jsonrpcConnectionConfig{
+			commitmentLevel: MaxCommitmentLevel,
+		},
+	}
+	_, err := j.SendTransaction(context.Background(), SendTransactionRequest{Transaction: *txn})
+	require.NotNil(t, err)
+
+	var preflightErr *PreflightFailureError
+	require.True(t, errors.As(err, &preflightErr))
+	require.Equal(t, []string{"Program log: AnchorError occurred"}, preflightErr.Logs)
+
+	var custom CustomInstructionError
+	require.True(t, errors.As(err, &custom))
+	require.Equal(t, CustomInstructionError(6000), custom)
+}
@@ -0,0 +1,10 @@
+// Package secp256k1Program provides functions for constructing and parsing instructions
+// for the Solana secp256k1 signature recovery precompile.
+// See program definition here:
+// https://github.com/solana-labs/solana/blob/v1.16.0/sdk/src/secp256k1_instruction.rs
+package secp256k1Program
+
+import solana "github.com/BRBussy/solgo"
+
+// ID is the Solana secp256k1 signature recovery program ID
+var ID = solana.NewPublicKeyFromBase58String("KeccakSecp256k11111111111111111111111111111")
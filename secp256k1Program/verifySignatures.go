@@ -0,0 +1,135 @@
+package secp256k1Program
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/BRBussy/solgo"
+	"github.com/BRBussy/solgo/internal/pkg/precompile"
+)
+
+// layout is the instruction data layout of the secp256k1 program,
+// in which the number of signed messages is followed by the SignatureOffsets
+var layout = precompile.Layout{
+	ProgramID:    ID,
+	OffsetsStart: 1,
+	OffsetsSize:  11,
+}
+
+const (
+	// EthAddressSize is the size of an Ethereum address
+	EthAddressSize = 20
+
+	// SignatureSize is the size of a secp256k1 signature excluding the recovery id
+	SignatureSize = 64
+)
+
+// EthAddress is an Ethereum address, which is the last 20 bytes of the
+// keccak256 hash of an uncompressed secp256k1 public key.
+type EthAddress [EthAddressSize]byte
+
+// String returns the EthAddress as a 0x prefixed hex string
+func (e EthAddress) String() string {
+	return "0x" + hex.EncodeToString(e[:])
+}
+
+// SignatureOffsets locates the signature, Ethereum address and message of a
+// signed message in the data of an instruction in the transaction.
+type SignatureOffsets struct {
+	SignatureOffset            uint16
+	SignatureInstructionIndex  uint8
+	EthAddressOffset           uint16
+	EthAddressInstructionIndex uint8
+	MessageDataOffset          uint16
+	MessageDataSize            uint16
+	MessageInstructionIndex    uint8
+}
+
+// SignedMessage is a message along with its secp256k1 signature
+// and the EthAddress of the signer.
+type SignedMessage struct {
+	EthAddress EthAddress
+	Message    []byte
+
+	// Signature is the 64 byte serialised r and s values of the signature
+	Signature [SignatureSize]byte
+
+	// RecoveryID is the id used to recover the public key from the signature
+	RecoveryID uint8
+}
+
+type VerifySignaturesParams struct {
+	// SignedMessages are the signed messages to be verified
+	SignedMessages []SignedMessage
+
+	// InstructionIndex is the index of the created instruction in its transaction.
+	// The secp256k1 program requires the SignatureOffsets to refer to data by instruction
+	// index, and so this is required for the data to be found in the created instruction.
+	InstructionIndex uint8
+}
+
+// VerifySignatures creates a Solana secp256k1 program Instruction which will cause the
+// transaction containing it to fail unless all of the SignedMessages are valid.
+// The Ethereum addresses, signatures and messages are all held in the data of the Instruction.
+func VerifySignatures(params VerifySignaturesParams) ([]solana.Instruction, error) {
+	signedMessages := make([][][]byte, len(params.SignedMessages))
+	for i := range params.SignedMessages {
+		// the recovery id is held after the signature
+		signedMessage := &params.SignedMessages[i]
+		signature := append(append([]byte{}, signedMessage.Signature[:]...), signedMessage.RecoveryID)
+		signedMessages[i] = [][]byte{signedMessage.EthAddress[:], signature, signedMessage.Message}
+	}
+	return layout.NewInstruction(signedMessages, func(i int, partOffsets []uint16) interface{} {
+		return SignatureOffsets{
+			SignatureOffset:            partOffsets[1],
+			SignatureInstructionIndex:  params.InstructionIndex,
+			EthAddressOffset:           partOffsets[0],
+			EthAddressInstructionIndex: params.InstructionIndex,
+			MessageDataOffset:          partOffsets[2],
+			MessageDataSize:            uint16(len(signedMessages[i][2])),
+			MessageInstructionIndex:    params.InstructionIndex,
+		}
+	})
+}
+
+// ParseVerifySignatures extracts the SignedMessages from the secp256k1 program Instruction
+// at the given index in the given Instructions of a transaction. SignatureOffsets may refer
+// to data held in any instruction in the transaction.
+//
+// Note that the SignedMessages are not verified.
+func ParseVerifySignatures(instructions []solana.Instruction, index int) ([]SignedMessage, error) {
+	// read signature offsets
+	var offsets []SignatureOffsets
+	if err := layout.ReadOffsets(instructions, index, func(n int) interface{} {
+		offsets = make([]SignatureOffsets, n)
+		return offsets
+	}); err != nil {
+		return nil, err
+	}
+
+	// extract signed messages
+	signedMessages := make([]SignedMessage, 0, len(offsets))
+	for _, offset := range offsets {
+		ethAddress, err := precompile.Data(instructions, int(offset.EthAddressInstructionIndex), offset.EthAddressOffset, EthAddressSize)
+		if err != nil {
+			return nil, fmt.Errorf("error getting eth address: %w", err)
+		}
+		signature, err := precompile.Data(instructions, int(offset.SignatureInstructionIndex), offset.SignatureOffset, SignatureSize+1)
+		if err != nil {
+			return nil, fmt.Errorf("error getting signature: %w", err)
+		}
+		message, err := precompile.Data(instructions, int(offset.MessageInstructionIndex), offset.MessageDataOffset, int(offset.MessageDataSize))
+		if err != nil {
+			return nil, fmt.Errorf("error getting message: %w", err)
+		}
+
+		signedMessage := SignedMessage{
+			Message:    append([]byte{}, message...),
+			RecoveryID: signature[SignatureSize],
+		}
+		copy(signedMessage.EthAddress[:], ethAddress)
+		copy(signedMessage.Signature[:], signature[:SignatureSize])
+		signedMessages = append(signedMessages, signedMessage)
+	}
+
+	return signedMessages, nil
+}
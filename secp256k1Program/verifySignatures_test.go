@@ -0,0 +1,59 @@
+package secp256k1Program
+
+import (
+	"github.com/BRBussy/solgo"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestVerifySignatures(t *testing.T) {
+	signedMessages := []SignedMessage{
+		{
+			EthAddress: EthAddress{0x01, 0x02, 19: 0x14},
+			Message:    []byte("attestation"),
+			Signature:  [SignatureSize]byte{0xaa, 63: 0xbb},
+			RecoveryID: 1,
+		},
+		{
+			EthAddress: EthAddress{0x03},
+			Message:    []byte("another"),
+			Signature:  [SignatureSize]byte{0xcc},
+			RecoveryID: 0,
+		},
+	}
+
+	instructions, err := VerifySignatures(VerifySignaturesParams{
+		SignedMessages:   signedMessages,
+		InstructionIndex: 2,
+	})
+	require.Nil(t, err)
+	require.Len(t, instructions, 1)
+
+	// check header and first signature offsets
+	data := instructions[0].Data
+	require.Equal(t, byte(2), data[0])
+	require.Equal(
+		t,
+		[]byte{
+			43, 0, 2, // signature
+			23, 0, 2, // eth address
+			108, 0, 11, 0, 2, // message
+		},
+		data[1:12],
+	)
+	require.Equal(t, "0x0102000000000000000000000000000000000014", signedMessages[0].EthAddress.String())
+
+	// parse signed messages back out from the instruction at its index
+	txnInstructions := []solana.Instruction{{}, {}, instructions[0]}
+	parsed, err := ParseVerifySignatures(txnInstructions, 2)
+	require.Nil(t, err)
+	require.Equal(t, signedMessages, parsed)
+
+	// offsets refer to data by instruction index, so parsing
+	// at another index should find the wrong data
+	_, err = ParseVerifySignatures(instructions, 0)
+	require.ErrorIs(t, err, ErrInvalidInstructionIndex)
+
+	_, err = VerifySignatures(VerifySignaturesParams{})
+	require.ErrorIs(t, err, ErrNoSignedMessages)
+}
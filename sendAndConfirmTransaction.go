@@ -0,0 +1,162 @@
+package solana
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultConfirmTransactionPollInterval is the default interval
+	// at which SendAndConfirmTransaction polls for the transaction status
+	DefaultConfirmTransactionPollInterval = 500 * time.Millisecond
+
+	// DefaultConfirmTransactionResendInterval is the default interval at which
+	// SendAndConfirmTransaction resends a transaction that has not yet been processed
+	DefaultConfirmTransactionResendInterval = 2 * time.Second
+)
+
+type SendAndConfirmTransactionRequest struct {
+	// SendTransactionRequest is the request used to send, and resend, the transaction.
+	// Preflight checks are skipped on resends.
+	SendTransactionRequest SendTransactionRequest
+
+	// CommitmentLevel is the commitment level that the transaction must reach.
+	// Default value if not specified is the Commitment of the Connection.
+	CommitmentLevel CommitmentLevel
+
+	// LastValidBlockHeight is the last block height at which the recent block hash
+	// of the transaction is valid, as returned by GetLatestBlockhash.
+	// If not specified, the LastValidBlockHeight of the latest block
+	// hash at the time the transaction is first sent is used.
+	LastValidBlockHeight uint64
+
+	// PollInterval is the interval at which the transaction status is polled.
+	// Default value if not specified is DefaultConfirmTransactionPollInterval.
+	PollInterval time.Duration
+
+	// ResendInterval is the interval at which the transaction is resent until it has been processed.
+	// Default value if not specified is DefaultConfirmTransactionResendInterval.
+	ResendInterval time.Duration
+}
+
+type SendAndConfirmTransactionResponse struct {
+	// TransactionID is the First Transaction Signature embedded
+	// in the transaction, as base58 encoded string - aka. transaction id
+	TransactionID string
+
+	// Status is the status of the transaction once it reached the requested CommitmentLevel
+	Status SignatureStatus
+}
+
+// SendAndConfirmTransaction sends a signed transaction with the given Connection and waits
+// for it to reach the requested CommitmentLevel, resending it periodically until it is processed.
+//
+// If the transaction fails the *TransactionError with which it failed is returned.
+// If the block height passes the LastValidBlockHeight before the transaction reaches the
+// requested CommitmentLevel then an error wrapping ErrTransactionExpired is returned,
+// after which the transaction can no longer be processed.
+func SendAndConfirmTransaction(ctx context.Context, connection Connection, request SendAndConfirmTransactionRequest) (*SendAndConfirmTransactionResponse, error) {
+	// apply defaults
+	if request.CommitmentLevel == "" {
+		request.CommitmentLevel = connection.Commitment()
+	}
+	if request.PollInterval == 0 {
+		request.PollInterval = DefaultConfirmTransactionPollInterval
+	}
+	if request.ResendInterval == 0 {
+		request.ResendInterval = DefaultConfirmTransactionResendInterval
+	}
+
+	// get last valid block height if not provided
+	if request.LastValidBlockHeight == 0 {
+		getLatestBlockhashResponse, err := connection.GetLatestBlockhash(
+			ctx,
+			GetLatestBlockhashRequest{
+				CommitmentLevel: request.CommitmentLevel,
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error getting latest block hash: %w", err)
+		}
+		request.LastValidBlockHeight = getLatestBlockhashResponse.LastValidBlockHeight
+	}
+
+	// send transaction
+	sendTransactionResponse, err := connection.SendTransaction(ctx, request.SendTransactionRequest)
+	if err != nil {
+		return nil, fmt.Errorf("error sending transaction: %w", err)
+	}
+	response := &SendAndConfirmTransactionResponse{
+		TransactionID: sendTransactionResponse.TransactionID,
+	}
+	lastSent := time.Now()
+
+	// preflight checks are not repeated on resends since
+	// they fail once the transaction has been processed
+	resendRequest := request.SendTransactionRequest
+	resendRequest.SkipPreflight = true
+
+	ticker := time.NewTicker(request.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		// check transaction status
+		getSignatureStatusesResponse, err := connection.GetSignatureStatuses(
+			ctx,
+			GetSignatureStatusesRequest{
+				Signatures: []string{response.TransactionID},
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error getting signature status: %w", err)
+		}
+		if len(getSignatureStatusesResponse.Statuses) != 1 {
+			return nil, fmt.Errorf("%d signature statuses: %w", len(getSignatureStatusesResponse.Statuses), ErrUnexpectedResponse)
+		}
+		status := getSignatureStatusesResponse.Statuses[0]
+		if status != nil {
+			if status.Err != nil {
+				return nil, fmt.Errorf("transaction %s failed: %w", response.TransactionID, status.Err)
+			}
+			if status.ConfirmationStatus.rank() >= request.CommitmentLevel.rank() {
+				response.Status = *status
+				return response, nil
+			}
+		}
+
+		// check block hash expiry
+		getBlockHeightResponse, err := connection.GetBlockHeight(
+			ctx,
+			GetBlockHeightRequest{
+				CommitmentLevel: request.CommitmentLevel,
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error getting block height: %w", err)
+		}
+		if getBlockHeightResponse.BlockHeight > request.LastValidBlockHeight {
+			return nil, fmt.Errorf(
+				"transaction %s not confirmed by block height %d, which exceeds last valid block height %d: %w",
+				response.TransactionID,
+				getBlockHeightResponse.BlockHeight,
+				request.LastValidBlockHeight,
+				ErrTransactionExpired,
+			)
+		}
+
+		// resend transaction if it has not yet been processed
+		if status == nil && time.Since(lastSent) >= request.ResendInterval {
+			// failure to resend is not fatal as the transaction may still be processed
+			if _, err := connection.SendTransaction(ctx, resendRequest); err != nil && ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			lastSent = time.Now()
+		}
+	}
+}
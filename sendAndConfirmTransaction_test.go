@@ -0,0 +1,158 @@
+package solana
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// confirmConnection is a Connection that serves the methods used by
+// SendAndConfirmTransaction, returning the next of the given statuses
+// and block heights on each poll
+type confirmConnection struct {
+	Connection
+	statuses         []*SignatureStatus
+	blockHeights     []uint64
+	sendRequests     []SendTransactionRequest
+	getStatusesCalls int
+}
+
+func (c *confirmConnection) Commitment() CommitmentLevel {
+	return ConfirmedCommitmentLevel
+}
+
+func (c *confirmConnection) GetLatestBlockhash(_ context.Context, _ GetLatestBlockhashRequest) (*GetLatestBlockhashResponse, error) {
+	return &GetLatestBlockhashResponse{LastValidBlockHeight: 100}, nil
+}
+
+func (c *confirmConnection) SendTransaction(_ context.Context, request SendTransactionRequest) (*SendTransactionResponse, error) {
+	c.sendRequests = append(c.sendRequests, request)
+	return &SendTransactionResponse{TransactionID: "sig1"}, nil
+}
+
+func (c *confirmConnection) GetSignatureStatuses(_ context.Context, request GetSignatureStatusesRequest) (*GetSignatureStatusesResponse, error) {
+	if len(request.Signatures) != 1 || request.Signatures[0] != "sig1" {
+		return nil, errors.New("unexpected signatures")
+	}
+	status := c.statuses[c.getStatusesCalls]
+	c.getStatusesCalls++
+	return &GetSignatureStatusesResponse{Statuses: []*SignatureStatus{status}}, nil
+}
+
+func (c *confirmConnection) GetBlockHeight(_ context.Context, _ GetBlockHeightRequest) (*GetBlockHeightResponse, error) {
+	return &GetBlockHeightResponse{BlockHeight: c.blockHeights[c.getStatusesCalls-1]}, nil
+}
+
+func TestSendAndConfirmTransaction(t *testing.T) {
+	processed := &SignatureStatus{Slot: 10, ConfirmationStatus: ProcessedCommitmentLevel}
+	confirmed := &SignatureStatus{Slot: 10, ConfirmationStatus: ConfirmedCommitmentLevel}
+	finalized := &SignatureStatus{Slot: 10, ConfirmationStatus: FinalizedCommitmentLevel}
+	failed := &SignatureStatus{
+		Slot:               10,
+		ConfirmationStatus: ProcessedCommitmentLevel,
+		Err:                &TransactionError{Type: InsufficientFundsForFeeTransactionError},
+	}
+
+	tests := []struct {
+		name         string
+		request      SendAndConfirmTransactionRequest
+		statuses     []*SignatureStatus
+		blockHeights []uint64
+		want         *SendAndConfirmTransactionResponse
+		wantSends    int
+		wantErrIs    error
+	}{
+		{
+			name:         "confirmed at connection commitment",
+			statuses:     []*SignatureStatus{nil, processed, confirmed},
+			blockHeights: []uint64{90, 91, 92},
+			want: &SendAndConfirmTransactionResponse{
+				TransactionID: "sig1",
+				Status:        *confirmed,
+			},
+			wantSends: 2,
+		},
+		{
+			name: "finalized at requested commitment",
+			request: SendAndConfirmTransactionRequest{
+				CommitmentLevel: FinalizedCommitmentLevel,
+			},
+			statuses:     []*SignatureStatus{confirmed, finalized},
+			blockHeights: []uint64{90, 91},
+			want: &SendAndConfirmTransactionResponse{
+				TransactionID: "sig1",
+				Status:        *finalized,
+			},
+			wantSends: 1,
+		},
+		{
+			name:         "transaction failed",
+			statuses:     []*SignatureStatus{nil, failed},
+			blockHeights: []uint64{90, 91},
+			wantSends:    2,
+			wantErrIs:    failed.Err,
+		},
+		{
+			name:         "block hash expired",
+			statuses:     []*SignatureStatus{nil, nil, processed},
+			blockHeights: []uint64{99, 100, 101},
+			wantSends:    3,
+			wantErrIs:    ErrTransactionExpired,
+		},
+		{
+			name: "block hash expired at given block height",
+			request: SendAndConfirmTransactionRequest{
+				LastValidBlockHeight: 50,
+			},
+			statuses:     []*SignatureStatus{nil},
+			blockHeights: []uint64{51},
+			wantSends:    1,
+			wantErrIs:    ErrTransactionExpired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connection := &confirmConnection{
+				statuses:     tt.statuses,
+				blockHeights: tt.blockHeights,
+			}
+			tt.request.SendTransactionRequest = SendTransactionRequest{Encoding: Base64Encoding}
+			tt.request.PollInterval = time.Millisecond
+			tt.request.ResendInterval = time.Nanosecond
+
+			got, err := SendAndConfirmTransaction(context.Background(), connection, tt.request)
+			if tt.wantErrIs != nil {
+				require.ErrorIs(t, err, tt.wantErrIs)
+			} else {
+				require.Nil(t, err)
+			}
+			require.Equal(t, tt.want, got)
+
+			// resends skip preflight checks
+			require.Len(t, connection.sendRequests, tt.wantSends)
+			require.False(t, connection.sendRequests[0].SkipPreflight)
+			for _, sendRequest := range connection.sendRequests[1:] {
+				require.True(t, sendRequest.SkipPreflight)
+				require.Equal(t, Base64Encoding, sendRequest.Encoding)
+			}
+		})
+	}
+}
+
+func TestSendAndConfirmTransaction_ContextDone(t *testing.T) {
+	connection := &confirmConnection{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := SendAndConfirmTransaction(
+		ctx,
+		connection,
+		SendAndConfirmTransactionRequest{
+			PollInterval: time.Hour,
+		},
+	)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Zero(t, connection.getStatusesCalls)
+}
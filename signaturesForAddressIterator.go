@@ -0,0 +1,85 @@
+package solana
+
+import (
+	"context"
+)
+
+// SignaturesForAddressIterator pages backwards through the entire transaction
+// history of an address, newest transaction first, with successive
+// GetSignaturesForAddress calls.
+//
+// Usage:
+//
+//	iterator := solana.NewSignaturesForAddressIterator(connection, request)
+//	for iterator.Next(ctx) {
+//		signatureInfo := iterator.Signature()
+//		...
+//	}
+//	if err := iterator.Err(); err != nil {
+//		...
+//	}
+type SignaturesForAddressIterator struct {
+	connection Connection
+	request    GetSignaturesForAddressRequest
+	page       []SignatureInfo
+	current    SignatureInfo
+	done       bool
+	err        error
+}
+
+// NewSignaturesForAddressIterator returns a new SignaturesForAddressIterator.
+// Iteration starts from request.Before, if set, and stops at request.Until, if set.
+// request.Limit sets the number of signatures retrieved per call.
+func NewSignaturesForAddressIterator(connection Connection, request GetSignaturesForAddressRequest) *SignaturesForAddressIterator {
+	if request.Limit == 0 {
+		request.Limit = MaxGetSignaturesForAddressLimit
+	}
+	return &SignaturesForAddressIterator{
+		connection: connection,
+		request:    request,
+	}
+}
+
+// Next advances the iterator to the next signature, which is then available
+// from Signature. It returns false when iteration is complete or an error occurs.
+func (i *SignaturesForAddressIterator) Next(ctx context.Context) bool {
+	if i.err != nil {
+		return false
+	}
+
+	// retrieve next page if current one is exhausted
+	if len(i.page) == 0 {
+		if i.done {
+			return false
+		}
+		response, err := i.connection.GetSignaturesForAddress(ctx, i.request)
+		if err != nil {
+			i.err = err
+			return false
+		}
+		i.page = response.Signatures
+
+		// a short page is the last one
+		if uint(len(i.page)) < i.request.Limit {
+			i.done = true
+		}
+		if len(i.page) == 0 {
+			return false
+		}
+		i.request.Before = i.page[len(i.page)-1].Signature
+	}
+
+	i.current = i.page[0]
+	i.page = i.page[1:]
+	return true
+}
+
+// Signature returns the current signature
+func (i *SignaturesForAddressIterator) Signature() SignatureInfo {
+	return i.current
+}
+
+// Err returns the error, if any, that stopped iteration
+func (i *SignaturesForAddressIterator) Err() error {
+	return i.err
+}
@@ -0,0 +1,118 @@
+package solana
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// signaturesConnection is a Connection that serves
+// GetSignaturesForAddress from a fixed history
+type signaturesConnection struct {
+	Connection
+	history  []SignatureInfo
+	failAt   int
+	requests []GetSignaturesForAddressRequest
+}
+
+func (c *signaturesConnection) GetSignaturesForAddress(_ context.Context, request GetSignaturesForAddressRequest) (*GetSignaturesForAddressResponse, error) {
+	c.requests = append(c.requests, request)
+	if len(c.requests) == c.failAt {
+		return nil, errors.New("some err")
+	}
+
+	// find start of page
+	start := 0
+	if request.Before != "" {
+		for start < len(c.history) && c.history[start].Signature != request.Before {
+			start++
+		}
+		start++
+	}
+
+	// fill page up to limit or until signature
+	response := new(GetSignaturesForAddressResponse)
+	for i := start; i < len(c.history) && uint(len(response.Signatures)) < request.Limit; i++ {
+		if c.history[i].Signature == request.Until {
+			break
+		}
+		response.Signatures = append(response.Signatures, c.history[i])
+	}
+	return response, nil
+}
+
+func TestSignaturesForAddressIterator(t *testing.T) {
+	history := []SignatureInfo{
+		{Signature: "sig5", Slot: 5},
+		{Signature: "sig4", Slot: 4},
+		{Signature: "sig3", Slot: 3},
+		{Signature: "sig2", Slot: 2},
+		{Signature: "sig1", Slot: 1},
+	}
+
+	tests := []struct {
+		name           string
+		request        GetSignaturesForAddressRequest
+		failAt         int
+		wantSignatures []string
+		wantBefores    []string
+		wantErr        bool
+	}{
+		{
+			name:           "pages through entire history",
+			request:        GetSignaturesForAddressRequest{Limit: 2},
+			wantSignatures: []string{"sig5", "sig4", "sig3", "sig2", "sig1"},
+			wantBefores:    []string{"", "sig4", "sig2"},
+		},
+		{
+			name:           "final page is empty",
+			request:        GetSignaturesForAddressRequest{Limit: 5},
+			wantSignatures: []string{"sig5", "sig4", "sig3", "sig2", "sig1"},
+			wantBefores:    []string{"", "sig1"},
+		},
+		{
+			name:           "default limit",
+			request:        GetSignaturesForAddressRequest{},
+			wantSignatures: []string{"sig5", "sig4", "sig3", "sig2", "sig1"},
+			wantBefores:    []string{""},
+		},
+		{
+			name:           "before and until",
+			request:        GetSignaturesForAddressRequest{Before: "sig5", Until: "sig1", Limit: 2},
+			wantSignatures: []string{"sig4", "sig3", "sig2"},
+			wantBefores:    []string{"sig5", "sig3"},
+		},
+		{
+			name:           "error retrieving page",
+			request:        GetSignaturesForAddressRequest{Limit: 2},
+			failAt:         2,
+			wantSignatures: []string{"sig5", "sig4"},
+			wantBefores:    []string{"", "sig4"},
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connection := &signaturesConnection{
+				history: history,
+				failAt:  tt.failAt,
+			}
+			iterator := NewSignaturesForAddressIterator(connection, tt.request)
+
+			var signatures []string
+			for iterator.Next(context.Background()) {
+				signatures = append(signatures, iterator.Signature().Signature)
+			}
+			require.Equal(t, tt.wantSignatures, signatures)
+			require.Equalf(t, tt.wantErr, iterator.Err() != nil, "error is nil")
+			require.False(t, iterator.Next(context.Background()))
+
+			var befores []string
+			for _, request := range connection.requests {
+				befores = append(befores, request.Before)
+			}
+			require.Equal(t, tt.wantBefores, befores)
+		})
+	}
+}
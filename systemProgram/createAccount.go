@@ -33,7 +33,7 @@ type createAccountInstructionData struct {
 	Instruction Instruction
 	Lamports    uint64
 	Space       uint64
-	Owner       solana.PublicKey
+	Owner       [32]byte
 }
 
 // CreateAccount creates a Solana system program Instruction
@@ -47,7 +47,7 @@ func CreateAccount(params CreateAccountParams) ([]solana.Instruction, error) {
 			Instruction: CreateAccountInstruction,
 			Lamports:    params.Lamports,
 			Space:       params.Space,
-			Owner:       params.ProgramID,
+			Owner:       params.ProgramID.ToBytes(),
 		},
 	); err != nil {
 		return nil, fmt.Errorf("error encoding create account data: %w", err)
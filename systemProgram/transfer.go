@@ -0,0 +1,65 @@
+package systemProgram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/BRBussy/solgo"
+)
+
+type TransferParams struct {
+	// FromPubkey is the account from which the Lamports will be transferred
+	// Req: [writer, signer]
+	FromPubkey solana.PublicKey
+
+	// ToPubkey is the account to which the Lamports will be transferred
+	// Req: [writer]
+	ToPubkey solana.PublicKey
+
+	// Lamports is the amount of Lamports to transfer
+	Lamports uint64
+}
+
+type transferInstructionData struct {
+	Instruction Instruction
+	Lamports    uint64
+}
+
+// Transfer creates a Solana system program Instruction
+func Transfer(params TransferParams) ([]solana.Instruction, error) {
+	// encode instruction data
+	buf := new(bytes.Buffer)
+	if err := binary.Write(
+		buf,
+		binary.LittleEndian,
+		transferInstructionData{
+			Instruction: TransferInstruction,
+			Lamports:    params.Lamports,
+		},
+	); err != nil {
+		return nil, fmt.Errorf("error encoding transfer data: %w", err)
+	}
+
+	// construct and return instruction
+	return []solana.Instruction{
+		{
+			InstructionAccountMeta: []solana.InstructionAccountMeta{
+				// 1st
+				// Addresses requiring signatures are 1st, and in the following order:
+				//
+				// those that require write access
+				{PubKey: params.FromPubkey, IsSigner: true, IsWritable: true},
+				// those that require read-only access
+
+				// 2nd
+				// Addresses not requiring signatures are 2nd, and in the following order:
+				//
+				// those that require write access
+				{PubKey: params.ToPubkey, IsWritable: true},
+				// those that require read-only access
+			},
+			ProgramIDPubKey: ID,
+			Data:            buf.Bytes(),
+		},
+	}, nil
+}
@@ -0,0 +1,36 @@
+package systemProgram
+
+import (
+	"github.com/BRBussy/solgo"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestTransfer(t *testing.T) {
+	from := solana.MustNewRandomKeypair().PublicKey
+	to := solana.MustNewRandomKeypair().PublicKey
+
+	instructions, err := Transfer(TransferParams{
+		FromPubkey: from,
+		ToPubkey:   to,
+		Lamports:   0x0102030405060708,
+	})
+	require.Nil(t, err)
+	require.Equal(
+		t,
+		[]solana.Instruction{
+			{
+				InstructionAccountMeta: []solana.InstructionAccountMeta{
+					{PubKey: from, IsSigner: true, IsWritable: true},
+					{PubKey: to, IsWritable: true},
+				},
+				ProgramIDPubKey: ID,
+				Data: []byte{
+					2, 0, 0, 0, // transfer instruction, u32
+					8, 7, 6, 5, 4, 3, 2, 1, // lamports, u64
+				},
+			},
+		},
+		instructions,
+	)
+}
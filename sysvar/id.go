@@ -0,0 +1,20 @@
+// Package sysvar provides the IDs of the Solana sysvar accounts.
+// See sysvar definitions here:
+// https://docs.solana.com/developing/runtime-facilities/sysvars
+package sysvar
+
+import solana "github.com/BRBussy/solgo"
+
+var (
+	// ClockID is the ID of the Clock sysvar account
+	ClockID = solana.NewPublicKeyFromBase58String("SysvarC1ock11111111111111111111111111111111")
+
+	// RentID is the ID of the Rent sysvar account
+	RentID = solana.NewPublicKeyFromBase58String("SysvarRent111111111111111111111111111111111")
+
+	// SlotHashesID is the ID of the SlotHashes sysvar account
+	SlotHashesID = solana.NewPublicKeyFromBase58String("SysvarS1otHashes111111111111111111111111111")
+
+	// InstructionsID is the ID of the Instructions sysvar account
+	InstructionsID = solana.NewPublicKeyFromBase58String("Sysvar1nstructions1111111111111111111111111")
+)
@@ -1,5 +1,17 @@
 package solana
 
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"github.com/BRBussy/solgo/internal/pkg/encoding"
+	"github.com/btcsuite/btcutil/base58"
+)
+
+// MaxTransactionSize is the maximum size in bytes of a serialised Transaction.
+// This is the IPv6 minimum MTU less the space required for headers.
+const MaxTransactionSize = 1232
+
 // Transaction is a Solana blockchain transaction.
 // Learn more at: https://docs.solana.com/developing/programming-model/transactions
 type Transaction struct {
@@ -7,6 +19,13 @@ type Transaction struct {
 	// Each Digital Signature is in the ed25519 binary format and consumes 64 bytes.
 	signatures Signatures // of Signatures
 
+	// feePayer is the account that pays the fee for the transaction.
+	// If not set the first signer of the first instruction is used.
+	feePayer PublicKey
+
+	// recentBlockHash is a base58 encoded recent block hash
+	recentBlockHash string
+
 	// instructions is a list of Instructions.
 	// Each Digital Signature is in the ed25519 binary format and consumes 64 bytes.
 	instructions Instructions // of Instructions
@@ -32,44 +51,148 @@ func (t *Transaction) AddInstructions(i ...Instruction) error {
 	return nil
 }
 
+// SetFeePayer sets the account that will pay the fee for the Transaction.
+// An error will be returned if the Transaction contains Signatures.
+func (t *Transaction) SetFeePayer(feePayer PublicKey) error {
+	if len(t.signatures) > 0 {
+		return ErrTransactionAlreadySigned
+	}
+	t.feePayer = feePayer
+	return nil
+}
+
+// SetRecentBlockHash sets the base58 encoded recent block hash on the Transaction.
+// An error will be returned if the Transaction contains Signatures.
+func (t *Transaction) SetRecentBlockHash(recentBlockHash string) error {
+	if len(t.signatures) > 0 {
+		return ErrTransactionAlreadySigned
+	}
+	t.recentBlockHash = recentBlockHash
+	return nil
+}
+
+// Message compiles the Transaction into the Message that is signed.
+func (t *Transaction) Message() (*Message, error) {
+	feePayer := t.feePayer
+	if len(feePayer.PublicKey) == 0 {
+		// fee payer not set, use first signer
+		for _, instruction := range t.instructions {
+			for _, meta := range instruction.InstructionAccountMeta {
+				if meta.IsSigner {
+					feePayer = meta.PubKey
+					break
+				}
+			}
+			if len(feePayer.PublicKey) != 0 {
+				break
+			}
+		}
+	}
+	if len(feePayer.PublicKey) == 0 {
+		return nil, ErrNoFeePayer
+	}
+
+	return compileMessage(feePayer, t.recentBlockHash, t.instructions)
+}
+
 // Sign signs the Transaction with given PrivateKey(s) and appends
 // a signature to a list of signatures held on the Transaction.
+// An error will be returned if any of the PrivateKey(s) are not
+// required to sign the Transaction.
 func (t *Transaction) Sign(pvtKeys ...PrivateKey) error {
+	// compile and serialise message
+	message, err := t.Message()
+	if err != nil {
+		return fmt.Errorf("error compiling message: %w", err)
+	}
+	messageBytes, err := message.ToBytes()
+	if err != nil {
+		return fmt.Errorf("error serialising message: %w", err)
+	}
+
+	// prepare signature slots if this is the first signing
+	if len(t.signatures) != int(message.Header.NumRequiredSignatures) {
+		t.signatures = make(Signatures, message.Header.NumRequiredSignatures)
+	}
+
+	// sign with each key in its slot
+	for _, pvtKey := range pvtKeys {
+		pubKey := pvtKey.PublicKey().ToBase58()
+		signed := false
+		for i := 0; i < int(message.Header.NumRequiredSignatures); i++ {
+			if message.AccountKeys[i].ToBase58() != pubKey {
+				continue
+			}
+			copy(t.signatures[i][:], ed25519.Sign(pvtKey.PrivateKey, messageBytes))
+			signed = true
+			break
+		}
+		if !signed {
+			return fmt.Errorf("%s: %w", pubKey, ErrUnexpectedSigner)
+		}
+	}
+
 	return nil
 }
 
-func (t *Transaction) ToBase58(recentBlockHash [32]byte) (string, error) {
-	messageAccountAddresses := []byte{}
-
-	// prepare compiled transaction
-	compiledTxn := make([]byte, 0)
-	for _, s := range [][]byte{
-		// [1.] Compact array of signatures
-		t.signatures.Compact().Data,
-
-		// [2.] Message
-		// [2.1] Header
-		{
-			// no. of required signatures in the contained transaction
-			0x00,
-			// no. of 'signed for' accounts that are read only
-			0x00,
-			// no. of read-only account addresses not requiring signatures
-			0x00,
-		},
-		// [2.2] Account addresses
-		messageAccountAddresses,
-		// [2.3] Recent blockhash
-		recentBlockHash[:],
-		// [2.4] compact array of instructions
-		t.instructions.Compact().Data,
-	} {
-		compiledTxn = append(compiledTxn, s...)
-	}
-
-	return "", nil
+// Signature returns the first Signature on the Transaction, which is used to identify
+// the Transaction, as a base58 encoded string - aka. transaction id.
+// An empty string is returned if the Transaction has not been signed.
+func (t *Transaction) Signature() string {
+	if len(t.signatures) == 0 {
+		return ""
+	}
+	return base58.Encode(t.signatures[0].Bytes())
 }
 
+// ToBytes serialises the Transaction into the Solana wire format.
+// Zero value signatures are included for any signatures that are
+// required but have not yet been provided.
+func (t *Transaction) ToBytes() ([]byte, error) {
+	// compile and serialise message
+	message, err := t.Message()
+	if err != nil {
+		return nil, fmt.Errorf("error compiling message: %w", err)
+	}
+	messageBytes, err := message.ToBytes()
+	if err != nil {
+		return nil, fmt.Errorf("error serialising message: %w", err)
+	}
+
+	// prepare signatures
+	signatures := t.signatures
+	if len(signatures) != int(message.Header.NumRequiredSignatures) {
+		signatures = make(Signatures, message.Header.NumRequiredSignatures)
+	}
+
+	// [1.] Compact array of signatures
+	compiledTxn := encoding.EncodeCompactU16(uint16(len(signatures)))
+	compiledTxn = append(compiledTxn, signatures.Compact().Data...)
+
+	// [2.] Message
+	compiledTxn = append(compiledTxn, messageBytes...)
+
+	if len(compiledTxn) > MaxTransactionSize {
+		return nil, fmt.Errorf("%d bytes: %w", len(compiledTxn), ErrTransactionTooLarge)
+	}
+
+	return compiledTxn, nil
+}
+
+// ToBase58 serialises the Transaction and base58 encodes the result
+func (t *Transaction) ToBase58() (string, error) {
+	compiledTxn, err := t.ToBytes()
+	if err != nil {
+		return "", err
+	}
+	return base58.Encode(compiledTxn), nil
+}
+
+// ToBase64 serialises the Transaction and base64 encodes the result
 func (t *Transaction) ToBase64() (string, error) {
-	return "", nil
+	compiledTxn, err := t.ToBytes()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(compiledTxn), nil
 }
@@ -0,0 +1,306 @@
+package solana
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TransactionErrorType is the type of a TransactionError
+type TransactionErrorType string
+
+const (
+	AccountInUseTransactionError                          TransactionErrorType = "AccountInUse"
+	AccountLoadedTwiceTransactionError                    TransactionErrorType = "AccountLoadedTwice"
+	AccountNotFoundTransactionError                       TransactionErrorType = "AccountNotFound"
+	ProgramAccountNotFoundTransactionError                TransactionErrorType = "ProgramAccountNotFound"
+	InsufficientFundsForFeeTransactionError               TransactionErrorType = "InsufficientFundsForFee"
+	InvalidAccountForFeeTransactionError                  TransactionErrorType = "InvalidAccountForFee"
+	AlreadyProcessedTransactionError                      TransactionErrorType = "AlreadyProcessed"
+	BlockhashNotFoundTransactionError                     TransactionErrorType = "BlockhashNotFound"
+	InstructionErrorTransactionError                      TransactionErrorType = "InstructionError"
+	CallChainTooDeepTransactionError                      TransactionErrorType = "CallChainTooDeep"
+	MissingSignatureForFeeTransactionError                TransactionErrorType = "MissingSignatureForFee"
+	InvalidAccountIndexTransactionError                   TransactionErrorType = "InvalidAccountIndex"
+	SignatureFailureTransactionError                      TransactionErrorType = "SignatureFailure"
+	InvalidProgramForExecutionTransactionError            TransactionErrorType = "InvalidProgramForExecution"
+	SanitizeFailureTransactionError                       TransactionErrorType = "SanitizeFailure"
+	ClusterMaintenanceTransactionError                    TransactionErrorType = "ClusterMaintenance"
+	AccountBorrowOutstandingTransactionError              TransactionErrorType = "AccountBorrowOutstanding"
+	WouldExceedMaxBlockCostLimitTransactionError          TransactionErrorType = "WouldExceedMaxBlockCostLimit"
+	UnsupportedVersionTransactionError                    TransactionErrorType = "UnsupportedVersion"
+	InvalidWritableAccountTransactionError                TransactionErrorType = "InvalidWritableAccount"
+	WouldExceedMaxAccountCostLimitTransactionError        TransactionErrorType = "WouldExceedMaxAccountCostLimit"
+	WouldExceedAccountDataBlockLimitTransactionError      TransactionErrorType = "WouldExceedAccountDataBlockLimit"
+	TooManyAccountLocksTransactionError                   TransactionErrorType = "TooManyAccountLocks"
+	AddressLookupTableNotFoundTransactionError            TransactionErrorType = "AddressLookupTableNotFound"
+	InvalidAddressLookupTableOwnerTransactionError        TransactionErrorType = "InvalidAddressLookupTableOwner"
+	InvalidAddressLookupTableDataTransactionError         TransactionErrorType = "InvalidAddressLookupTableData"
+	InvalidAddressLookupTableIndexTransactionError        TransactionErrorType = "InvalidAddressLookupTableIndex"
+	InvalidRentPayingAccountTransactionError              TransactionErrorType = "InvalidRentPayingAccount"
+	WouldExceedMaxVoteCostLimitTransactionError           TransactionErrorType = "WouldExceedMaxVoteCostLimit"
+	WouldExceedAccountDataTotalLimitTransactionError      TransactionErrorType = "WouldExceedAccountDataTotalLimit"
+	DuplicateInstructionTransactionError                  TransactionErrorType = "DuplicateInstruction"
+	InsufficientFundsForRentTransactionError              TransactionErrorType = "InsufficientFundsForRent"
+	MaxLoadedAccountsDataSizeExceededTransactionError     TransactionErrorType = "MaxLoadedAccountsDataSizeExceeded"
+	InvalidLoadedAccountsDataSizeLimitTransactionError    TransactionErrorType = "InvalidLoadedAccountsDataSizeLimit"
+	ResanitizationNeededTransactionError                  TransactionErrorType = "ResanitizationNeeded"
+	ProgramExecutionTemporarilyRestrictedTransactionError TransactionErrorType = "ProgramExecutionTemporarilyRestricted"
+	UnbalancedTransactionTransactionError                 TransactionErrorType = "UnbalancedTransaction"
+	ProgramCacheHitMaxLimitTransactionError               TransactionErrorType = "ProgramCacheHitMaxLimit"
+)
+
+// TransactionError is an error with which a transaction failed.
+// Use errors.As to inspect the InstructionError of a failed instruction.
+type TransactionError struct {
+	Type TransactionErrorType
+
+	// InstructionError is set if Type is InstructionErrorTransactionError
+	InstructionError *InstructionError
+
+	// Index is the index of the duplicate instruction if Type is DuplicateInstructionTransactionError,
+	// or the index of the account if Type is InsufficientFundsForRentTransactionError
+	// or ProgramExecutionTemporarilyRestrictedTransactionError
+	Index *uint8
+}
+
+func (e *TransactionError) Error() string {
+	switch {
+	case e.InstructionError != nil:
+		return e.InstructionError.Error()
+	case e.Index != nil:
+		return fmt.Sprintf("%s(%d)", e.Type, *e.Index)
+	default:
+		return string(e.Type)
+	}
+}
+
+// Unwrap returns the InstructionError, if any
+func (e *TransactionError) Unwrap() error {
+	if e.InstructionError == nil {
+		return nil
+	}
+	return e.InstructionError
+}
+
+// UnmarshalJSON implements json.Unmarshaler for TransactionError, which is
+// given as either the name of the variant, or an object holding the variant's value
+// keyed by its name, e.g. {"InstructionError": [0, {"Custom": 1}]}
+func (e *TransactionError) UnmarshalJSON(data []byte) error {
+	// variants without a value are given as a string
+	if err := json.Unmarshal(data, &e.Type); err == nil {
+		return nil
+	}
+
+	var variant map[string]json.RawMessage
+	if err := json.Unmarshal(data, &variant); err != nil {
+		return err
+	}
+	if len(variant) != 1 {
+		return fmt.Errorf("transaction error %s: %w", string(data), ErrUnexpectedResponse)
+	}
+	for name, value := range variant {
+		e.Type = TransactionErrorType(name)
+		switch e.Type {
+		case InstructionErrorTransactionError:
+			e.InstructionError = new(InstructionError)
+			if err := json.Unmarshal(value, e.InstructionError); err != nil {
+				return err
+			}
+
+		case DuplicateInstructionTransactionError:
+			e.Index = new(uint8)
+			if err := json.Unmarshal(value, e.Index); err != nil {
+				return err
+			}
+
+		case InsufficientFundsForRentTransactionError, ProgramExecutionTemporarilyRestrictedTransactionError:
+			account := new(
+				struct {
+					AccountIndex uint8 `json:"account_index"`
+				},
+			)
+			if err := json.Unmarshal(value, account); err != nil {
+				return err
+			}
+			e.Index = &account.AccountIndex
+		}
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler for TransactionError
+func (e TransactionError) MarshalJSON() ([]byte, error) {
+	switch {
+	case e.InstructionError != nil:
+		return json.Marshal(map[TransactionErrorType]*InstructionError{e.Type: e.InstructionError})
+	case e.Index != nil && e.Type == DuplicateInstructionTransactionError:
+		return json.Marshal(map[TransactionErrorType]uint8{e.Type: *e.Index})
+	case e.Index != nil:
+		return json.Marshal(map[TransactionErrorType]map[string]uint8{e.Type: {"account_index": *e.Index}})
+	default:
+		return json.Marshal(string(e.Type))
+	}
+}
+
+// InstructionError is an error with which an instruction of a transaction failed.
+// Err is an InstructionErrorType, a CustomInstructionError returned by the
+// program or a BorshIOInstructionError.
+type InstructionError struct {
+	// Index is the index of the instruction that failed
+	Index uint8
+	Err   error
+}
+
+func (e *InstructionError) Error() string {
+	return fmt.Sprintf("error processing instruction %d: %s", e.Index, e.Err)
+}
+
+// Unwrap returns Err
+func (e *InstructionError) Unwrap() error {
+	return e.Err
+}
+
+// UnmarshalJSON implements json.Unmarshaler for InstructionError,
+// which is given as an [index, error] pair
+func (e *InstructionError) UnmarshalJSON(data []byte) error {
+	var pair []json.RawMessage
+	if err := json.Unmarshal(data, &pair); err != nil {
+		return err
+	}
+	if len(pair) != 2 {
+		return fmt.Errorf("instruction error %s: %w", string(data), ErrUnexpectedResponse)
+	}
+	if err := json.Unmarshal(pair[0], &e.Index); err != nil {
+		return err
+	}
+
+	// variants without a value are given as a string
+	var errType InstructionErrorType
+	if err := json.Unmarshal(pair[1], &errType); err == nil {
+		e.Err = errType
+		return nil
+	}
+
+	var variant map[string]json.RawMessage
+	if err := json.Unmarshal(pair[1], &variant); err != nil {
+		return err
+	}
+	if len(variant) != 1 {
+		return fmt.Errorf("instruction error %s: %w", string(pair[1]), ErrUnexpectedResponse)
+	}
+	for name, value := range variant {
+		switch InstructionErrorType(name) {
+		case CustomInstructionErrorType:
+			var code CustomInstructionError
+			if err := json.Unmarshal(value, &code); err != nil {
+				return err
+			}
+			e.Err = code
+
+		case BorshIOErrorInstructionErrorType:
+			var message BorshIOInstructionError
+			if err := json.Unmarshal(value, &message); err != nil {
+				return err
+			}
+			e.Err = message
+
+		default:
+			e.Err = InstructionErrorType(name)
+		}
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler for InstructionError
+func (e InstructionError) MarshalJSON() ([]byte, error) {
+	var err interface{}
+	switch typedErr := e.Err.(type) {
+	case CustomInstructionError:
+		err = map[InstructionErrorType]uint32{CustomInstructionErrorType: uint32(typedErr)}
+	case BorshIOInstructionError:
+		err = map[InstructionErrorType]string{BorshIOErrorInstructionErrorType: string(typedErr)}
+	case InstructionErrorType:
+		err = typedErr
+	default:
+		return nil, fmt.Errorf("instruction error of type %T: %w", e.Err, ErrUnexpectedInstructionError)
+	}
+	return json.Marshal([]interface{}{e.Index, err})
+}
+
+// InstructionErrorType is the type of an error with which an instruction failed
+type InstructionErrorType string
+
+const (
+	GenericErrorInstructionErrorType                           InstructionErrorType = "GenericError"
+	InvalidArgumentInstructionErrorType                        InstructionErrorType = "InvalidArgument"
+	InvalidInstructionDataInstructionErrorType                 InstructionErrorType = "InvalidInstructionData"
+	InvalidAccountDataInstructionErrorType                     InstructionErrorType = "InvalidAccountData"
+	AccountDataTooSmallInstructionErrorType                    InstructionErrorType = "AccountDataTooSmall"
+	InsufficientFundsInstructionErrorType                      InstructionErrorType = "InsufficientFunds"
+	IncorrectProgramIDInstructionErrorType                     InstructionErrorType = "IncorrectProgramId"
+	MissingRequiredSignatureInstructionErrorType               InstructionErrorType = "MissingRequiredSignature"
+	AccountAlreadyInitializedInstructionErrorType              InstructionErrorType = "AccountAlreadyInitialized"
+	UninitializedAccountInstructionErrorType                   InstructionErrorType = "UninitializedAccount"
+	UnbalancedInstructionInstructionErrorType                  InstructionErrorType = "UnbalancedInstruction"
+	ModifiedProgramIDInstructionErrorType                      InstructionErrorType = "ModifiedProgramId"
+	ExternalAccountLamportSpendInstructionErrorType            InstructionErrorType = "ExternalAccountLamportSpend"
+	ExternalAccountDataModifiedInstructionErrorType            InstructionErrorType = "ExternalAccountDataModified"
+	ReadonlyLamportChangeInstructionErrorType                  InstructionErrorType = "ReadonlyLamportChange"
+	ReadonlyDataModifiedInstructionErrorType                   InstructionErrorType = "ReadonlyDataModified"
+	DuplicateAccountIndexInstructionErrorType                  InstructionErrorType = "DuplicateAccountIndex"
+	ExecutableModifiedInstructionErrorType                     InstructionErrorType = "ExecutableModified"
+	RentEpochModifiedInstructionErrorType                      InstructionErrorType = "RentEpochModified"
+	NotEnoughAccountKeysInstructionErrorType                   InstructionErrorType = "NotEnoughAccountKeys"
+	AccountDataSizeChangedInstructionErrorType                 InstructionErrorType = "AccountDataSizeChanged"
+	AccountNotExecutableInstructionErrorType                   InstructionErrorType = "AccountNotExecutable"
+	AccountBorrowFailedInstructionErrorType                    InstructionErrorType = "AccountBorrowFailed"
+	AccountBorrowOutstandingInstructionErrorType               InstructionErrorType = "AccountBorrowOutstanding"
+	DuplicateAccountOutOfSyncInstructionErrorType              InstructionErrorType = "DuplicateAccountOutOfSync"
+	CustomInstructionErrorType                                 InstructionErrorType = "Custom"
+	InvalidErrorInstructionErrorType                           InstructionErrorType = "InvalidError"
+	ExecutableDataModifiedInstructionErrorType                 InstructionErrorType = "ExecutableDataModified"
+	ExecutableLamportChangeInstructionErrorType                InstructionErrorType = "ExecutableLamportChange"
+	ExecutableAccountNotRentExemptInstructionErrorType         InstructionErrorType = "ExecutableAccountNotRentExempt"
+	UnsupportedProgramIDInstructionErrorType                   InstructionErrorType = "UnsupportedProgramId"
+	CallDepthInstructionErrorType                              InstructionErrorType = "CallDepth"
+	MissingAccountInstructionErrorType                         InstructionErrorType = "MissingAccount"
+	ReentrancyNotAllowedInstructionErrorType                   InstructionErrorType = "ReentrancyNotAllowed"
+	MaxSeedLengthExceededInstructionErrorType                  InstructionErrorType = "MaxSeedLengthExceeded"
+	InvalidSeedsInstructionErrorType                           InstructionErrorType = "InvalidSeeds"
+	InvalidReallocInstructionErrorType                         InstructionErrorType = "InvalidRealloc"
+	ComputationalBudgetExceededInstructionErrorType            InstructionErrorType = "ComputationalBudgetExceeded"
+	PrivilegeEscalationInstructionErrorType                    InstructionErrorType = "PrivilegeEscalation"
+	ProgramEnvironmentSetupFailureInstructionErrorType         InstructionErrorType = "ProgramEnvironmentSetupFailure"
+	ProgramFailedToCompleteInstructionErrorType                InstructionErrorType = "ProgramFailedToComplete"
+	ProgramFailedToCompileInstructionErrorType                 InstructionErrorType = "ProgramFailedToCompile"
+	ImmutableInstructionErrorType                              InstructionErrorType = "Immutable"
+	IncorrectAuthorityInstructionErrorType                     InstructionErrorType = "IncorrectAuthority"
+	BorshIOErrorInstructionErrorType                           InstructionErrorType = "BorshIoError"
+	AccountNotRentExemptInstructionErrorType                   InstructionErrorType = "AccountNotRentExempt"
+	InvalidAccountOwnerInstructionErrorType                    InstructionErrorType = "InvalidAccountOwner"
+	ArithmeticOverflowInstructionErrorType                     InstructionErrorType = "ArithmeticOverflow"
+	UnsupportedSysvarInstructionErrorType                      InstructionErrorType = "UnsupportedSysvar"
+	IllegalOwnerInstructionErrorType                           InstructionErrorType = "IllegalOwner"
+	MaxAccountsDataAllocationsExceededInstructionErrorType     InstructionErrorType = "MaxAccountsDataAllocationsExceeded"
+	MaxAccountsExceededInstructionErrorType                    InstructionErrorType = "MaxAccountsExceeded"
+	MaxInstructionTraceLengthExceededInstructionErrorType      InstructionErrorType = "MaxInstructionTraceLengthExceeded"
+	BuiltinProgramsMustConsumeComputeUnitsInstructionErrorType InstructionErrorType = "BuiltinProgramsMustConsumeComputeUnits"
+)
+
+func (t InstructionErrorType) Error() string {
+	return string(t)
+}
+
+// CustomInstructionError is a program specific error code returned by a program
+type CustomInstructionError uint32
+
+func (c CustomInstructionError) Error() string {
+	return fmt.Sprintf("custom program error: 0x%x", uint32(c))
+}
+
+// BorshIOInstructionError is an error serialising or deserialising data with Borsh
+type BorshIOInstructionError string
+
+func (b BorshIOInstructionError) Error() string {
+	return "borsh io error: " + string(b)
+}
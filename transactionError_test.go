@@ -0,0 +1,126 @@
+package solana
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestTransactionError_JSON(t *testing.T) {
+	index := uint8(2)
+
+	tests := []struct {
+		name      string
+		json      string
+		want      TransactionError
+		wantError string
+		wantErr   bool
+	}{
+		{
+			name:      "variant without value",
+			json:      `"BlockhashNotFound"`,
+			want:      TransactionError{Type: BlockhashNotFoundTransactionError},
+			wantError: "BlockhashNotFound",
+		},
+		{
+			name: "instruction error without value",
+			json: `{"InstructionError":[1,"InvalidAccountData"]}`,
+			want: TransactionError{
+				Type: InstructionErrorTransactionError,
+				InstructionError: &InstructionError{
+					Index: 1,
+					Err:   InvalidAccountDataInstructionErrorType,
+				},
+			},
+			wantError: "error processing instruction 1: InvalidAccountData",
+		},
+		{
+			name: "custom instruction error",
+			json: `{"InstructionError":[0,{"Custom":6000}]}`,
+			want: TransactionError{
+				Type: InstructionErrorTransactionError,
+				InstructionError: &InstructionError{
+					Index: 0,
+					Err:   CustomInstructionError(6000),
+				},
+			},
+			wantError: "error processing instruction 0: custom program error: 0x1770",
+		},
+		{
+			name: "borsh io instruction error",
+			json: `{"InstructionError":[3,{"BorshIoError":"Unexpected length of input"}]}`,
+			want: TransactionError{
+				Type: InstructionErrorTransactionError,
+				InstructionError: &InstructionError{
+					Index: 3,
+					Err:   BorshIOInstructionError("Unexpected length of input"),
+				},
+			},
+			wantError: "error processing instruction 3: borsh io error: Unexpected length of input",
+		},
+		{
+			name: "duplicate instruction",
+			json: `{"DuplicateInstruction":2}`,
+			want: TransactionError{
+				Type:  DuplicateInstructionTransactionError,
+				Index: &index,
+			},
+			wantError: "DuplicateInstruction(2)",
+		},
+		{
+			name: "insufficient funds for rent",
+			json: `{"InsufficientFundsForRent":{"account_index":2}}`,
+			want: TransactionError{
+				Type:  InsufficientFundsForRentTransactionError,
+				Index: &index,
+			},
+			wantError: "InsufficientFundsForRent(2)",
+		},
+		{
+			name:    "invalid instruction error",
+			json:    `{"InstructionError":[0]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid variant",
+			json:    `{"AccountInUse":null,"AccountNotFound":null}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got TransactionError
+			err := json.Unmarshal([]byte(tt.json), &got)
+			require.Equalf(t, tt.wantErr, err != nil, "error is nil")
+			if tt.wantErr {
+				return
+			}
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantError, got.Error())
+
+			// should marshal back to the same json
+			data, err := json.Marshal(got)
+			require.Nil(t, err)
+			require.JSONEq(t, tt.json, string(data))
+		})
+	}
+}
+
+func TestTransactionError_As(t *testing.T) {
+	var txnErr *TransactionError
+	require.Nil(t, json.Unmarshal([]byte(`{"InstructionError":[1,{"Custom":1}]}`), &txnErr))
+	err := error(txnErr)
+
+	var instructionErr *InstructionError
+	require.True(t, errors.As(err, &instructionErr))
+	require.Equal(t, uint8(1), instructionErr.Index)
+
+	var custom CustomInstructionError
+	require.True(t, errors.As(err, &custom))
+	require.Equal(t, CustomInstructionError(1), custom)
+
+	require.False(t, errors.Is(err, InvalidArgumentInstructionErrorType))
+	require.Nil(t, json.Unmarshal([]byte(`{"InstructionError":[0,"InvalidArgument"]}`), &txnErr))
+	require.True(t, errors.Is(txnErr, InvalidArgumentInstructionErrorType))
+}
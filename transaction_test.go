@@ -0,0 +1,195 @@
+package solana
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"github.com/BRBussy/solgo/internal/pkg/encoding"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestTransaction_Message(t *testing.T) {
+	payer := MustNewRandomKeypair()
+	signer := MustNewRandomKeypair()
+	writable := MustNewRandomKeypair()
+	readonly := MustNewRandomKeypair()
+	programID := MustNewRandomKeypair()
+	blockHash := base58.Encode(make([]byte, 32))
+
+	tests := []struct {
+		name         string
+		feePayer     *PublicKey
+		instructions []Instruction
+		want         *Message
+		wantErr      error
+	}{
+		{
+			name:     "accounts ordered and de-duplicated",
+			feePayer: &payer.PublicKey,
+			instructions: []Instruction{
+				{
+					InstructionAccountMeta: []InstructionAccountMeta{
+						{PubKey: readonly.PublicKey},
+						{PubKey: writable.PublicKey, IsWritable: true},
+						{PubKey: signer.PublicKey, IsSigner: true},
+					},
+					ProgramIDPubKey: programID.PublicKey,
+					Data:            []byte{1, 2, 3},
+				},
+				{
+					InstructionAccountMeta: []InstructionAccountMeta{
+						{PubKey: readonly.PublicKey},
+						{PubKey: payer.PublicKey, IsSigner: true},
+					},
+					ProgramIDPubKey: programID.PublicKey,
+				},
+			},
+			want: &Message{
+				Header: MessageHeader{
+					NumRequiredSignatures:       2,
+					NumReadonlySignedAccounts:   1,
+					NumReadonlyUnsignedAccounts: 2,
+				},
+				AccountKeys: []PublicKey{
+					payer.PublicKey,
+					signer.PublicKey,
+					writable.PublicKey,
+					readonly.PublicKey,
+					programID.PublicKey,
+				},
+				RecentBlockHash: blockHash,
+				Instructions: []CompiledInstruction{
+					{ProgramIDIndex: 4, AccountIndices: []uint8{3, 2, 1}, Data: []byte{1, 2, 3}},
+					{ProgramIDIndex: 4, AccountIndices: []uint8{3, 0}},
+				},
+			},
+		},
+		{
+			name: "fee payer defaults to first signer",
+			instructions: []Instruction{
+				{
+					InstructionAccountMeta: []InstructionAccountMeta{
+						{PubKey: writable.PublicKey, IsWritable: true},
+						{PubKey: signer.PublicKey, IsSigner: true},
+					},
+					ProgramIDPubKey: programID.PublicKey,
+				},
+			},
+			want: &Message{
+				Header: MessageHeader{
+					NumRequiredSignatures:       1,
+					NumReadonlySignedAccounts:   0,
+					NumReadonlyUnsignedAccounts: 1,
+				},
+				AccountKeys: []PublicKey{
+					signer.PublicKey,
+					writable.PublicKey,
+					programID.PublicKey,
+				},
+				RecentBlockHash: blockHash,
+				Instructions: []CompiledInstruction{
+					{ProgramIDIndex: 2, AccountIndices: []uint8{1, 0}},
+				},
+			},
+		},
+		{
+			name: "no fee payer",
+			instructions: []Instruction{
+				{ProgramIDPubKey: programID.PublicKey},
+			},
+			wantErr: ErrNoFeePayer,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txn := NewTransaction()
+			require.Nil(t, txn.AddInstructions(tt.instructions...))
+			require.Nil(t, txn.SetRecentBlockHash(blockHash))
+			if tt.feePayer != nil {
+				require.Nil(t, txn.SetFeePayer(*tt.feePayer))
+			}
+			got, err := txn.Message()
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTransaction_Sign(t *testing.T) {
+	payer := MustNewRandomKeypair()
+	signer := MustNewRandomKeypair()
+	programID := MustNewRandomKeypair()
+
+	txn := NewTransaction()
+	require.Nil(t, txn.AddInstructions(Instruction{
+		InstructionAccountMeta: []InstructionAccountMeta{
+			{PubKey: signer.PublicKey, IsSigner: true},
+		},
+		ProgramIDPubKey: programID.PublicKey,
+		Data:            []byte{9, 8, 7},
+	}))
+	require.Nil(t, txn.SetFeePayer(payer.PublicKey))
+	require.Nil(t, txn.SetRecentBlockHash("CSymwgTNX1j3E4qhKfJAUE41nBWEwXufoYryPbkde5RR"))
+
+	// unsigned transactions serialise with empty signatures
+	unsigned, err := txn.ToBytes()
+	require.Nil(t, err)
+	require.Equal(t, byte(2), unsigned[0])
+	require.Equal(t, make([]byte, 128), unsigned[1:129])
+	require.Equal(t, "", txn.Signature())
+
+	// sign with keys in any order
+	require.Nil(t, txn.Sign(signer.PrivateKey, payer.PrivateKey))
+	require.ErrorIs(t, txn.Sign(MustNewRandomKeypair().PrivateKey), ErrUnexpectedSigner)
+	require.ErrorIs(t, txn.SetFeePayer(signer.PublicKey), ErrTransactionAlreadySigned)
+
+	// signatures should verify over the message
+	message, err := txn.Message()
+	require.Nil(t, err)
+	messageBytes, err := message.ToBytes()
+	require.Nil(t, err)
+
+	signed, err := txn.ToBytes()
+	require.Nil(t, err)
+	require.Equal(t, messageBytes, signed[129:])
+	require.True(t, ed25519.Verify(payer.PublicKey.PublicKey, messageBytes, signed[1:65]))
+	require.True(t, ed25519.Verify(signer.PublicKey.PublicKey, messageBytes, signed[65:129]))
+	require.Equal(t, base58.Encode(signed[1:65]), txn.Signature())
+
+	encoded, err := txn.ToBase64()
+	require.Nil(t, err)
+	require.Equal(t, base64.StdEncoding.EncodeToString(signed), encoded)
+}
+
+func TestMessage_ToBytes(t *testing.T) {
+	accountKey := NewPublicKeyFromBytes([32]byte{1})
+	programID := NewPublicKeyFromBytes([32]byte{2})
+	blockHash := [32]byte{3}
+
+	got, err := Message{
+		Header: MessageHeader{
+			NumRequiredSignatures:       1,
+			NumReadonlySignedAccounts:   0,
+			NumReadonlyUnsignedAccounts: 1,
+		},
+		AccountKeys:     []PublicKey{accountKey, programID},
+		RecentBlockHash: base58.Encode(blockHash[:]),
+		Instructions: []CompiledInstruction{
+			{ProgramIDIndex: 1, AccountIndices: []uint8{0}, Data: make([]byte, 200)},
+		},
+	}.ToBytes()
+	require.Nil(t, err)
+
+	want := []byte{1, 0, 1, 2}
+	want = append(want, accountKey.PublicKey...)
+	want = append(want, programID.PublicKey...)
+	want = append(want, blockHash[:]...)
+	want = append(want, 1, 1, 1, 0)
+	want = append(want, encoding.EncodeCompactU16(200)...)
+	want = append(want, make([]byte, 200)...)
+	require.Equal(t, want, got)
+
+	_, err = Message{RecentBlockHash: "invalid"}.ToBytes()
+	require.ErrorIs(t, err, ErrInvalidBlockHash)
+}
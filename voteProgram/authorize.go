@@ -0,0 +1,64 @@
+package voteProgram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/BRBussy/solgo"
+	"github.com/BRBussy/solgo/sysvar"
+)
+
+type AuthorizeParams struct {
+	// VotePubkey is the vote account whose authority is being changed
+	// Req: [writer]
+	VotePubkey solana.PublicKey
+
+	// AuthorizedPubkey is the current authority of the type given
+	// by VoteAuthorize. Note that the withdraw authority may also be
+	// used to change the voter authority.
+	// Req: [signer]
+	AuthorizedPubkey solana.PublicKey
+
+	// NewAuthorizedPubkey is the public key of the new authority
+	NewAuthorizedPubkey solana.PublicKey
+
+	// VoteAuthorize indicates which authority is being changed
+	VoteAuthorize VoteAuthorize
+}
+
+type authorizeInstructionData struct {
+	Instruction         Instruction
+	NewAuthorizedPubkey [32]byte
+	VoteAuthorize       VoteAuthorize
+}
+
+// Authorize creates a Solana vote program Instruction to change the
+// voter or withdrawer authority of a vote account
+func Authorize(params AuthorizeParams) ([]solana.Instruction, error) {
+	// encode instruction data
+	buf := new(bytes.Buffer)
+	if err := binary.Write(
+		buf,
+		binary.LittleEndian,
+		authorizeInstructionData{
+			Instruction:         AuthorizeInstruction,
+			NewAuthorizedPubkey: params.NewAuthorizedPubkey.ToBytes(),
+			VoteAuthorize:       params.VoteAuthorize,
+		},
+	); err != nil {
+		return nil, fmt.Errorf("error encoding authorize data: %w", err)
+	}
+
+	// construct and return instruction
+	return []solana.Instruction{
+		{
+			InstructionAccountMeta: []solana.InstructionAccountMeta{
+				{PubKey: params.VotePubkey, IsSigner: false, IsWritable: true},
+				{PubKey: sysvar.ClockID, IsSigner: false, IsWritable: false},
+				{PubKey: params.AuthorizedPubkey, IsSigner: true, IsWritable: false},
+			},
+			ProgramIDPubKey: ID,
+			Data:            buf.Bytes(),
+		},
+	}, nil
+}
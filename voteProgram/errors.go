@@ -0,0 +1,8 @@
+package voteProgram
+
+import "errors"
+
+var (
+	ErrUnsupportedStateVersion = errors.New("unsupported vote state version")
+	ErrInvalidStateData        = errors.New("invalid vote state data")
+)
@@ -0,0 +1,10 @@
+// Package voteProgram provides a set of functions for constructing Solana vote program
+// instructions and decoding vote account state.
+// See instruction definitions here:
+// https://github.com/solana-labs/solana/blob/v1.16.0/programs/vote/src/vote_instruction.rs
+package voteProgram
+
+import solana "github.com/BRBussy/solgo"
+
+// ID is the Solana vote program ID
+var ID = solana.NewPublicKeyFromBase58String("Vote111111111111111111111111111111111111111")
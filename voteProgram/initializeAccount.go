@@ -0,0 +1,71 @@
+package voteProgram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/BRBussy/solgo"
+	"github.com/BRBussy/solgo/sysvar"
+)
+
+type InitializeAccountParams struct {
+	// VotePubkey is the vote account to be initialized.
+	// It must already have been created and be owned by the vote program.
+	// Req: [writer]
+	VotePubkey solana.PublicKey
+
+	// NodePubkey is the identity of the validator that will be voting.
+	// Req: [signer]
+	NodePubkey solana.PublicKey
+
+	// AuthorizedVoter is the public key permitted to submit votes
+	AuthorizedVoter solana.PublicKey
+
+	// AuthorizedWithdrawer is the public key permitted to withdraw lamports
+	// from the vote account
+	AuthorizedWithdrawer solana.PublicKey
+
+	// Commission is the percentage (0-100) of rewards taken by the validator
+	Commission uint8
+}
+
+type initializeAccountInstructionData struct {
+	Instruction          Instruction
+	NodePubkey           [32]byte
+	AuthorizedVoter      [32]byte
+	AuthorizedWithdrawer [32]byte
+	Commission           uint8
+}
+
+// InitializeAccount creates a Solana vote program Instruction to initialize a vote account
+func InitializeAccount(params InitializeAccountParams) ([]solana.Instruction, error) {
+	// encode instruction data
+	buf := new(bytes.Buffer)
+	if err := binary.Write(
+		buf,
+		binary.LittleEndian,
+		initializeAccountInstructionData{
+			Instruction:          InitializeAccountInstruction,
+			NodePubkey:           params.NodePubkey.ToBytes(),
+			AuthorizedVoter:      params.AuthorizedVoter.ToBytes(),
+			AuthorizedWithdrawer: params.AuthorizedWithdrawer.ToBytes(),
+			Commission:           params.Commission,
+		},
+	); err != nil {
+		return nil, fmt.Errorf("error encoding initialize account data: %w", err)
+	}
+
+	// construct and return instruction
+	return []solana.Instruction{
+		{
+			InstructionAccountMeta: []solana.InstructionAccountMeta{
+				{PubKey: params.VotePubkey, IsSigner: false, IsWritable: true},
+				{PubKey: sysvar.RentID, IsSigner: false, IsWritable: false},
+				{PubKey: sysvar.ClockID, IsSigner: false, IsWritable: false},
+				{PubKey: params.NodePubkey, IsSigner: true, IsWritable: false},
+			},
+			ProgramIDPubKey: ID,
+			Data:            buf.Bytes(),
+		},
+	}, nil
+}
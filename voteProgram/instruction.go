@@ -0,0 +1,34 @@
+package voteProgram
+
+// Instruction is a Solana vote program Instruction.
+// See rust defs here: https://github.com/solana-labs/solana/blob/v1.16.0/programs/vote/src/vote_instruction.rs
+type Instruction uint32
+
+const (
+	InitializeAccountInstruction Instruction = iota
+	AuthorizeInstruction
+	VoteInstruction
+	WithdrawInstruction
+	UpdateValidatorIdentityInstruction
+	UpdateCommissionInstruction
+	VoteSwitchInstruction
+	AuthorizeCheckedInstruction
+	UpdateVoteStateInstruction
+	UpdateVoteStateSwitchInstruction
+	AuthorizeWithSeedInstruction
+	AuthorizeCheckedWithSeedInstruction
+	CompactUpdateVoteStateInstruction
+	CompactUpdateVoteStateSwitchInstruction
+)
+
+// VoteAuthorize identifies which authority of a vote account is being changed.
+type VoteAuthorize uint32
+
+const (
+	// VoterVoteAuthorize is the authority permitted to submit votes
+	VoterVoteAuthorize VoteAuthorize = iota
+
+	// WithdrawerVoteAuthorize is the authority permitted to withdraw
+	// lamports from the vote account and to change its other authorities
+	WithdrawerVoteAuthorize
+)
@@ -0,0 +1,135 @@
+package voteProgram
+
+import (
+	"github.com/BRBussy/solgo"
+	"github.com/BRBussy/solgo/sysvar"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestAuthorize(t *testing.T) {
+	vote := solana.MustNewRandomKeypair().PublicKey
+	authorized := solana.MustNewRandomKeypair().PublicKey
+	newAuthorized := solana.MustNewRandomKeypair().PublicKey
+	newAuthorizedBytes := newAuthorized.ToBytes()
+
+	instructions, err := Authorize(AuthorizeParams{
+		VotePubkey:          vote,
+		AuthorizedPubkey:    authorized,
+		NewAuthorizedPubkey: newAuthorized,
+		VoteAuthorize:       WithdrawerVoteAuthorize,
+	})
+	require.Nil(t, err)
+	require.Equal(
+		t,
+		[]solana.Instruction{
+			{
+				InstructionAccountMeta: []solana.InstructionAccountMeta{
+					{PubKey: vote, IsWritable: true},
+					{PubKey: sysvar.ClockID},
+					{PubKey: authorized, IsSigner: true},
+				},
+				ProgramIDPubKey: ID,
+				Data: append(
+					append(
+						[]byte{1, 0, 0, 0}, // authorize instruction, u32
+						newAuthorizedBytes[:]...,
+					),
+					1, 0, 0, 0, // withdrawer vote authorize, u32
+				),
+			},
+		},
+		instructions,
+	)
+}
+
+func TestUpdateCommission(t *testing.T) {
+	vote := solana.MustNewRandomKeypair().PublicKey
+	withdrawer := solana.MustNewRandomKeypair().PublicKey
+
+	instructions, err := UpdateCommission(UpdateCommissionParams{
+		VotePubkey:                 vote,
+		AuthorizedWithdrawerPubkey: withdrawer,
+		Commission:                 42,
+	})
+	require.Nil(t, err)
+	require.Equal(
+		t,
+		[]solana.Instruction{
+			{
+				InstructionAccountMeta: []solana.InstructionAccountMeta{
+					{PubKey: vote, IsWritable: true},
+					{PubKey: withdrawer, IsSigner: true},
+				},
+				ProgramIDPubKey: ID,
+				Data: []byte{
+					5, 0, 0, 0, // update commission instruction, u32
+					42, // commission, u8
+				},
+			},
+		},
+		instructions,
+	)
+}
+
+func TestUpdateValidatorIdentity(t *testing.T) {
+	vote := solana.MustNewRandomKeypair().PublicKey
+	node := solana.MustNewRandomKeypair().PublicKey
+	withdrawer := solana.MustNewRandomKeypair().PublicKey
+
+	instructions, err := UpdateValidatorIdentity(UpdateValidatorIdentityParams{
+		VotePubkey:                 vote,
+		NodePubkey:                 node,
+		AuthorizedWithdrawerPubkey: withdrawer,
+	})
+	require.Nil(t, err)
+	require.Equal(
+		t,
+		[]solana.Instruction{
+			{
+				InstructionAccountMeta: []solana.InstructionAccountMeta{
+					{PubKey: vote, IsWritable: true},
+					{PubKey: node, IsSigner: true},
+					{PubKey: withdrawer, IsSigner: true},
+				},
+				ProgramIDPubKey: ID,
+				Data: []byte{
+					4, 0, 0, 0, // update validator identity instruction, u32
+				},
+			},
+		},
+		instructions,
+	)
+}
+
+func TestWithdraw(t *testing.T) {
+	vote := solana.MustNewRandomKeypair().PublicKey
+	withdrawer := solana.MustNewRandomKeypair().PublicKey
+	to := solana.MustNewRandomKeypair().PublicKey
+
+	instructions, err := Withdraw(WithdrawParams{
+		VotePubkey:                 vote,
+		AuthorizedWithdrawerPubkey: withdrawer,
+		ToPubkey:                   to,
+		Lamports:                   0x0102030405060708,
+	})
+	require.Nil(t, err)
+	require.Equal(
+		t,
+		[]solana.Instruction{
+			{
+				InstructionAccountMeta: []solana.InstructionAccountMeta{
+					{PubKey: vote, IsWritable: true},
+					{PubKey: to, IsWritable: true},
+					{PubKey: withdrawer, IsSigner: true},
+				},
+				ProgramIDPubKey: ID,
+				Data: []byte{
+					3, 0, 0, 0, // withdraw instruction, u32
+					8, 7, 6, 5, 4, 3, 2, 1, // lamports, u64
+				},
+			},
+		},
+		instructions,
+	)
+}
@@ -0,0 +1,251 @@
+package voteProgram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/BRBussy/solgo"
+)
+
+// StateVersion is the version of the layout of a serialised VoteState.
+// See rust defs here: https://github.com/solana-labs/solana/blob/v1.16.0/sdk/program/src/vote/state/vote_state_versions.rs
+type StateVersion uint32
+
+const (
+	V0_23_5StateVersion StateVersion = iota
+	V1_14_11StateVersion
+	CurrentStateVersion
+)
+
+// Lockout is a vote cast by the validator along with the
+// number of confirmations it has received.
+type Lockout struct {
+	// Latency is the number of slots between the voted on slot and the
+	// slot in which the vote landed. Only set on CurrentStateVersion.
+	Latency uint8
+
+	// Slot is the slot that was voted on
+	Slot uint64
+
+	// ConfirmationCount is the number of votes that have been stacked on
+	// top of this one, which determines the lockout period.
+	ConfirmationCount uint32
+}
+
+// AuthorizedVoter is the voter authority of a vote account for a given epoch
+type AuthorizedVoter struct {
+	Epoch           uint64
+	AuthorizedVoter solana.PublicKey
+}
+
+// PriorVoter is a previous voter authority along with the
+// epoch range over which it was the authority
+type PriorVoter struct {
+	AuthorizedPubkey solana.PublicKey
+	EpochStart       uint64
+	EpochEnd         uint64
+}
+
+// EpochCredits is the number of credits earned by the vote account in an epoch
+type EpochCredits struct {
+	Epoch           uint64
+	Credits         uint64
+	PreviousCredits uint64
+}
+
+// BlockTimestamp is the most recent timestamp submitted with a vote
+type BlockTimestamp struct {
+	Slot      uint64
+	Timestamp int64
+}
+
+// VoteState is the state held in the data of a vote account
+type VoteState struct {
+	// Version is the layout version from which the VoteState was decoded
+	Version StateVersion
+
+	// NodePubkey is the identity of the validator voting
+	NodePubkey solana.PublicKey
+
+	// AuthorizedWithdrawer is the public key permitted to withdraw
+	// lamports from the vote account
+	AuthorizedWithdrawer solana.PublicKey
+
+	// Commission is the percentage (0-100) of rewards taken by the validator
+	Commission uint8
+
+	// Votes are the votes cast by the validator, oldest first
+	Votes []Lockout
+
+	// RootSlot is the most recent slot which has been rooted, nil if none
+	RootSlot *uint64
+
+	// AuthorizedVoters are the voter authorities of the vote account by epoch
+	AuthorizedVoters []AuthorizedVoter
+
+	// PriorVoters are the previous voter authorities of the vote account, oldest first
+	PriorVoters []PriorVoter
+
+	// EpochCredits is the history of credits earned by the vote account
+	EpochCredits []EpochCredits
+
+	// LastTimestamp is the most recent timestamp submitted with a vote
+	LastTimestamp BlockTimestamp
+}
+
+// priorVotersCapacity is the fixed number of entries held in
+// the serialised circular buffer of prior voters
+const priorVotersCapacity = 32
+
+// DecodeVoteState decodes the given vote account data into a VoteState.
+// The V1_14_11StateVersion and CurrentStateVersion layouts are supported.
+func DecodeVoteState(data []byte) (*VoteState, error) {
+	r := bytes.NewReader(data)
+
+	// read version
+	var version StateVersion
+	if err := binary.Read(r, binary.LittleEndian, &version); err != nil {
+		return nil, fmt.Errorf("error reading vote state version: %w", err)
+	}
+	switch version {
+	case V1_14_11StateVersion, CurrentStateVersion:
+	default:
+		return nil, fmt.Errorf("%d: %w", version, ErrUnsupportedStateVersion)
+	}
+	voteState := VoteState{Version: version}
+
+	// read node pubkey, authorized withdrawer and commission
+	var header struct {
+		NodePubkey           [32]byte
+		AuthorizedWithdrawer [32]byte
+		Commission           uint8
+	}
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("error reading vote state header: %w", err)
+	}
+	voteState.NodePubkey = solana.NewPublicKeyFromBytes(header.NodePubkey)
+	voteState.AuthorizedWithdrawer = solana.NewPublicKeyFromBytes(header.AuthorizedWithdrawer)
+	voteState.Commission = header.Commission
+
+	// read votes
+	noVotes, err := readLength(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading number of votes: %w", err)
+	}
+	voteState.Votes = make([]Lockout, 0, noVotes)
+	for i := uint64(0); i < noVotes; i++ {
+		var vote Lockout
+		if version == CurrentStateVersion {
+			if err := binary.Read(r, binary.LittleEndian, &vote.Latency); err != nil {
+				return nil, fmt.Errorf("error reading vote latency: %w", err)
+			}
+		}
+		if err := binary.Read(r, binary.LittleEndian, &vote.Slot); err != nil {
+			return nil, fmt.Errorf("error reading vote slot: %w", err)
+		}
+		if err := binary.Read(r, binary.LittleEndian, &vote.ConfirmationCount); err != nil {
+			return nil, fmt.Errorf("error reading vote confirmation count: %w", err)
+		}
+		voteState.Votes = append(voteState.Votes, vote)
+	}
+
+	// read root slot
+	var rootSlotSet uint8
+	if err := binary.Read(r, binary.LittleEndian, &rootSlotSet); err != nil {
+		return nil, fmt.Errorf("error reading root slot option: %w", err)
+	}
+	if rootSlotSet == 1 {
+		rootSlot := new(uint64)
+		if err := binary.Read(r, binary.LittleEndian, rootSlot); err != nil {
+			return nil, fmt.Errorf("error reading root slot: %w", err)
+		}
+		voteState.RootSlot = rootSlot
+	}
+
+	// read authorized voters
+	noAuthorizedVoters, err := readLength(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading number of authorized voters: %w", err)
+	}
+	voteState.AuthorizedVoters = make([]AuthorizedVoter, 0, noAuthorizedVoters)
+	for i := uint64(0); i < noAuthorizedVoters; i++ {
+		var authorizedVoter struct {
+			Epoch           uint64
+			AuthorizedVoter [32]byte
+		}
+		if err := binary.Read(r, binary.LittleEndian, &authorizedVoter); err != nil {
+			return nil, fmt.Errorf("error reading authorized voter: %w", err)
+		}
+		voteState.AuthorizedVoters = append(
+			voteState.AuthorizedVoters,
+			AuthorizedVoter{
+				Epoch:           authorizedVoter.Epoch,
+				AuthorizedVoter: solana.NewPublicKeyFromBytes(authorizedVoter.AuthorizedVoter),
+			},
+		)
+	}
+
+	// read prior voters circular buffer
+	var priorVoters struct {
+		Buf [priorVotersCapacity]struct {
+			AuthorizedPubkey [32]byte
+			EpochStart       uint64
+			EpochEnd         uint64
+		}
+		Idx     uint64
+		IsEmpty uint8
+	}
+	if err := binary.Read(r, binary.LittleEndian, &priorVoters); err != nil {
+		return nil, fmt.Errorf("error reading prior voters: %w", err)
+	}
+	voteState.PriorVoters = make([]PriorVoter, 0)
+	if priorVoters.IsEmpty == 0 {
+		// the entry at Idx is the most recently written, so
+		// walk the buffer starting from the entry after it
+		for i := uint64(1); i <= priorVotersCapacity; i++ {
+			entry := priorVoters.Buf[(priorVoters.Idx+i)%priorVotersCapacity]
+			if entry.AuthorizedPubkey == [32]byte{} && entry.EpochStart == 0 && entry.EpochEnd == 0 {
+				// skip unused entries
+				continue
+			}
+			voteState.PriorVoters = append(
+				voteState.PriorVoters,
+				PriorVoter{
+					AuthorizedPubkey: solana.NewPublicKeyFromBytes(entry.AuthorizedPubkey),
+					EpochStart:       entry.EpochStart,
+					EpochEnd:         entry.EpochEnd,
+				},
+			)
+		}
+	}
+
+	// read epoch credits
+	noEpochCredits, err := readLength(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading number of epoch credits: %w", err)
+	}
+	voteState.EpochCredits = make([]EpochCredits, noEpochCredits)
+	if err := binary.Read(r, binary.LittleEndian, voteState.EpochCredits); err != nil {
+		return nil, fmt.Errorf("error reading epoch credits: %w", err)
+	}
+
+	// read last timestamp
+	if err := binary.Read(r, binary.LittleEndian, &voteState.LastTimestamp); err != nil {
+		return nil, fmt.Errorf("error reading last timestamp: %w", err)
+	}
+
+	return &voteState, nil
+}
+
+// readLength reads the u64 length prefix of a serialised collection and
+// ensures that it is not longer than the data remaining in the reader.
+func readLength(r *bytes.Reader) (uint64, error) {
+	var length uint64
+	if err := binary.Read(r, binary.LittleEndian, &length); err != nil {
+		return 0, err
+	}
+	if length > uint64(r.Len()) {
+		return 0, fmt.Errorf("length %d exceeds remaining data: %w", length, ErrInvalidStateData)
+	}
+	return length, nil
+}
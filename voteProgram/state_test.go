@@ -0,0 +1,166 @@
+package voteProgram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/BRBussy/solgo"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDecodeVoteState(t *testing.T) {
+	nodePubkey := solana.MustNewRandomKeypair().PublicKey
+	withdrawer := solana.MustNewRandomKeypair().PublicKey
+	voter := solana.MustNewRandomKeypair().PublicKey
+	priorVoter := solana.MustNewRandomKeypair().PublicKey
+
+	// encodeVoteState builds serialised vote state data in the given version
+	encodeVoteState := func(version StateVersion) []byte {
+		buf := new(bytes.Buffer)
+		write := func(v interface{}) {
+			require.Nil(t, binary.Write(buf, binary.LittleEndian, v))
+		}
+		write(version)
+		write(nodePubkey.ToBytes())
+		write(withdrawer.ToBytes())
+		write(uint8(10))
+
+		// votes
+		write(uint64(2))
+		for _, slot := range []uint64{100, 101} {
+			if version == CurrentStateVersion {
+				write(uint8(1))
+			}
+			write(slot)
+			write(uint32(102 - slot))
+		}
+
+		// root slot
+		write(uint8(1))
+		write(uint64(99))
+
+		// authorized voters
+		write(uint64(1))
+		write(uint64(5))
+		write(voter.ToBytes())
+
+		// prior voters
+		for i := 0; i < priorVotersCapacity; i++ {
+			if i == 0 {
+				write(priorVoter.ToBytes())
+				write(uint64(1))
+				write(uint64(4))
+				continue
+			}
+			write([32]byte{})
+			write(uint64(0))
+			write(uint64(0))
+		}
+		write(uint64(0))
+		write(uint8(0))
+
+		// epoch credits
+		write(uint64(1))
+		write(EpochCredits{Epoch: 5, Credits: 200, PreviousCredits: 100})
+
+		// last timestamp
+		write(BlockTimestamp{Slot: 101, Timestamp: 1650000000})
+
+		return buf.Bytes()
+	}
+
+	rootSlot := uint64(99)
+	wantVoteState := func(version StateVersion, latency uint8) *VoteState {
+		return &VoteState{
+			Version:              version,
+			NodePubkey:           nodePubkey,
+			AuthorizedWithdrawer: withdrawer,
+			Commission:           10,
+			Votes: []Lockout{
+				{Latency: latency, Slot: 100, ConfirmationCount: 2},
+				{Latency: latency, Slot: 101, ConfirmationCount: 1},
+			},
+			RootSlot: &rootSlot,
+			AuthorizedVoters: []AuthorizedVoter{
+				{Epoch: 5, AuthorizedVoter: voter},
+			},
+			PriorVoters: []PriorVoter{
+				{AuthorizedPubkey: priorVoter, EpochStart: 1, EpochEnd: 4},
+			},
+			EpochCredits: []EpochCredits{
+				{Epoch: 5, Credits: 200, PreviousCredits: 100},
+			},
+			LastTimestamp: BlockTimestamp{Slot: 101, Timestamp: 1650000000},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    *VoteState
+		wantErr error
+	}{
+		{
+			name: "success - current version",
+			data: encodeVoteState(CurrentStateVersion),
+			want: wantVoteState(CurrentStateVersion, 1),
+		},
+		{
+			name: "success - v1.14.11 version",
+			data: encodeVoteState(V1_14_11StateVersion),
+			want: wantVoteState(V1_14_11StateVersion, 0),
+		},
+		{
+			name:    "unsupported version",
+			data:    encodeVoteState(V0_23_5StateVersion),
+			wantErr: ErrUnsupportedStateVersion,
+		},
+		{
+			name:    "invalid vote length",
+			data:    append(encodeVoteState(CurrentStateVersion)[:69], 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff),
+			wantErr: ErrInvalidStateData,
+		},
+		{
+			name:    "truncated data",
+			data:    encodeVoteState(CurrentStateVersion)[:100],
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeVoteState(tt.data)
+			if tt.want == nil {
+				require.NotNil(t, err)
+				if tt.wantErr != nil {
+					require.ErrorIs(t, err, tt.wantErr)
+				}
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestInitializeAccount(t *testing.T) {
+	params := InitializeAccountParams{
+		VotePubkey:           solana.MustNewRandomKeypair().PublicKey,
+		NodePubkey:           solana.MustNewRandomKeypair().PublicKey,
+		AuthorizedVoter:      solana.MustNewRandomKeypair().PublicKey,
+		AuthorizedWithdrawer: solana.MustNewRandomKeypair().PublicKey,
+		Commission:           7,
+	}
+	instructions, err := InitializeAccount(params)
+	require.Nil(t, err)
+	require.Len(t, instructions, 1)
+
+	// data should be the instruction followed by VoteInit
+	wantData := []byte{0, 0, 0, 0}
+	wantData = append(wantData, params.NodePubkey.PublicKey...)
+	wantData = append(wantData, params.AuthorizedVoter.PublicKey...)
+	wantData = append(wantData, params.AuthorizedWithdrawer.PublicKey...)
+	wantData = append(wantData, 7)
+	require.Equal(t, wantData, instructions[0].Data)
+	require.Equal(t, ID, instructions[0].ProgramIDPubKey)
+	require.Len(t, instructions[0].InstructionAccountMeta, 4)
+}
@@ -0,0 +1,55 @@
+package voteProgram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/BRBussy/solgo"
+)
+
+type UpdateCommissionParams struct {
+	// VotePubkey is the vote account whose commission is being updated
+	// Req: [writer]
+	VotePubkey solana.PublicKey
+
+	// AuthorizedWithdrawerPubkey is the withdraw authority of the vote account
+	// Req: [signer]
+	AuthorizedWithdrawerPubkey solana.PublicKey
+
+	// Commission is the new percentage (0-100) of rewards taken by the validator
+	Commission uint8
+}
+
+type updateCommissionInstructionData struct {
+	Instruction Instruction
+	Commission  uint8
+}
+
+// UpdateCommission creates a Solana vote program Instruction to update
+// the commission of a vote account
+func UpdateCommission(params UpdateCommissionParams) ([]solana.Instruction, error) {
+	// encode instruction data
+	buf := new(bytes.Buffer)
+	if err := binary.Write(
+		buf,
+		binary.LittleEndian,
+		updateCommissionInstructionData{
+			Instruction: UpdateCommissionInstruction,
+			Commission:  params.Commission,
+		},
+	); err != nil {
+		return nil, fmt.Errorf("error encoding update commission data: %w", err)
+	}
+
+	// construct and return instruction
+	return []solana.Instruction{
+		{
+			InstructionAccountMeta: []solana.InstructionAccountMeta{
+				{PubKey: params.VotePubkey, IsSigner: false, IsWritable: true},
+				{PubKey: params.AuthorizedWithdrawerPubkey, IsSigner: true, IsWritable: false},
+			},
+			ProgramIDPubKey: ID,
+			Data:            buf.Bytes(),
+		},
+	}, nil
+}
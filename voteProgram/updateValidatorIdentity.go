@@ -0,0 +1,49 @@
+package voteProgram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/BRBussy/solgo"
+)
+
+type UpdateValidatorIdentityParams struct {
+	// VotePubkey is the vote account whose validator identity is being updated
+	// Req: [writer]
+	VotePubkey solana.PublicKey
+
+	// NodePubkey is the new validator identity
+	// Req: [signer]
+	NodePubkey solana.PublicKey
+
+	// AuthorizedWithdrawerPubkey is the withdraw authority of the vote account
+	// Req: [signer]
+	AuthorizedWithdrawerPubkey solana.PublicKey
+}
+
+// UpdateValidatorIdentity creates a Solana vote program Instruction to
+// update the validator identity (node pubkey) of a vote account
+func UpdateValidatorIdentity(params UpdateValidatorIdentityParams) ([]solana.Instruction, error) {
+	// encode instruction data
+	buf := new(bytes.Buffer)
+	if err := binary.Write(
+		buf,
+		binary.LittleEndian,
+		UpdateValidatorIdentityInstruction,
+	); err != nil {
+		return nil, fmt.Errorf("error encoding update validator identity data: %w", err)
+	}
+
+	// construct and return instruction
+	return []solana.Instruction{
+		{
+			InstructionAccountMeta: []solana.InstructionAccountMeta{
+				{PubKey: params.VotePubkey, IsSigner: false, IsWritable: true},
+				{PubKey: params.NodePubkey, IsSigner: true, IsWritable: false},
+				{PubKey: params.AuthorizedWithdrawerPubkey, IsSigner: true, IsWritable: false},
+			},
+			ProgramIDPubKey: ID,
+			Data:            buf.Bytes(),
+		},
+	}, nil
+}
@@ -0,0 +1,60 @@
+package voteProgram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/BRBussy/solgo"
+)
+
+type WithdrawParams struct {
+	// VotePubkey is the vote account from which Lamports will be withdrawn
+	// Req: [writer]
+	VotePubkey solana.PublicKey
+
+	// AuthorizedWithdrawerPubkey is the withdraw authority of the vote account
+	// Req: [signer]
+	AuthorizedWithdrawerPubkey solana.PublicKey
+
+	// ToPubkey is the account to which the Lamports will be transferred
+	// Req: [writer]
+	ToPubkey solana.PublicKey
+
+	// Lamports is the amount of Lamports to withdraw
+	Lamports uint64
+}
+
+type withdrawInstructionData struct {
+	Instruction Instruction
+	Lamports    uint64
+}
+
+// Withdraw creates a Solana vote program Instruction to withdraw
+// Lamports from a vote account
+func Withdraw(params WithdrawParams) ([]solana.Instruction, error) {
+	// encode instruction data
+	buf := new(bytes.Buffer)
+	if err := binary.Write(
+		buf,
+		binary.LittleEndian,
+		withdrawInstructionData{
+			Instruction: WithdrawInstruction,
+			Lamports:    params.Lamports,
+		},
+	); err != nil {
+		return nil, fmt.Errorf("error encoding withdraw data: %w", err)
+	}
+
+	// construct and return instruction
+	return []solana.Instruction{
+		{
+			InstructionAccountMeta: []solana.InstructionAccountMeta{
+				{PubKey: params.VotePubkey, IsSigner: false, IsWritable: true},
+				{PubKey: params.ToPubkey, IsSigner: false, IsWritable: true},
+				{PubKey: params.AuthorizedWithdrawerPubkey, IsSigner: true, IsWritable: false},
+			},
+			ProgramIDPubKey: ID,
+			Data:            buf.Bytes(),
+		},
+	}, nil
+}